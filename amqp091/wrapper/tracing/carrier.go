@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/rmscoal/tengcorux/tracer"
+)
+
+// HeadersCarrier adapts the amqp.Table of the message headers to satisfy
+// the tracer.Carrier interface.
+type HeadersCarrier amqp.Table
+
+// Make sure that HeadersCarrier implements [tracer.Carrier] during compile
+// time.
+var _ tracer.Carrier = HeadersCarrier{}
+
+// Get returns the value associated with the given key, if it is a string.
+func (hc HeadersCarrier) Get(key string) string {
+	switch value := hc[key].(type) {
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return ""
+	}
+}
+
+// Set stores the key-value pair into the headers.
+func (hc HeadersCarrier) Set(key string, value string) {
+	hc[key] = value
+}
+
+// Keys lists the keys stored in the headers.
+func (hc HeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
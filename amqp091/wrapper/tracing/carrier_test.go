@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"sort"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestHeadersCarrier(t *testing.T) {
+	headers := amqp.Table{"bytes": []byte("1"), "int": int32(2)}
+	carrier := HeadersCarrier(headers)
+
+	carrier.Set("string", "3")
+
+	tests := map[string]string{
+		"bytes":   "1",
+		"int":     "",
+		"string":  "3",
+		"missing": "",
+	}
+	for key, want := range tests {
+		if got := carrier.Get(key); got != want {
+			t.Errorf("%s: want %q, got %q", key, want, got)
+		}
+	}
+
+	keys := carrier.Keys()
+	sort.Strings(keys)
+	if len(keys) != 3 || keys[0] != "bytes" || keys[2] != "string" {
+		t.Errorf("unexpected keys %v", keys)
+	}
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// DeliveryHandler handles a delivered message. The context holds the entry
+// span of the delivery and its request id.
+type DeliveryHandler func(ctx context.Context, delivery amqp.Delivery) error
+
+// NewDeliveryHandler wraps the handler such that every delivery is handled
+// within an entry span. The span continues the trace propagated by the
+// message headers, the request id is taken from the X-Request-Id header or
+// generated and injected into the context. Acknowledging the delivery is
+// left to the handler. For example:
+//
+//	deliveries, err := ch.ConsumeWithContext(ctx, "orders", "billing",
+//		false, false, false, false, nil)
+//	if err != nil {
+//		return err
+//	}
+//	handle := tracing.NewDeliveryHandler(handleOrder)
+//	for delivery := range deliveries {
+//		if err := handle(ctx, delivery); err != nil {
+//			_ = delivery.Nack(false, true)
+//			continue
+//		}
+//		_ = delivery.Ack(false)
+//	}
+func NewDeliveryHandler(handler DeliveryHandler, opts ...Option) DeliveryHandler {
+	c := newConfig(opts...)
+
+	return func(ctx context.Context, delivery amqp.Delivery) (err error) {
+		carrier := HeadersCarrier(delivery.Headers)
+		if propagator, ok := c.getTracer().(tracer.Propagator); ok {
+			ctx = propagator.Extract(ctx, carrier)
+		}
+		if requestID := carrier.Get(reqid.HeaderKey); requestID != "" {
+			ctx = reqid.InjectValue(ctx, requestID)
+		} else {
+			ctx = reqid.Inject(ctx)
+		}
+
+		ctx, span := c.startSpan(ctx, delivery.Exchange, delivery.RoutingKey,
+			tracer.SpanTypeEntry)
+		span.SetAttributes(
+			attribute.MQDeliveryTag(delivery.DeliveryTag),
+			attribute.RequestID(reqid.RetrieveFromContext(ctx)),
+		)
+		if delivery.ConsumerTag != "" {
+			span.SetAttributes(attribute.MQSubscriber(delivery.ConsumerTag))
+		}
+		if delivery.MessageId != "" {
+			span.SetAttributes(attribute.MQMessageID(delivery.MessageId))
+		}
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err := fmt.Errorf("panic: %v", recovered)
+				finishSpan(span, err)
+				panic(recovered)
+			}
+			finishSpan(span, err)
+		}()
+
+		return handler(ctx, delivery)
+	}
+}
@@ -0,0 +1,143 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"github.com/rmscoal/tengcorux/tracer/tracetest"
+)
+
+func TestNewDeliveryHandler(t *testing.T) {
+	t.Run("Propagation", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		handle := NewDeliveryHandler(func(ctx context.Context, delivery amqp.Delivery) error {
+			if reqid.RetrieveFromContext(ctx) != "request-id" {
+				t.Error("expected the request id to be injected")
+			}
+			if tr.SpanFromContext(ctx) == nil {
+				t.Error("expected the span to be in the context")
+			}
+			return nil
+		}, WithTracer(tr))
+
+		err := handle(context.Background(), amqp.Delivery{
+			Headers: amqp.Table{
+				tracetest.TraceIDKey: "7",
+				tracetest.SpanIDKey:  []byte("3"),
+				reqid.HeaderKey:      "request-id",
+			},
+			Exchange:    "orders",
+			RoutingKey:  "orders.created",
+			DeliveryTag: 5,
+			ConsumerTag: "billing",
+			MessageId:   "msg-1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Name != "RabbitMQ/orders/orders.created/Consumer" {
+			t.Errorf("unexpected span name %s", span.Name)
+		} else if span.Type != tracer.SpanTypeEntry || span.Layer != tracer.SpanLayerMQ {
+			t.Errorf("expected an entry mq span, got %d %d", span.Type, span.Layer)
+		} else if span.TraceID != 7 || span.ParentSpanID != 3 {
+			t.Errorf("expected the publisher trace to be continued, got %d/%d",
+				span.TraceID, span.ParentSpanID)
+		}
+
+		tests := map[attribute.Key]attribute.Value{
+			attribute.MQSystemKey:      attribute.StringValue("rabbitmq"),
+			attribute.MQExchangeKey:    attribute.StringValue("orders"),
+			attribute.MQRoutingKeyKey:  attribute.StringValue("orders.created"),
+			attribute.MQDeliveryTagKey: attribute.Int64Value(5),
+			attribute.MQSubscriberKey:  attribute.StringValue("billing"),
+			attribute.MQMessageIDKey:   attribute.StringValue("msg-1"),
+			attribute.RequestIDKey:     attribute.StringValue("request-id"),
+		}
+		for key, want := range tests {
+			if got, _ := span.Attribute(key); got != want {
+				t.Errorf("%s: want %v, got %v", key, want, got)
+			}
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		handle := NewDeliveryHandler(func(ctx context.Context, delivery amqp.Delivery) error {
+			return errors.New("some_error")
+		}, WithTracer(tr))
+		if err := handle(context.Background(), amqp.Delivery{}); err == nil {
+			t.Fatal("expected the handler error")
+		}
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Error == nil || span.Status != tracer.StatusError {
+			t.Error("expected the error to fail the span")
+		}
+		if got, _ := span.Attribute(attribute.RequestIDKey); got.AsString() == "" {
+			t.Error("expected a request id to be generated")
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		handle := NewDeliveryHandler(func(ctx context.Context, delivery amqp.Delivery) error {
+			panic("boom")
+		}, WithTracer(tr))
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected the panic to be propagated")
+				}
+			}()
+			_ = handle(context.Background(), amqp.Delivery{})
+		}()
+
+		if span := tr.Recorder().EndedSpans()[0]; span.Status != tracer.StatusError {
+			t.Errorf("expected error status, got %s", span.Status)
+		}
+	})
+}
+
+func TestPublishConsume(t *testing.T) {
+	tr := tracetest.NewTracer()
+	fake := &fakePublisher{}
+	publisher := WrapPublisher(fake, WithTracer(tr))
+
+	ctx := reqid.InjectValue(context.Background(), "request-id")
+	err := publisher.PublishWithContext(ctx, "orders", "orders.created",
+		false, false, amqp.Publishing{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handle := NewDeliveryHandler(func(ctx context.Context, delivery amqp.Delivery) error {
+		if reqid.RetrieveFromContext(ctx) != "request-id" {
+			t.Error("expected the request id to be propagated")
+		}
+		return nil
+	}, WithTracer(tr))
+	err = handle(context.Background(), amqp.Delivery{
+		Headers:    fake.published[0].Headers,
+		Exchange:   "orders",
+		RoutingKey: "orders.created",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := tr.Recorder().EndedSpans()
+	publisherSpan, consumerSpan := spans[0], spans[1]
+	if consumerSpan.TraceID != publisherSpan.TraceID ||
+		consumerSpan.ParentSpanID != publisherSpan.SpanID {
+		t.Error("expected the consumer to continue the publisher trace")
+	}
+}
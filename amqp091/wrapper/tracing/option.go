@@ -0,0 +1,23 @@
+package tracing
+
+import (
+	"github.com/rmscoal/tengcorux/tracer"
+)
+
+type Option func(*config)
+
+// WithTracer uses the given tracer instead of the global tracer.
+func WithTracer(t tracer.Tracer) Option {
+	return func(c *config) {
+		c.tracer = t
+	}
+}
+
+// WithInstance records the given server address, or instance ID, of the
+// message queue. It is also recorded as the server address and port of the
+// spans.
+func WithInstance(instance string) Option {
+	return func(c *config) {
+		c.instance = instance
+	}
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// Publisher publishes messages, as done by *amqp.Channel.
+type Publisher interface {
+	PublishWithContext(ctx context.Context, exchange, key string,
+		mandatory, immediate bool, msg amqp.Publishing) error
+}
+
+// Make sure that *amqp.Channel implements [Publisher] during compile time.
+var _ Publisher = (*amqp.Channel)(nil)
+
+// WrapPublisher returns a Publisher publishing every message within an exit
+// span. The trace context and the request id found in the context are
+// propagated through the message headers. For example:
+//
+//	ch, err := conn.Channel()
+//	if err != nil {
+//		return err
+//	}
+//	publisher := tracing.WrapPublisher(ch)
+//	err = publisher.PublishWithContext(ctx, "orders", "orders.created",
+//		false, false, amqp.Publishing{Body: body})
+func WrapPublisher(publisher Publisher, opts ...Option) Publisher {
+	return &tracingPublisher{Publisher: publisher, cfg: newConfig(opts...)}
+}
+
+type tracingPublisher struct {
+	Publisher
+	cfg *config
+}
+
+// PublishWithContext publishes the message within a span continuing the
+// trace found in ctx.
+func (p *tracingPublisher) PublishWithContext(ctx context.Context, exchange, key string,
+	mandatory, immediate bool, msg amqp.Publishing,
+) error {
+	ctx, span := p.cfg.startSpan(ctx, exchange, key, tracer.SpanTypeExit)
+	if msg.MessageId != "" {
+		span.SetAttributes(attribute.MQMessageID(msg.MessageId))
+	}
+
+	// The headers are copied since the table may be shared by the caller.
+	headers := make(amqp.Table, len(msg.Headers)+3)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	if propagator, ok := p.cfg.getTracer().(tracer.Propagator); ok {
+		propagator.Inject(ctx, HeadersCarrier(headers))
+	}
+	if requestID := reqid.RetrieveFromContext(ctx); requestID != "" {
+		headers[reqid.HeaderKey] = requestID
+		span.SetAttributes(attribute.RequestID(requestID))
+	}
+	msg.Headers = headers
+
+	err := p.Publisher.PublishWithContext(ctx, exchange, key, mandatory, immediate, msg)
+	finishSpan(span, err)
+	return err
+}
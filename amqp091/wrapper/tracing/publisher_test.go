@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"github.com/rmscoal/tengcorux/tracer/tracetest"
+)
+
+// fakePublisher records the published messages instead of sending them.
+type fakePublisher struct {
+	published []amqp.Publishing
+	err       error
+}
+
+func (p *fakePublisher) PublishWithContext(_ context.Context, _, _ string,
+	_, _ bool, msg amqp.Publishing,
+) error {
+	p.published = append(p.published, msg)
+	return p.err
+}
+
+func TestWrapPublisher(t *testing.T) {
+	t.Run("Propagation", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		fake := &fakePublisher{}
+		publisher := WrapPublisher(fake, WithTracer(tr), WithInstance("rabbitmq:5672"))
+
+		headers := amqp.Table{"tenant": "acme"}
+		ctx := reqid.InjectValue(context.Background(), "request-id")
+		err := publisher.PublishWithContext(ctx, "orders", "orders.created",
+			false, false, amqp.Publishing{MessageId: "msg-1", Headers: headers})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Name != "RabbitMQ/orders/orders.created/Producer" {
+			t.Errorf("unexpected span name %s", span.Name)
+		} else if span.Type != tracer.SpanTypeExit || span.Layer != tracer.SpanLayerMQ {
+			t.Errorf("expected an exit mq span, got %d %d", span.Type, span.Layer)
+		}
+
+		carrier := HeadersCarrier(fake.published[0].Headers)
+		if carrier.Get(tracetest.SpanIDKey) != strconv.FormatUint(span.SpanID, 10) {
+			t.Error("expected the span context to be injected into the headers")
+		}
+		if carrier.Get(reqid.HeaderKey) != "request-id" {
+			t.Error("expected the request id to be injected into the headers")
+		}
+		if carrier.Get("tenant") != "acme" {
+			t.Error("expected the original headers to be kept")
+		}
+		if len(headers) != 1 {
+			t.Error("expected the caller headers to not be modified")
+		}
+
+		tests := map[attribute.Key]attribute.Value{
+			attribute.MQSystemKey:          attribute.StringValue("rabbitmq"),
+			attribute.MQExchangeKey:        attribute.StringValue("orders"),
+			attribute.MQRoutingKeyKey:      attribute.StringValue("orders.created"),
+			attribute.MQInstanceIDKey:      attribute.StringValue("rabbitmq:5672"),
+			attribute.HTTPServerAddressKey: attribute.StringValue("rabbitmq"),
+			attribute.HTTPServerPortKey:    attribute.IntValue(5672),
+			attribute.MQMessageIDKey:       attribute.StringValue("msg-1"),
+			attribute.RequestIDKey:         attribute.StringValue("request-id"),
+		}
+		for key, want := range tests {
+			if got, _ := span.Attribute(key); got != want {
+				t.Errorf("%s: want %v, got %v", key, want, got)
+			}
+		}
+	})
+
+	t.Run("Instance ID", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		publisher := WrapPublisher(&fakePublisher{}, WithTracer(tr),
+			WithInstance("rabbitmq-cluster"))
+		err := publisher.PublishWithContext(context.Background(), "orders", "",
+			false, false, amqp.Publishing{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		span := tr.Recorder().EndedSpans()[0]
+		if got, _ := span.Attribute(attribute.HTTPServerAddressKey); got.AsString() != "rabbitmq-cluster" {
+			t.Errorf("expected the instance to be the server address, got %v", got)
+		}
+		if _, ok := span.Attribute(attribute.HTTPServerPortKey); ok {
+			t.Error("expected no server port")
+		}
+	})
+
+	t.Run("Default Exchange", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		publisher := WrapPublisher(&fakePublisher{}, WithTracer(tr))
+		err := publisher.PublishWithContext(context.Background(), "", "orders",
+			false, false, amqp.Publishing{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if name := tr.Recorder().EndedSpans()[0].Name; name != "RabbitMQ/amq.default/orders/Producer" {
+			t.Errorf("unexpected span name %s", name)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		publisher := WrapPublisher(&fakePublisher{err: amqp.ErrClosed}, WithTracer(tr))
+		err := publisher.PublishWithContext(context.Background(), "orders", "",
+			false, false, amqp.Publishing{})
+		if !errors.Is(err, amqp.ErrClosed) {
+			t.Fatalf("expected the publisher error, got %v", err)
+		}
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Error == nil || span.Status != tracer.StatusError {
+			t.Error("expected the error to fail the span")
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// defaultExchange names the default exchange, whose name is empty, within
+// the span names.
+const defaultExchange = "amq.default"
+
+// config holds the configuration shared by the wrappers.
+type config struct {
+	tracer   tracer.Tracer
+	instance string
+}
+
+func newConfig(opts ...Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *config) getTracer() tracer.Tracer {
+	if c.tracer != nil {
+		return c.tracer
+	}
+	return tracer.GetGlobalTracer()
+}
+
+// startSpan starts the span of a message routed by the exchange and the
+// routing key, recording the attributes shared by the publisher and
+// consumer spans. The instance is also recorded as the server address and
+// port, the peer of the span.
+func (c *config) startSpan(ctx context.Context, exchange, routingKey string,
+	spanType tracer.SpanType,
+) (context.Context, tracer.Span) {
+	name := exchange
+	if name == "" {
+		name = defaultExchange
+	}
+	if spanType == tracer.SpanTypeExit {
+		name = "RabbitMQ/" + name + "/" + routingKey + "/Producer"
+	} else {
+		name = "RabbitMQ/" + name + "/" + routingKey + "/Consumer"
+	}
+
+	ctx, span := c.getTracer().StartSpan(ctx, name,
+		tracer.WithSpanType(spanType),
+		tracer.WithSpanLayer(tracer.SpanLayerMQ),
+	)
+
+	span.SetAttributes(
+		attribute.MQSystem("rabbitmq"),
+		attribute.MQExchange(exchange),
+		attribute.MQRoutingKey(routingKey),
+	)
+	if c.instance != "" {
+		span.SetAttributes(attribute.MQInstanceID(c.instance))
+		span.SetAttributes(peerAttributes(c.instance)...)
+	}
+	return ctx, span
+}
+
+// peerAttributes returns the server.address and server.port attributes of
+// the instance, either an address such as "rabbitmq:5672" or an instance
+// ID recorded as the server address as is.
+func peerAttributes(instance string) []attribute.KeyValue {
+	host, port, err := net.SplitHostPort(instance)
+	if err != nil {
+		return []attribute.KeyValue{attribute.HTTPServerAddress(instance)}
+	}
+	attrs := []attribute.KeyValue{attribute.HTTPServerAddress(host)}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, attribute.HTTPServerPort(p))
+	}
+	return attrs
+}
+
+// finishSpan records the error, if any, and ends the span.
+func finishSpan(span tracer.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(tracer.StatusError, err.Error())
+	}
+	span.End()
+}
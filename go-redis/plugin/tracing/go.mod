@@ -5,10 +5,15 @@ go 1.21
 require (
 	github.com/redis/go-redis/extra/rediscmd/v9 v9.7.0
 	github.com/redis/go-redis/v9 v9.7.0
-	github.com/rmscoal/tengcorux/tracer v0.1.4
+	github.com/rmscoal/tengcorux/metric v0.0.0-20261017174934-22fc87409d0a
+	github.com/rmscoal/tengcorux/tracer v0.1.5-0.20261017175312-3ec99204e181
 )
 
 require (
 	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 )
+
+replace github.com/rmscoal/tengcorux/tracer => ../../../tracer
+
+replace github.com/rmscoal/tengcorux/metric => ../../../metric
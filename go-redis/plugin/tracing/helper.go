@@ -1,11 +1,24 @@
 package tracing
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rmscoal/tengcorux/tracer"
 )
 
+// recordError records the error to the span and marks the span as failed,
+// unless the error is redis.Nil which merely tells that the key is missing.
+func recordError(span tracer.Span, err error) {
+	span.RecordError(err)
+	if !errors.Is(err, redis.Nil) {
+		span.SetStatus(tracer.StatusError, err.Error())
+	}
+}
+
 // funcFileLine finds a pkg in the runtime caller.
 func funcFileLine(pkg string) (string, string, int) {
 	const depth = 16
@@ -0,0 +1,354 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rmscoal/tengcorux/metric"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+const (
+	// defaultPoolStatsInterval is the default interval of sampling the
+	// redis.PoolStats.
+	defaultPoolStatsInterval = 10 * time.Second
+
+	// pipelineOperation is the db.operation of a pipeline.
+	pipelineOperation = "pipeline"
+)
+
+// Metrics is a go-redis hook recording the latency and the errors of the
+// processed commands.
+type Metrics struct {
+	attributes  []attribute.KeyValue
+	instruments lazy[*commandInstruments]
+}
+
+// commandInstruments holds the instruments of the processed commands.
+type commandInstruments struct {
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+// lazy creates a value from the meter of WithMeter, else from the global
+// meter at the first use of the value. Hence, the instrumentation may be
+// registered before the global meter is set.
+type lazy[T any] struct {
+	meter  metric.Meter
+	create func(metric.Meter) (T, error)
+
+	once  sync.Once
+	value T
+	err   error
+}
+
+// get returns the value, or the error of its creation.
+func (l *lazy[T]) get() (T, error) {
+	l.once.Do(func() {
+		meter := l.meter
+		if meter == nil {
+			meter = metric.GetGlobalMeter()
+		}
+		l.value, l.err = l.create(meter)
+	})
+	return l.value, l.err
+}
+
+// eager creates the value right away when the meter is given by WithMeter,
+// such that its error is returned to the caller.
+func (l *lazy[T]) eager() error {
+	if l.meter == nil {
+		return nil
+	}
+	_, err := l.get()
+	return err
+}
+
+type metricsConfig struct {
+	meter             metric.Meter
+	attributes        []attribute.KeyValue
+	poolStatsInterval time.Duration
+}
+
+type MetricsOption func(*metricsConfig)
+
+// WithMeter sets the meter creating the instruments. Defaults to the global
+// meter at the first command or sample.
+func WithMeter(meter metric.Meter) MetricsOption {
+	return func(cfg *metricsConfig) {
+		cfg.meter = meter
+	}
+}
+
+// WithMetricsAttributes adds given attributes to the measurements later on.
+func WithMetricsAttributes(attrs ...attribute.KeyValue) MetricsOption {
+	return func(cfg *metricsConfig) {
+		cfg.attributes = append(cfg.attributes, attrs...)
+	}
+}
+
+// WithPoolStatsInterval sets the interval of sampling the redis.PoolStats.
+// Defaults to 10 seconds.
+func WithPoolStatsInterval(interval time.Duration) MetricsOption {
+	return func(cfg *metricsConfig) {
+		if interval > 0 {
+			cfg.poolStatsInterval = interval
+		}
+	}
+}
+
+func newMetricsConfig(opts []MetricsOption) *metricsConfig {
+	cfg := &metricsConfig{
+		attributes:        []attribute.KeyValue{attribute.DBSystem("redis")},
+		poolStatsInterval: defaultPoolStatsInterval,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// NewMetricsHook creates a new Metrics instance.
+func NewMetricsHook(opts ...MetricsOption) (*Metrics, error) {
+	return newMetricsHook(newMetricsConfig(opts))
+}
+
+func newMetricsHook(cfg *metricsConfig) (*Metrics, error) {
+	m := &Metrics{
+		attributes: cfg.attributes,
+		instruments: lazy[*commandInstruments]{
+			meter:  cfg.meter,
+			create: newCommandInstruments,
+		},
+	}
+	if err := m.instruments.eager(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func newCommandInstruments(meter metric.Meter) (*commandInstruments, error) {
+	duration, err := meter.Float64Histogram("db.client.operation.duration",
+		metric.WithDescription("Duration of redis commands."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	errs, err := meter.Int64Counter("db.client.operation.errors",
+		metric.WithDescription("Number of failed redis commands."),
+		metric.WithUnit("{command}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commandInstruments{duration: duration, errors: errs}, nil
+}
+
+func (m *Metrics) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (m *Metrics) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		m.record(ctx, cmd.Name(), start, err)
+		return err
+	}
+}
+
+func (m *Metrics) ProcessPipelineHook(
+	next redis.ProcessPipelineHook,
+) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		m.record(ctx, pipelineOperation, start, err)
+		return err
+	}
+}
+
+// record records the latency of the operation, and counts the error unless
+// it is redis.Nil which merely tells that the key is missing.
+func (m *Metrics) record(ctx context.Context, operation string, start time.Time, err error) {
+	instruments, ierr := m.instruments.get()
+	if ierr != nil {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(m.attributes)+1)
+	attrs = append(attrs, m.attributes...)
+	attrs = append(attrs, attribute.DBOperation(operation))
+
+	instruments.duration.Record(ctx, time.Since(start).Seconds(),
+		metric.WithAttributes(attrs...))
+	if err != nil && !errors.Is(err, redis.Nil) {
+		instruments.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+}
+
+// poolStatsSampler records the redis.PoolStats of a client. The hits, misses
+// and timeouts are cumulative within redis, hence only their increase since
+// the previous sample is added to the counters.
+type poolStatsSampler struct {
+	stats       func() *redis.PoolStats
+	attributes  []attribute.KeyValue
+	previous    redis.PoolStats
+	instruments lazy[*poolStatsInstruments]
+}
+
+// poolStatsInstruments holds the instruments of the redis.PoolStats.
+type poolStatsInstruments struct {
+	hits       metric.Int64Counter
+	misses     metric.Int64Counter
+	timeouts   metric.Int64Counter
+	idleConns  metric.Int64Gauge
+	totalConns metric.Int64Gauge
+}
+
+func newPoolStatsSampler(
+	cfg *metricsConfig, stats func() *redis.PoolStats,
+) (*poolStatsSampler, error) {
+	s := &poolStatsSampler{
+		stats:      stats,
+		attributes: cfg.attributes,
+		instruments: lazy[*poolStatsInstruments]{
+			meter:  cfg.meter,
+			create: newPoolStatsInstruments,
+		},
+	}
+	if err := s.instruments.eager(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func newPoolStatsInstruments(meter metric.Meter) (*poolStatsInstruments, error) {
+	i := &poolStatsInstruments{}
+
+	var err error
+	if i.hits, err = meter.Int64Counter("db.client.connections.hits",
+		metric.WithDescription("Number of times a free connection was found in the pool."),
+	); err != nil {
+		return nil, err
+	}
+	if i.misses, err = meter.Int64Counter("db.client.connections.misses",
+		metric.WithDescription("Number of times a free connection was not found in the pool."),
+	); err != nil {
+		return nil, err
+	}
+	if i.timeouts, err = meter.Int64Counter("db.client.connections.timeouts",
+		metric.WithDescription("Number of times waiting for a connection timed out."),
+	); err != nil {
+		return nil, err
+	}
+	if i.idleConns, err = meter.Int64Gauge("db.client.connections.idle",
+		metric.WithDescription("Number of idle connections in the pool."),
+		metric.WithUnit("{connection}"),
+	); err != nil {
+		return nil, err
+	}
+	if i.totalConns, err = meter.Int64Gauge("db.client.connections.total",
+		metric.WithDescription("Number of total connections in the pool."),
+		metric.WithUnit("{connection}"),
+	); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// sample records the current redis.PoolStats.
+func (s *poolStatsSampler) sample(ctx context.Context) {
+	instruments, err := s.instruments.get()
+	if err != nil {
+		return
+	}
+	stats := s.stats()
+	if stats == nil {
+		return
+	}
+
+	opt := metric.WithAttributes(s.attributes...)
+	instruments.hits.Add(ctx, increase(s.previous.Hits, stats.Hits), opt)
+	instruments.misses.Add(ctx, increase(s.previous.Misses, stats.Misses), opt)
+	instruments.timeouts.Add(ctx, increase(s.previous.Timeouts, stats.Timeouts), opt)
+	instruments.idleConns.Record(ctx, int64(stats.IdleConns), opt)
+	instruments.totalConns.Record(ctx, int64(stats.TotalConns), opt)
+	s.previous = *stats
+}
+
+// run samples the redis.PoolStats every interval until ctx is done.
+func (s *poolStatsSampler) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sample(ctx)
+		}
+	}
+}
+
+// increase returns the increase of a cumulative value. A lower current value
+// means the value was reset, such as a removed node, thus counting from zero.
+func increase(previous, current uint32) int64 {
+	if current < previous {
+		return int64(current)
+	}
+	return int64(current - previous)
+}
+
+// InstrumentMetrics registers the metrics hook given the redis instance and
+// samples its redis.PoolStats every interval until ctx is done. Both are
+// labelled with the redis.client_type of the instance. The hook is added to
+// the cluster and ring themselves rather than to their nodes, hence the
+// commands are not recorded twice.
+func InstrumentMetrics(
+	ctx context.Context, rd redis.UniversalClient, opts ...MetricsOption,
+) error {
+	var clientType string
+	switch rd.(type) {
+	case *redis.Client:
+		clientType = "client"
+	case *redis.ClusterClient:
+		clientType = "cluster"
+	case *redis.Ring:
+		clientType = "ring"
+	default:
+		return fmt.Errorf("tracing: unsupported redis client type: %T", rd)
+	}
+
+	options := append([]MetricsOption(nil), opts...)
+	options = append(options, WithMetricsAttributes(
+		attribute.KeyValuePair("redis.client_type", clientType)))
+
+	cfg := newMetricsConfig(options)
+	hook, err := newMetricsHook(cfg)
+	if err != nil {
+		return err
+	}
+
+	sampler, err := newPoolStatsSampler(cfg, rd.PoolStats)
+	if err != nil {
+		return err
+	}
+
+	rd.AddHook(hook)
+	go sampler.run(ctx, cfg.poolStatsInterval)
+
+	return nil
+}
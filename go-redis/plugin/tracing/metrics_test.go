@@ -0,0 +1,237 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rmscoal/tengcorux/metric"
+	"github.com/rmscoal/tengcorux/metric/metrictest"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+func TestMetricsHook_ProcessHook(t *testing.T) {
+	meter := metrictest.NewMeter()
+	hook, err := NewMetricsHook(WithMeter(meter),
+		WithMetricsAttributes(attribute.KeyValuePair("ho", "ok")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	t.Run("Success", func(t *testing.T) {
+		processHook := hook.ProcessHook(func(context.Context, redis.Cmder) error {
+			return nil
+		})
+		_ = processHook(ctx, redis.NewCmd(ctx, "ping"))
+
+		ping := []attribute.KeyValue{
+			attribute.DBSystem("redis"),
+			attribute.DBOperation("ping"),
+			attribute.KeyValuePair("ho", "ok"),
+		}
+		if count := meter.Count("db.client.operation.duration", ping...); count != 1 {
+			t.Errorf("expected 1 duration but got %d", count)
+		}
+		if count := meter.Count("db.client.operation.errors"); count != 0 {
+			t.Errorf("expected no errors but got %d", count)
+		}
+	})
+
+	t.Run("redis.Nil", func(t *testing.T) {
+		processHook := hook.ProcessHook(func(context.Context, redis.Cmder) error {
+			return redis.Nil
+		})
+		_ = processHook(ctx, redis.NewStringCmd(ctx, "get", "key"))
+
+		get := attribute.DBOperation("get")
+		if count := meter.Count("db.client.operation.duration", get); count != 1 {
+			t.Errorf("expected 1 duration but got %d", count)
+		}
+		if count := meter.Count("db.client.operation.errors", get); count != 0 {
+			t.Errorf("expected redis.Nil not to be counted but got %d", count)
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		processHook := hook.ProcessHook(func(context.Context, redis.Cmder) error {
+			return errors.New("connection refused")
+		})
+		err := processHook(ctx, redis.NewStringCmd(ctx, "set", "key", "value"))
+		if err == nil {
+			t.Error("expected error to be returned")
+		}
+
+		set := attribute.DBOperation("set")
+		if sum := meter.Sum("db.client.operation.errors", set); sum != 1 {
+			t.Errorf("expected 1 error but got %v", sum)
+		}
+	})
+}
+
+func TestMetricsHook_ProcessPipelineHook(t *testing.T) {
+	meter := metrictest.NewMeter()
+	hook, err := NewMetricsHook(WithMeter(meter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	processPipelineHook := hook.ProcessPipelineHook(func(
+		context.Context, []redis.Cmder,
+	) error {
+		return errors.New("connection refused")
+	})
+	_ = processPipelineHook(ctx, []redis.Cmder{
+		redis.NewCmd(ctx, "ping"),
+		redis.NewStringCmd(ctx, "get", "key"),
+	})
+
+	pipeline := attribute.DBOperation(pipelineOperation)
+	if count := meter.Count("db.client.operation.duration", pipeline); count != 1 {
+		t.Errorf("expected 1 duration but got %d", count)
+	}
+	if sum := meter.Sum("db.client.operation.errors", pipeline); sum != 1 {
+		t.Errorf("expected 1 error but got %v", sum)
+	}
+}
+
+func TestMetricsHook_GlobalMeter(t *testing.T) {
+	hook, err := NewMetricsHook()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meter := metrictest.NewMeter()
+	metric.SetGlobalMeter(meter)
+	defer metric.SetGlobalMeter(&metric.NoopMeter{})
+
+	ctx := context.TODO()
+	processHook := hook.ProcessHook(func(context.Context, redis.Cmder) error {
+		return nil
+	})
+	_ = processHook(ctx, redis.NewCmd(ctx, "ping"))
+
+	if count := meter.Count("db.client.operation.duration"); count != 1 {
+		t.Errorf("expected the meter set after the hook to record 1 command but got %d",
+			count)
+	}
+}
+
+func TestPoolStatsSampler(t *testing.T) {
+	meter := metrictest.NewMeter()
+	stats := &redis.PoolStats{Hits: 3, Misses: 1, TotalConns: 2, IdleConns: 1}
+	sampler, err := newPoolStatsSampler(newMetricsConfig([]MetricsOption{
+		WithMeter(meter),
+	}), func() *redis.PoolStats {
+		return stats
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	sampler.sample(ctx)
+	stats = &redis.PoolStats{Hits: 5, Misses: 1, Timeouts: 1, TotalConns: 3, IdleConns: 0}
+	sampler.sample(ctx)
+
+	if sum := meter.Sum("db.client.connections.hits"); sum != 5 {
+		t.Errorf("expected 5 hits but got %v", sum)
+	}
+	if sum := meter.Sum("db.client.connections.misses"); sum != 1 {
+		t.Errorf("expected 1 miss but got %v", sum)
+	}
+	if sum := meter.Sum("db.client.connections.timeouts"); sum != 1 {
+		t.Errorf("expected 1 timeout but got %v", sum)
+	}
+	if last, _ := meter.Last("db.client.connections.idle"); last != 0 {
+		t.Errorf("expected 0 idle connections but got %v", last)
+	}
+	if last, _ := meter.Last("db.client.connections.total"); last != 3 {
+		t.Errorf("expected 3 total connections but got %v", last)
+	}
+
+	t.Run("Reset", func(t *testing.T) {
+		stats = &redis.PoolStats{Hits: 2}
+		sampler.sample(ctx)
+		if sum := meter.Sum("db.client.connections.hits"); sum != 7 {
+			t.Errorf("expected 7 hits but got %v", sum)
+		}
+	})
+}
+
+func TestInstrumentMetrics(t *testing.T) {
+	t.Run("Client", func(t *testing.T) {
+		meter := metrictest.NewMeter()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		rdb := redis.NewClient(&redis.Options{
+			Addr:       "127.0.0.1:1",
+			MaxRetries: -1,
+		})
+		defer rdb.Close()
+
+		err := InstrumentMetrics(ctx, rdb, WithMeter(meter),
+			WithPoolStatsInterval(time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := rdb.Ping(ctx).Err(); err == nil {
+			t.Fatal("expected ping to fail")
+		}
+
+		clientType := attribute.KeyValuePair("redis.client_type", "client")
+		if sum := meter.Sum("db.client.operation.errors", clientType,
+			attribute.DBOperation("ping")); sum != 1 {
+			t.Errorf("expected 1 error but got %v", sum)
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for meter.Count("db.client.connections.total", clientType) == 0 {
+			if time.Now().After(deadline) {
+				t.Fatal("expected the pool stats to be sampled")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+
+	t.Run("ClusterClient", func(t *testing.T) {
+		meter := metrictest.NewMeter()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		rdb := redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs: []string{"127.0.0.1:1"},
+		})
+		defer rdb.Close()
+
+		if err := InstrumentMetrics(ctx, rdb, WithMeter(meter)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Ring", func(t *testing.T) {
+		meter := metrictest.NewMeter()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		rdb := redis.NewRing(&redis.RingOptions{
+			Addrs: map[string]string{"shard": "127.0.0.1:1"},
+		})
+		defer rdb.Close()
+
+		if err := InstrumentMetrics(ctx, rdb, WithMeter(meter)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		if err := InstrumentMetrics(context.Background(), nil); err == nil {
+			t.Error("expected error for unsupported client")
+		}
+	})
+}
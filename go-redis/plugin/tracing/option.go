@@ -1,9 +1,6 @@
 package tracing
 
 import (
-	"net"
-	"strconv"
-
 	"github.com/rmscoal/tengcorux/tracer/attribute"
 )
 
@@ -16,14 +13,20 @@ func WithAttributes(attrs ...attribute.KeyValue) Option {
 	}
 }
 
-// WithConnectionString add connection string attribute.
+// WithConnectionString add connection string attribute, unless the address
+// is excluded by IncludeAddress.
 func WithConnectionString(str string) Option {
 	return func(tr *Tracing) {
-		if str != "" && tr.includeAddress {
-			tr.spanAttributes = append(tr.spanAttributes,
-				attribute.KeyValuePair("db.connection_string", str),
-			)
-		}
+		tr.connString = str
+	}
+}
+
+// WithRedactor replaces the default redactor applied to the span attributes
+// such as the command statements. By default, [attribute.DefaultRedactor] is
+// used and passing nil disables redaction.
+func WithRedactor(redactor *attribute.Redactor) Option {
+	return func(tr *Tracing) {
+		tr.redactor = redactor
 	}
 }
 
@@ -56,49 +59,19 @@ func WithClientType(t string) Option {
 	}
 }
 
-// WithServerAddress adds redis server address to attributes.
+// WithServerAddress adds the "server.address" and "server.port" attributes
+// of the given "host:port" redis address, unless the address is excluded by
+// IncludeAddress. They are the peer of the spans.
 func WithServerAddress(addr string) Option {
 	return func(tr *Tracing) {
-		if addr == "" {
-			return
-		} else if !tr.includeAddress {
-			return
-		}
-
-		host, portStr, err := net.SplitHostPort(addr)
-		if err != nil {
-			return
-		}
-		tr.spanAttributes = append(tr.spanAttributes,
-			attribute.KeyValuePair("server.host", host),
-		)
-
-		port, err := strconv.Atoi(portStr)
-		if err != nil {
-			return
-		}
-		tr.spanAttributes = append(tr.spanAttributes,
-			attribute.KeyValuePair("server.port", port),
-		)
+		tr.serverAddress = addr
 	}
 }
 
 // IncludeAddress determines to include redis address in span attributes.
+// Defaults to false, regardless of the order of the options.
 func IncludeAddress(on bool) Option {
 	return func(tr *Tracing) {
 		tr.includeAddress = on
-		if !on {
-			// Find spans that has server.host or server.port as key and
-			// remove them
-			for i := 0; i < len(tr.spanAttributes); i++ {
-				switch tr.spanAttributes[i].Key {
-				case "server.port", "server.host", "db.connection_string":
-					tr.spanAttributes = append(tr.spanAttributes[:i],
-						tr.spanAttributes[i+1:]...,
-					)
-					i--
-				}
-			}
-		}
 	}
 }
@@ -19,8 +19,11 @@ type Tracing struct {
 	spanAttributes    []attribute.KeyValue
 	spanStartOptions  []tracer.StartSpanOption
 	spanNameGenerator SpanNameGenerator
+	redactor          *attribute.Redactor
 
 	includeAddress bool
+	serverAddress  string
+	connString     string
 }
 
 func (tr *Tracing) DialHook(next redis.DialHook) redis.DialHook {
@@ -32,14 +35,13 @@ func (tr *Tracing) DialHook(next redis.DialHook) redis.DialHook {
 			if err != nil {
 				goto Dial
 			}
-			attrs = append(attrs,
-				attribute.KeyValuePair("server.address", host))
+			attrs = append(attrs, attribute.HTTPServerAddress(host))
 
 			port, err := strconv.Atoi(portStr)
 			if err != nil {
 				goto Dial
 			}
-			attrs = append(attrs, attribute.KeyValuePair("server.port", port))
+			attrs = append(attrs, attribute.HTTPServerPort(port))
 		}
 
 	Dial:
@@ -48,11 +50,11 @@ func (tr *Tracing) DialHook(next redis.DialHook) redis.DialHook {
 			tr.spanStartOptions...)
 		defer span.End()
 
-		span.SetAttributes(attrs...)
+		span.SetAttributes(tr.redactor.Redact(attrs...)...)
 
 		conn, err := next(ctx, network, addr)
 		if err != nil {
-			span.RecordError(err)
+			recordError(span, err)
 			return nil, err
 		}
 		return conn, nil
@@ -78,10 +80,10 @@ func (tr *Tracing) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
 			tr.spanStartOptions...)
 		defer span.End()
 
-		span.SetAttributes(attrs...)
+		span.SetAttributes(tr.redactor.Redact(attrs...)...)
 
 		if err := next(ctx, cmd); err != nil {
-			span.RecordError(err)
+			recordError(span, err)
 			return err
 		}
 
@@ -110,10 +112,10 @@ func (tr *Tracing) ProcessPipelineHook(
 			tr.spanStartOptions...)
 		defer span.End()
 
-		span.SetAttributes(attrs...)
+		span.SetAttributes(tr.redactor.Redact(attrs...)...)
 
 		if err := next(ctx, cmds); err != nil {
-			span.RecordError(err)
+			recordError(span, err)
 			return err
 		}
 
@@ -158,18 +160,43 @@ func NewHook(opts ...Option) *Tracing {
 			Process:         _defaultSpanNameProcessGenerator,
 			PipelineProcess: _defaultSpanNamePipelineGenerator,
 		},
+		redactor: attribute.DefaultRedactor(),
 	}
 
 	for _, opt := range opts {
 		opt(tr)
 	}
 
+	if tr.includeAddress {
+		tr.spanAttributes = append(tr.spanAttributes,
+			addressAttributes(tr.serverAddress, tr.connString)...)
+	}
+
 	return tr
 }
 
+// addressAttributes returns the server address and port of the "host:port"
+// addr and the connection string, skipping the empty or malformed ones.
+func addressAttributes(addr, connString string) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if host, portStr, err := net.SplitHostPort(addr); err == nil {
+		attrs = append(attrs, attribute.HTTPServerAddress(host))
+		if port, err := strconv.Atoi(portStr); err == nil {
+			attrs = append(attrs, attribute.HTTPServerPort(port))
+		}
+	}
+	if connString != "" {
+		attrs = append(attrs,
+			attribute.KeyValuePair("db.connection_string", connString))
+	}
+	return attrs
+}
+
 // InstrumentTracing automatically registers hook given the redis instance. It
 // also detects the client type such that on multi-nodes environment it is able
-// to register tracing with the OnNewNode hook.
+// to register tracing with the OnNewNode hook. When IncludeAddress is on, the
+// address of the client, or of each node, is recorded as the server address
+// of the spans, which tracers such as SkyWalking use as the peer.
 func InstrumentTracing(rd redis.UniversalClient, opts ...Option) error {
 	var options []Option
 	options = append(options, opts...)
@@ -179,37 +206,44 @@ func InstrumentTracing(rd redis.UniversalClient, opts ...Option) error {
 		redisOption := rd.Options()
 		connString := formatDBConnString(redisOption.Network, redisOption.Addr)
 		options = append(options, WithClientType("client"),
+			WithServerAddress(redisOption.Addr),
 			WithConnectionString(connString))
 
 		rd.AddHook(NewHook(options...))
 		return nil
 	case *redis.ClusterClient:
 		rd.AddHook(NewHook(options...))
-		rd.OnNewNode(func(rdb *redis.Client) {
-			redisOption := rdb.Options()
-			connString := formatDBConnString(redisOption.Network,
-				redisOption.Addr)
-			nodeOptions := append([]Option(nil), options...)
-			nodeOptions = append(nodeOptions, WithClientType("cluster"),
-				WithConnectionString(connString))
-
-			rdb.AddHook(NewHook(nodeOptions...))
-		})
+		rd.OnNewNode(nodeHook(options, "cluster"))
 		return nil
 	case *redis.Ring:
 		rd.AddHook(NewHook(options...))
-		rd.OnNewNode(func(rdb *redis.Client) {
-			redisOption := rdb.Options()
-			connString := formatDBConnString(redisOption.Network,
-				redisOption.Addr)
-			nodeOptions := append([]Option(nil), options...)
-			nodeOptions = append(nodeOptions, WithClientType("ring"),
-				WithConnectionString(connString))
-
-			rdb.AddHook(NewHook(nodeOptions...))
-		})
+		// The shards given by the options are created along the ring, hence
+		// before the OnNewNode hook is registered.
+		addHook := nodeHook(options, "ring")
+		rd.OnNewNode(addHook)
+		_ = rd.ForEachShard(context.Background(),
+			func(_ context.Context, rdb *redis.Client) error {
+				addHook(rdb)
+				return nil
+			})
 		return nil
 	default:
 		return fmt.Errorf("tracing: unsupported redis client type: %T", rd)
 	}
 }
+
+// nodeHook returns a function registering the hook on a node of a cluster or
+// ring, recording the address of the node.
+func nodeHook(options []Option, clientType string) func(rdb *redis.Client) {
+	return func(rdb *redis.Client) {
+		redisOption := rdb.Options()
+		connString := formatDBConnString(redisOption.Network,
+			redisOption.Addr)
+		nodeOptions := append([]Option(nil), options...)
+		nodeOptions = append(nodeOptions, WithClientType(clientType),
+			WithServerAddress(redisOption.Addr),
+			WithConnectionString(connString))
+
+		rdb.AddHook(NewHook(nodeOptions...))
+	}
+}
@@ -2,6 +2,7 @@ package tracing
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"testing"
@@ -150,22 +151,22 @@ func TestTracingHook_ProcessHook(t *testing.T) {
 		for _, attr := range ttSpan.Attributes {
 			switch attr.Key {
 			case attribute.DBStatementKey:
-				val := attr.Value.(string)
+				val := attr.Value.AsString()
 				if val != "ping" {
 					t.Errorf("expected val to be ping but got %s", val)
 				}
 			case attribute.DBSystemKey:
-				val := attr.Value.(string)
+				val := attr.Value.AsString()
 				if val != "redis" {
 					t.Errorf("expected val to be redis but got %s", val)
 				}
 			case attribute.DBOperationKey:
-				val := attr.Value.(string)
+				val := attr.Value.AsString()
 				if val != "ping" {
 					t.Errorf("expected val to be ping but got %s", val)
 				}
 			case "code.function", "code.filepath", "code.lineno":
-				if attr.Value == nil {
+				if attr.Value.Type() == attribute.TypeInvalid {
 					t.Error("expected code.xxx attribute not be empty")
 				}
 			}
@@ -180,6 +181,83 @@ func TestTracingHook_ProcessHook(t *testing.T) {
 	}
 }
 
+func TestTracingHook_ProcessHook_Error(t *testing.T) {
+	tt := tracetest.NewTracer()
+	tracer.SetGlobalTracer(tt)
+
+	hook := NewHook()
+	ctx := context.TODO()
+
+	t.Run("redis.Nil", func(t *testing.T) {
+		processHook := hook.ProcessHook(func(context.Context, redis.Cmder) error {
+			return redis.Nil
+		})
+		_ = processHook(ctx, redis.NewStringCmd(ctx, "get", "key"))
+
+		spans := tt.Recorder().EndedSpans()
+		span := spans[len(spans)-1]
+		if !errors.Is(span.Error, redis.Nil) {
+			t.Errorf("expected redis.Nil to be recorded but got %v", span.Error)
+		}
+		if span.Status != tracer.StatusUnset {
+			t.Errorf("expected status to be Unset but got %s", span.Status)
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		processHook := hook.ProcessHook(func(context.Context, redis.Cmder) error {
+			return errors.New("connection refused")
+		})
+		_ = processHook(ctx, redis.NewCmd(ctx, "ping"))
+
+		spans := tt.Recorder().EndedSpans()
+		span := spans[len(spans)-1]
+		if span.Status != tracer.StatusError {
+			t.Errorf("expected status to be Error but got %s", span.Status)
+		} else if span.StatusDescription != "connection refused" {
+			t.Errorf("expected description to be connection refused but got %s",
+				span.StatusDescription)
+		}
+	})
+}
+
+func TestTracingHook_ProcessHook_Redaction(t *testing.T) {
+	tt := tracetest.NewTracer()
+	tracer.SetGlobalTracer(tt)
+	ctx := context.TODO()
+
+	t.Run("Default", func(t *testing.T) {
+		processHook := NewHook().ProcessHook(func(context.Context, redis.Cmder) error {
+			return nil
+		})
+		_ = processHook(ctx, redis.NewCmd(ctx, "set", "user", "john.doe@example.com"))
+
+		spans := tt.Recorder().EndedSpans()
+		for _, attr := range spans[len(spans)-1].Attributes {
+			if attr.Key == attribute.DBStatementKey &&
+				attr.Value.AsString() != "set user "+attribute.DefaultMask {
+				t.Errorf("expected the email to be redacted but got %v", attr.Value)
+			}
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		processHook := NewHook(WithRedactor(nil)).ProcessHook(
+			func(context.Context, redis.Cmder) error {
+				return nil
+			})
+		_ = processHook(ctx, redis.NewCmd(ctx, "set", "user", "john.doe@example.com"))
+
+		spans := tt.Recorder().EndedSpans()
+		for _, attr := range spans[len(spans)-1].Attributes {
+			if attr.Key == attribute.DBStatementKey &&
+				attr.Value.AsString() != "set user john.doe@example.com" {
+				t.Errorf("expected the statement to be kept but got %v", attr.Value)
+			}
+		}
+	})
+}
+
 func TestTracingHook_ProcessPipelineHook(t *testing.T) {
 	tt := tracetest.NewTracer()
 	tracer.SetGlobalTracer(tt)
@@ -222,17 +300,17 @@ func TestTracingHook_ProcessPipelineHook(t *testing.T) {
 		for _, attr := range ttSpan.Attributes {
 			switch attr.Key {
 			case attribute.DBStatementKey:
-				val := attr.Value.(string)
+				val := attr.Value.AsString()
 				if val != "ping\nget key" {
 					t.Errorf("expected val to be ping but got %s", val)
 				}
 			case attribute.DBSystemKey:
-				val := attr.Value.(string)
+				val := attr.Value.AsString()
 				if val != "redis" {
 					t.Errorf("expected val to be redis but got %s", val)
 				}
 			case "code.function", "code.filepath", "code.lineno":
-				if attr.Value == nil {
+				if attr.Value.Type() == attribute.TypeInvalid {
 					t.Error("expected code.xxx attribute not be empty")
 				}
 			}
@@ -347,3 +425,86 @@ func TestOption_WithSpanNameGenerator_Dial(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestInstrumentTracing(t *testing.T) {
+	// hasPeer reports whether a ping span records the given address.
+	hasPeer := func(tt *tracetest.Tracer, host string, port int) bool {
+		for _, span := range tt.Recorder().EndedSpans() {
+			if span.Name != "redis.ping" {
+				continue
+			}
+			address, _ := span.Attribute(attribute.HTTPServerAddressKey)
+			p, _ := span.Attribute(attribute.HTTPServerPortKey)
+			if address.AsString() == host && p.AsInt64() == int64(port) {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("Client", func(t *testing.T) {
+		tt := tracetest.NewTracer()
+		tracer.SetGlobalTracer(tt)
+
+		rdb := redis.NewClient(&redis.Options{
+			Addr:       "127.0.0.1:1",
+			MaxRetries: -1,
+		})
+		defer rdb.Close()
+
+		if err := InstrumentTracing(rdb, IncludeAddress(true)); err != nil {
+			t.Fatal(err)
+		}
+		_ = rdb.Ping(context.TODO()).Err()
+
+		if !hasPeer(tt, "127.0.0.1", 1) {
+			t.Error("expected the client address to be recorded")
+		}
+	})
+
+	t.Run("Address Excluded By Default", func(t *testing.T) {
+		tt := tracetest.NewTracer()
+		tracer.SetGlobalTracer(tt)
+
+		rdb := redis.NewClient(&redis.Options{
+			Addr:       "127.0.0.1:1",
+			MaxRetries: -1,
+		})
+		defer rdb.Close()
+
+		if err := InstrumentTracing(rdb); err != nil {
+			t.Fatal(err)
+		}
+		_ = rdb.Ping(context.TODO()).Err()
+
+		if hasPeer(tt, "127.0.0.1", 1) {
+			t.Error("expected the client address to not be recorded")
+		}
+	})
+
+	t.Run("Ring", func(t *testing.T) {
+		tt := tracetest.NewTracer()
+		tracer.SetGlobalTracer(tt)
+
+		rdb := redis.NewRing(&redis.RingOptions{
+			Addrs:      map[string]string{"shard": "127.0.0.1:1"},
+			MaxRetries: -1,
+		})
+		defer rdb.Close()
+
+		if err := InstrumentTracing(rdb, IncludeAddress(true)); err != nil {
+			t.Fatal(err)
+		}
+		_ = rdb.Ping(context.TODO()).Err()
+
+		if !hasPeer(tt, "127.0.0.1", 1) {
+			t.Error("expected the node address to be recorded")
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		if err := InstrumentTracing(nil); err == nil {
+			t.Error("expected error for unsupported client")
+		}
+	})
+}
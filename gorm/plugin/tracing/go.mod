@@ -3,7 +3,8 @@ module github.com/rmscoal/tengcorux/gorm/plugin/tracing
 go 1.21
 
 require (
-	github.com/rmscoal/tengcorux/tracer v0.1.4
+	github.com/rmscoal/tengcorux/metric v0.0.0-20261017174934-22fc87409d0a
+	github.com/rmscoal/tengcorux/tracer v0.1.5-0.20261017175312-3ec99204e181
 	gorm.io/driver/sqlite v1.5.6
 	gorm.io/gorm v1.25.12
 )
@@ -14,3 +15,7 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.22 // indirect
 	golang.org/x/text v0.14.0 // indirect
 )
+
+replace github.com/rmscoal/tengcorux/tracer => ../../../tracer
+
+replace github.com/rmscoal/tengcorux/metric => ../../../metric
@@ -0,0 +1,337 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rmscoal/tengcorux/metric"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"gorm.io/gorm"
+)
+
+// defaultDBStatsInterval is the default interval of sampling the sql.DBStats.
+const defaultDBStatsInterval = 10 * time.Second
+
+// metricsStartKey defines the key for the start time of an operation in which
+// the value will be passed through context during the before and after calls.
+type metricsStartKey struct{}
+
+// metrics implements gorm.Plugin recording the duration, the rows affected
+// and the errors of the operations, and sampling the sql.DBStats.
+type metrics struct {
+	meter metric.Meter
+
+	// configs
+	attributes      []attribute.KeyValue
+	dbStatsInterval time.Duration
+
+	// instruments
+	duration     metric.Float64Histogram
+	rowsAffected metric.Int64Counter
+	errors       metric.Int64Counter
+	dbStats      *dbStatsSampler
+}
+
+type MetricsOption func(*metrics)
+
+// WithMeter registers the given meter to the metrics instance. Defaults to
+// the global meter at the time the plugin is initialized.
+func WithMeter(meter metric.Meter) MetricsOption {
+	return func(m *metrics) {
+		m.meter = meter
+	}
+}
+
+// WithMetricsAttributes adds given attributes to the measurements later on.
+func WithMetricsAttributes(attrs ...attribute.KeyValue) MetricsOption {
+	return func(m *metrics) {
+		m.attributes = append(m.attributes, attrs...)
+	}
+}
+
+// WithDBStatsInterval sets the minimum interval between two samples of the
+// sql.DBStats. Defaults to 10 seconds.
+func WithDBStatsInterval(interval time.Duration) MetricsOption {
+	return func(m *metrics) {
+		if interval > 0 {
+			m.dbStatsInterval = interval
+		}
+	}
+}
+
+// NewMetricsPlugin returns a metrics instance that could be called during
+// the registration of gorm Writer Plugin, along with the tracing one. For
+// example:
+//
+//	db.Use(tracing.NewMetricsPlugin(tracing.WithMeter(someMeter)))
+//
+// This will register the metrics within gorm's callbacks. The sql.DBStats
+// are sampled along the operations, at most once every interval, hence no
+// sampling outlives the database.
+func NewMetricsPlugin(opts ...MetricsOption) gorm.Plugin {
+	m := &metrics{
+		dbStatsInterval: defaultDBStatsInterval,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Name returns the plugin name as required by gorm.Plugin.
+func (m *metrics) Name() string {
+	return "gorm:metrics"
+}
+
+// Initialize creates the instruments from the meter and registers all the
+// metrics callbacks to the GORM instance, then takes the first sample of the
+// sql.DBStats of db.DB().
+func (m *metrics) Initialize(db *gorm.DB) error {
+	if m.meter == nil {
+		m.meter = metric.GetGlobalMeter()
+	}
+	m.attributes = append([]attribute.KeyValue{
+		attribute.DBSystem(mapDBSystem(db.Dialector.Name())),
+	}, m.attributes...)
+
+	var err error
+	if m.duration, err = m.meter.Float64Histogram("db.client.operation.duration",
+		metric.WithDescription("Duration of database operations."),
+		metric.WithUnit("s"),
+	); err != nil {
+		return err
+	}
+	if m.rowsAffected, err = m.meter.Int64Counter("db.client.operation.rows_affected",
+		metric.WithDescription("Number of rows affected by database operations."),
+		metric.WithUnit("{row}"),
+	); err != nil {
+		return err
+	}
+	if m.errors, err = m.meter.Int64Counter("db.client.operation.errors",
+		metric.WithDescription("Number of failed database operations."),
+		metric.WithUnit("{operation}"),
+	); err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if m.dbStats, err = newDBStatsSampler(m.meter, m.attributes,
+		sqlDB.Stats); err != nil {
+		return err
+	}
+
+	var errs error
+
+	// QUERY
+	errs = errors.Join(errs,
+		db.Callback().Query().Before("gorm:query").Register("metrics:before:query",
+			m.before()))
+	errs = errors.Join(errs,
+		db.Callback().Query().After("gorm:query").Register("metrics:after:query",
+			m.after("SELECT")))
+
+	// CREATE
+	errs = errors.Join(errs,
+		db.Callback().Create().Before("gorm:create").Register("metrics:before:create",
+			m.before()))
+	errs = errors.Join(errs,
+		db.Callback().Create().After("gorm:create").Register("metrics:after:create",
+			m.after("INSERT")))
+
+	// UPDATE
+	errs = errors.Join(errs,
+		db.Callback().Update().Before("gorm:update").Register("metrics:before:update",
+			m.before()))
+	errs = errors.Join(errs,
+		db.Callback().Update().After("gorm:update").Register("metrics:after:update",
+			m.after("UPDATE")))
+
+	// DELETE
+	errs = errors.Join(errs,
+		db.Callback().Delete().Before("gorm:delete").Register("metrics:before:delete",
+			m.before()))
+	errs = errors.Join(errs,
+		db.Callback().Delete().After("gorm:delete").Register("metrics:after:delete",
+			m.after("DELETE")))
+
+	// ROW
+	errs = errors.Join(errs,
+		db.Callback().Row().Before("gorm:row").Register("metrics:before:row",
+			m.before()))
+	errs = errors.Join(errs,
+		db.Callback().Row().After("gorm:row").Register("metrics:after:row",
+			m.after("ROW")))
+
+	// RAW
+	errs = errors.Join(errs,
+		db.Callback().Raw().Before("gorm:raw").Register("metrics:before:raw",
+			m.before()))
+	errs = errors.Join(errs,
+		db.Callback().Raw().After("gorm:raw").Register("metrics:after:raw",
+			m.after("RAW")))
+
+	if errs != nil {
+		return errs
+	}
+
+	m.dbStats.sampleEvery(context.Background(), m.dbStatsInterval)
+
+	return nil
+}
+
+func (m *metrics) before() func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		tx.Statement.Context = context.WithValue(tx.Statement.Context,
+			metricsStartKey{}, time.Now())
+	}
+}
+
+func (m *metrics) after(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		start, ok := tx.Statement.Context.Value(metricsStartKey{}).(time.Time)
+		if !ok {
+			return
+		}
+
+		attrs := make([]attribute.KeyValue, 0, len(m.attributes)+2)
+		attrs = append(attrs, m.attributes...)
+		attrs = append(attrs,
+			attribute.DBOperation(operation),
+			attribute.DBTable(tx.Statement.Table),
+		)
+		opt := metric.WithAttributes(attrs...)
+
+		ctx := tx.Statement.Context
+		m.duration.Record(ctx, time.Since(start).Seconds(), opt)
+		if tx.RowsAffected > 0 {
+			m.rowsAffected.Add(ctx, tx.RowsAffected, opt)
+		}
+
+		switch {
+		case tx.Error == nil,
+			tx.Error == io.EOF,
+			errors.Is(tx.Error, driver.ErrSkip),
+			errors.Is(tx.Error, gorm.ErrRecordNotFound),
+			errors.Is(tx.Error, sql.ErrNoRows):
+			// Not found is an expected outcome, hence not counted alongside
+			// the ignored errors.
+		default:
+			m.errors.Add(ctx, 1, opt)
+		}
+
+		m.dbStats.sampleEvery(ctx, m.dbStatsInterval)
+	}
+}
+
+// dbStatsSampler records the sql.DBStats of a database. The wait count and
+// duration are cumulative within database/sql, hence only their increase
+// since the previous sample is added to the counters.
+type dbStatsSampler struct {
+	stats      func() sql.DBStats
+	attributes []attribute.KeyValue
+
+	mu       sync.Mutex
+	previous sql.DBStats
+	sampled  time.Time
+
+	maxOpen      metric.Int64Gauge
+	open         metric.Int64Gauge
+	inUse        metric.Int64Gauge
+	idle         metric.Int64Gauge
+	waitCount    metric.Int64Counter
+	waitDuration metric.Float64Counter
+}
+
+func newDBStatsSampler(
+	meter metric.Meter, attributes []attribute.KeyValue, stats func() sql.DBStats,
+) (*dbStatsSampler, error) {
+	s := &dbStatsSampler{stats: stats, attributes: attributes}
+
+	var err error
+	if s.maxOpen, err = meter.Int64Gauge("db.client.connections.max",
+		metric.WithDescription("Maximum number of open connections to the database."),
+		metric.WithUnit("{connection}"),
+	); err != nil {
+		return nil, err
+	}
+	if s.open, err = meter.Int64Gauge("db.client.connections.open",
+		metric.WithDescription("Number of established connections to the database."),
+		metric.WithUnit("{connection}"),
+	); err != nil {
+		return nil, err
+	}
+	if s.inUse, err = meter.Int64Gauge("db.client.connections.in_use",
+		metric.WithDescription("Number of connections currently in use."),
+		metric.WithUnit("{connection}"),
+	); err != nil {
+		return nil, err
+	}
+	if s.idle, err = meter.Int64Gauge("db.client.connections.idle",
+		metric.WithDescription("Number of idle connections."),
+		metric.WithUnit("{connection}"),
+	); err != nil {
+		return nil, err
+	}
+	if s.waitCount, err = meter.Int64Counter("db.client.connections.wait_count",
+		metric.WithDescription("Number of connections waited for."),
+		metric.WithUnit("{connection}"),
+	); err != nil {
+		return nil, err
+	}
+	if s.waitDuration, err = meter.Float64Counter("db.client.connections.wait_duration",
+		metric.WithDescription("Total time blocked waiting for a new connection."),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// sample records the current sql.DBStats.
+func (s *dbStatsSampler) sample(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.record(ctx)
+}
+
+// sampleEvery records the current sql.DBStats unless they have been sampled
+// within the interval.
+func (s *dbStatsSampler) sampleEvery(ctx context.Context, interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.sampled.IsZero() && time.Since(s.sampled) < interval {
+		return
+	}
+	s.record(ctx)
+}
+
+// record records the current sql.DBStats, s.mu must be held.
+func (s *dbStatsSampler) record(ctx context.Context) {
+	stats := s.stats()
+	opt := metric.WithAttributes(s.attributes...)
+
+	s.maxOpen.Record(ctx, int64(stats.MaxOpenConnections), opt)
+	s.open.Record(ctx, int64(stats.OpenConnections), opt)
+	s.inUse.Record(ctx, int64(stats.InUse), opt)
+	s.idle.Record(ctx, int64(stats.Idle), opt)
+	if stats.WaitCount >= s.previous.WaitCount {
+		s.waitCount.Add(ctx, stats.WaitCount-s.previous.WaitCount, opt)
+	}
+	if stats.WaitDuration >= s.previous.WaitDuration {
+		s.waitDuration.Add(ctx,
+			(stats.WaitDuration - s.previous.WaitDuration).Seconds(), opt)
+	}
+	s.previous = stats
+	s.sampled = time.Now()
+}
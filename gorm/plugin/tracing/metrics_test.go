@@ -0,0 +1,226 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/rmscoal/tengcorux/metric"
+	"github.com/rmscoal/tengcorux/metric/metrictest"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMetrics(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:metrics?mode=memory&cache=shared"),
+		&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	t.Cleanup(func() { // Close DB during cleanup
+		sqlDB, err := db.DB()
+		if err != nil {
+			t.Fatalf("failed to connect database: %v", err)
+		}
+		_ = sqlDB.Close()
+	})
+
+	err = db.AutoMigrate(&userModel{})
+	if err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	meter := metrictest.NewMeter()
+	err = db.Use(NewMetricsPlugin(
+		WithMeter(meter),
+		WithMetricsAttributes(attribute.DBName("metrics")),
+		WithDBStatsInterval(time.Millisecond),
+	))
+	if err != nil {
+		t.Fatalf("failed to register metrics plugin: %v", err)
+	}
+	if db.Config.Plugins["gorm:metrics"] == nil {
+		t.Fatalf("failed to find metrics gorm plugin in gorm plugins")
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		users := []userModel{{Name: "John"}, {Name: "Jane"}}
+		if err := db.WithContext(context.TODO()).Create(&users).Error; err != nil {
+			t.Fatalf("failed to create users: %v", err)
+		}
+
+		insert := []attribute.KeyValue{
+			attribute.DBSystem(SQLite),
+			attribute.DBName("metrics"),
+			attribute.DBOperation("INSERT"),
+			attribute.DBTable("users"),
+		}
+		if count := meter.Count("db.client.operation.duration", insert...); count != 1 {
+			t.Errorf("expected 1 duration but got %d", count)
+		}
+		if sum := meter.Sum("db.client.operation.rows_affected", insert...); sum != 2 {
+			t.Errorf("expected 2 rows affected but got %v", sum)
+		}
+	})
+
+	t.Run("Record Not Found", func(t *testing.T) {
+		var user userModel
+		_ = db.WithContext(context.TODO()).Where("id = ?", -1).Take(&user).Error
+
+		selects := attribute.DBOperation("SELECT")
+		if count := meter.Count("db.client.operation.duration", selects); count != 1 {
+			t.Errorf("expected 1 duration but got %d", count)
+		}
+		if count := meter.Count("db.client.operation.errors", selects); count != 0 {
+			t.Errorf("expected not found not to be counted but got %d", count)
+		}
+	})
+
+	t.Run("Failed Query", func(t *testing.T) {
+		err := db.WithContext(context.TODO()).
+			Exec("SELECT * FROM unknown_table").Error
+		if err == nil {
+			t.Fatal("expected the query to fail")
+		}
+
+		if sum := meter.Sum("db.client.operation.errors",
+			attribute.DBOperation("RAW")); sum != 1 {
+			t.Errorf("expected 1 error but got %v", sum)
+		}
+	})
+
+	t.Run("DB Stats", func(t *testing.T) {
+		before := meter.Count("db.client.connections.open", attribute.DBSystem(SQLite))
+		if before == 0 {
+			t.Fatal("expected the db stats to be sampled on initialize")
+		}
+
+		time.Sleep(2 * time.Millisecond)
+		if err := db.WithContext(context.TODO()).Exec("SELECT 1").Error; err != nil {
+			t.Fatalf("failed to query: %v", err)
+		}
+		after := meter.Count("db.client.connections.open", attribute.DBSystem(SQLite))
+		if after != before+1 {
+			t.Errorf("expected the db stats to be sampled along the query, got %d samples",
+				after-before)
+		}
+	})
+}
+
+func TestMetrics_GlobalMeter(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:global_metrics?mode=memory&cache=shared"),
+		&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	t.Cleanup(func() { // Close DB during cleanup
+		sqlDB, err := db.DB()
+		if err != nil {
+			t.Fatalf("failed to connect database: %v", err)
+		}
+		_ = sqlDB.Close()
+	})
+
+	plugin := NewMetricsPlugin()
+
+	meter := metrictest.NewMeter()
+	metric.SetGlobalMeter(meter)
+	defer metric.SetGlobalMeter(&metric.NoopMeter{})
+
+	if err := db.Use(plugin); err != nil {
+		t.Fatalf("failed to register metrics plugin: %v", err)
+	}
+	if err := db.WithContext(context.TODO()).Exec("SELECT 1").Error; err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+
+	if count := meter.Count("db.client.operation.duration"); count != 1 {
+		t.Errorf("expected the meter set before initialize to record 1 operation but got %d",
+			count)
+	}
+}
+
+func TestDBStatsSampler(t *testing.T) {
+	meter := metrictest.NewMeter()
+	stats := sql.DBStats{
+		MaxOpenConnections: 10,
+		OpenConnections:    3,
+		InUse:              2,
+		Idle:               1,
+		WaitCount:          4,
+		WaitDuration:       time.Second,
+	}
+	sampler, err := newDBStatsSampler(meter, nil, func() sql.DBStats {
+		return stats
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	sampler.sample(ctx)
+	stats.WaitCount, stats.WaitDuration, stats.InUse = 6, 3*time.Second, 0
+	sampler.sample(ctx)
+
+	if last, _ := meter.Last("db.client.connections.max"); last != 10 {
+		t.Errorf("expected 10 max connections but got %v", last)
+	}
+	if last, _ := meter.Last("db.client.connections.open"); last != 3 {
+		t.Errorf("expected 3 open connections but got %v", last)
+	}
+	if last, _ := meter.Last("db.client.connections.in_use"); last != 0 {
+		t.Errorf("expected 0 connections in use but got %v", last)
+	}
+	if last, _ := meter.Last("db.client.connections.idle"); last != 1 {
+		t.Errorf("expected 1 idle connection but got %v", last)
+	}
+	if sum := meter.Sum("db.client.connections.wait_count"); sum != 6 {
+		t.Errorf("expected wait count 6 but got %v", sum)
+	}
+	if sum := meter.Sum("db.client.connections.wait_duration"); sum != 3 {
+		t.Errorf("expected wait duration 3 but got %v", sum)
+	}
+}
+
+func TestDBStatsSampler_SampleEvery(t *testing.T) {
+	meter := metrictest.NewMeter()
+	sampler, err := newDBStatsSampler(meter, nil, func() sql.DBStats {
+		return sql.DBStats{}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	sampler.sampleEvery(ctx, time.Hour)
+	sampler.sampleEvery(ctx, time.Hour)
+	if count := meter.Count("db.client.connections.open"); count != 1 {
+		t.Errorf("expected 1 sample within the interval but got %d", count)
+	}
+
+	sampler.sampleEvery(ctx, 0)
+	if count := meter.Count("db.client.connections.open"); count != 2 {
+		t.Errorf("expected 2 samples once the interval passed but got %d", count)
+	}
+}
+
+func TestMetricsOption(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		m := NewMetricsPlugin().(*metrics)
+		if m.dbStatsInterval != defaultDBStatsInterval {
+			t.Errorf("expected default interval but got %s", m.dbStatsInterval)
+		}
+		if m.meter != nil {
+			t.Error("expected the meter to be resolved on initialize")
+		}
+	})
+
+	t.Run("Invalid Values", func(t *testing.T) {
+		m := NewMetricsPlugin(WithDBStatsInterval(0)).(*metrics)
+		if m.dbStatsInterval != defaultDBStatsInterval {
+			t.Errorf("expected default interval but got %s", m.dbStatsInterval)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package tracing
 
-import "github.com/rmscoal/tengcorux/tracer"
+import (
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
 
 type Option func(*tracing)
 
@@ -24,3 +27,12 @@ func WithSQLVariables() Option {
 		t.showSQLVariable = true
 	}
 }
+
+// WithRedactor replaces the default redactor applied to the span attributes,
+// most notably to the statement recorded along WithSQLVariables. By default,
+// [attribute.DefaultRedactor] is used and passing nil disables redaction.
+func WithRedactor(redactor *attribute.Redactor) Option {
+	return func(t *tracing) {
+		t.redactor = redactor
+	}
+}
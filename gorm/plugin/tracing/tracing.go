@@ -31,6 +31,7 @@ type tracing struct {
 	// configs
 	showSQLVariable   bool
 	spanNameGenerator SpanNameGenerator
+	redactor          *attribute.Redactor
 }
 
 // NewPlugin returns a tracing instance that could be called during
@@ -43,6 +44,7 @@ func NewPlugin(opts ...Option) gorm.Plugin {
 	t := &tracing{
 		provider:          tracer.GetGlobalTracer(),
 		spanNameGenerator: _defaultSpanNameGenerator,
+		redactor:          attribute.DefaultRedactor(),
 	}
 
 	for _, opt := range opts {
@@ -152,7 +154,7 @@ func (t *tracing) after() func(*gorm.DB) {
 		} else {
 			dbStmtAttr = attribute.DBStatement(tx.Statement.SQL.String())
 		}
-		span.SetAttributes(
+		span.SetAttributes(t.redactor.Redact(
 			dbStmtAttr,
 			attribute.DBTable(tx.Statement.Table),
 			attribute.DBName(tx.Name()),
@@ -164,17 +166,21 @@ func (t *tracing) after() func(*gorm.DB) {
 					"")),
 			attribute.KeyValuePair("gorm.plugin.package",
 				t.Name()+"@"+t.Version()),
-		)
+		)...)
 
 		switch {
 		case tx.Error == nil,
 			tx.Error == io.EOF,
-			errors.Is(tx.Error, gorm.ErrRecordNotFound),
-			errors.Is(tx.Error, driver.ErrSkip),
-			errors.Is(tx.Error, sql.ErrNoRows):
+			errors.Is(tx.Error, driver.ErrSkip):
 			// We ignore these errors
+		case errors.Is(tx.Error, gorm.ErrRecordNotFound),
+			errors.Is(tx.Error, sql.ErrNoRows):
+			// Not found is an expected outcome, hence we only record the
+			// error without failing the span.
+			span.RecordError(tx.Error)
 		default:
 			span.RecordError(tx.Error)
+			span.SetStatus(tracer.StatusError, tx.Error.Error())
 		}
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/rmscoal/tengcorux/tracer"
 	"github.com/rmscoal/tengcorux/tracer/attribute"
 	"github.com/rmscoal/tengcorux/tracer/tracetest"
 	"gorm.io/driver/sqlite"
@@ -52,7 +53,7 @@ func TestTracing(t *testing.T) {
 
 				firstSpan := spans[0]
 				for _, attr := range firstSpan.Attributes {
-					val := attr.Value.(string)
+					val := attr.Value.AsString()
 					switch attr.Key {
 					case attribute.DBSystemKey:
 						if val != SQLite {
@@ -93,7 +94,7 @@ func TestTracing(t *testing.T) {
 
 				firstSpan := spans[0]
 				for _, attr := range firstSpan.Attributes {
-					val := attr.Value.(string)
+					val := attr.Value.AsString()
 					switch attr.Key {
 					case attribute.DBSystemKey:
 						if val != SQLite {
@@ -129,7 +130,7 @@ func TestTracing(t *testing.T) {
 
 				firstSpan := spans[0]
 				for _, attr := range firstSpan.Attributes {
-					val := attr.Value.(string)
+					val := attr.Value.AsString()
 					switch attr.Key {
 					case attribute.DBSystemKey:
 						if val != SQLite {
@@ -169,7 +170,7 @@ func TestTracing(t *testing.T) {
 
 				firstSpan := spans[0]
 				for _, attr := range firstSpan.Attributes {
-					val := attr.Value.(string)
+					val := attr.Value.AsString()
 					switch attr.Key {
 					case attribute.DBSystemKey:
 						if val != SQLite {
@@ -205,7 +206,7 @@ func TestTracing(t *testing.T) {
 
 				firstSpan := spans[0]
 				for _, attr := range firstSpan.Attributes {
-					val := attr.Value.(string)
+					val := attr.Value.AsString()
 					switch attr.Key {
 					case attribute.DBSystemKey:
 						if val != SQLite {
@@ -235,7 +236,7 @@ func TestTracing(t *testing.T) {
 
 				firstSpan := spans[0]
 				for _, attr := range firstSpan.Attributes {
-					val := attr.Value.(string)
+					val := attr.Value.AsString()
 					switch attr.Key {
 					case attribute.DBSystemKey:
 						if val != SQLite {
@@ -300,6 +301,123 @@ func TestTracing(t *testing.T) {
 	}
 }
 
+func TestTracing_Status(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"),
+		&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	t.Cleanup(func() { // Close DB during cleanup
+		sqlDB, err := db.DB()
+		if err != nil {
+			t.Fatalf("failed to connect database: %v", err)
+		}
+		_ = sqlDB.Close()
+	})
+
+	err = db.AutoMigrate(&userModel{})
+	if err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	tr := tracetest.NewTracer()
+	if err := db.Use(NewPlugin(WithTracer(tr))); err != nil {
+		t.Fatalf("failed to register tracing plugin: %v", err)
+	}
+
+	t.Run("Record Not Found", func(t *testing.T) {
+		var user userModel
+		err := db.WithContext(context.TODO()).Where("id = ?", -1).
+			Take(&user).Error
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("expected record not found but got %v", err)
+		}
+
+		spans := tr.Recorder().EndedSpans()
+		span := spans[len(spans)-1]
+		if !errors.Is(span.Error, gorm.ErrRecordNotFound) {
+			t.Errorf("expected the error to be recorded, got %v", span.Error)
+		}
+		if span.Status != tracer.StatusUnset {
+			t.Errorf("expected status to be Unset, got %s", span.Status)
+		}
+	})
+
+	t.Run("Failed Query", func(t *testing.T) {
+		err := db.WithContext(context.TODO()).
+			Exec("SELECT * FROM unknown_table").Error
+		if err == nil {
+			t.Fatal("expected the query to fail")
+		}
+
+		spans := tr.Recorder().EndedSpans()
+		span := spans[len(spans)-1]
+		if span.Error == nil {
+			t.Error("expected the error to be recorded")
+		}
+		if span.Status != tracer.StatusError {
+			t.Errorf("expected status to be Error, got %s", span.Status)
+		} else if span.StatusDescription != err.Error() {
+			t.Errorf("expected description %q, got %q", err.Error(),
+				span.StatusDescription)
+		}
+	})
+}
+
+func TestTracing_Redaction(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"),
+		&gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	t.Cleanup(func() { // Close DB during cleanup
+		sqlDB, err := db.DB()
+		if err != nil {
+			t.Fatalf("failed to connect database: %v", err)
+		}
+		_ = sqlDB.Close()
+	})
+
+	err = db.AutoMigrate(&userModel{})
+	if err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	tr := tracetest.NewTracer()
+	err = db.Use(NewPlugin(WithTracer(tr), WithSQLVariables(),
+		WithRedactor(attribute.NewRedactor(
+			attribute.WithEmailMasking(),
+			attribute.WithDeniedKeys(attribute.DBTableKey),
+		))))
+	if err != nil {
+		t.Fatalf("failed to register tracing plugin: %v", err)
+	}
+
+	var users []userModel
+	err = db.WithContext(context.TODO()).
+		Where("name = ?", "john.doe@example.com").
+		Find(&users).Error
+	if err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+
+	spans := tr.Recorder().EndedSpans()
+	for _, attr := range spans[len(spans)-1].Attributes {
+		switch attr.Key {
+		case attribute.DBStatementKey:
+			val := attr.Value.AsString()
+			if strings.Contains(val, "john.doe@example.com") ||
+				!strings.Contains(val, attribute.DefaultMask) {
+				t.Errorf("expected the email to be redacted, got %s", val)
+			}
+		case attribute.DBTableKey:
+			if attr.Value.AsString() != attribute.DefaultMask {
+				t.Errorf("expected the table to be redacted, got %v", attr.Value)
+			}
+		}
+	}
+}
+
 func TestMapDBSystem(t *testing.T) {
 	tests := []struct {
 		input string
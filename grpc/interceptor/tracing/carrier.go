@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"google.golang.org/grpc/metadata"
+
+	"github.com/rmscoal/tengcorux/tracer"
+)
+
+// metadataCarrier adapts metadata.MD to satisfy the tracer.Carrier interface.
+type metadataCarrier metadata.MD
+
+// Make sure that metadataCarrier implements [tracer.Carrier] during compile
+// time.
+var _ tracer.Carrier = metadataCarrier{}
+
+// Get returns the first value associated with the given key.
+func (mc metadataCarrier) Get(key string) string {
+	values := metadata.MD(mc).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set stores the key-value pair into the metadata, replacing the existing
+// values of the key.
+func (mc metadataCarrier) Set(key string, value string) {
+	metadata.MD(mc).Set(key, value)
+}
+
+// Keys lists the keys stored in the metadata.
+func (mc metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(mc))
+	for k := range mc {
+		keys = append(keys, k)
+	}
+	return keys
+}
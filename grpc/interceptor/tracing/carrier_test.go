@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"sort"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMetadataCarrier(t *testing.T) {
+	md := metadata.MD{}
+	carrier := metadataCarrier(md)
+
+	carrier.Set("Trace-Id", "1")
+	carrier.Set("span-id", "2")
+	carrier.Set("span-id", "3")
+
+	if got := carrier.Get("trace-id"); got != "1" {
+		t.Errorf("expected keys to be case insensitive, got %q", got)
+	}
+	if got := md.Get("span-id"); len(got) != 1 || got[0] != "3" {
+		t.Errorf("expected the value to be replaced, got %v", got)
+	}
+	if got := carrier.Get("missing"); got != "" {
+		t.Errorf("expected an empty value, got %q", got)
+	}
+
+	keys := carrier.Keys()
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "span-id" || keys[1] != "trace-id" {
+		t.Errorf("unexpected keys %v", keys)
+	}
+}
@@ -0,0 +1,137 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/rmscoal/tengcorux/tracer"
+)
+
+// UnaryClientInterceptor returns an interceptor invoking every unary call
+// within an exit span. The trace context and the request id found in the
+// context are propagated through the outgoing metadata. For example:
+//
+//	conn, err := grpc.NewClient(target,
+//		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor()),
+//		grpc.WithChainStreamInterceptor(tracing.StreamClientInterceptor()),
+//	)
+func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	c := newConfig(opts...)
+
+	return func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption,
+	) error {
+		if c.skip(method) {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+
+		ctx, span := c.startClientSpan(ctx, cc.Target(), method)
+		defer span.End()
+
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		finishClientSpan(span, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns an interceptor opening every streaming
+// call within an exit span, see UnaryClientInterceptor. The span ends once
+// the stream is done: when receiving fails or reaches io.EOF, when sending
+// or closing fails, after the single response of a call without server
+// streaming, or once the context of the call is done, such that streams
+// abandoned by cancelling their context still end their span.
+func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
+	c := newConfig(opts...)
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+		method string, streamer grpc.Streamer, callOpts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		if c.skip(method) {
+			return streamer(ctx, desc, cc, method, callOpts...)
+		}
+
+		ctx, span := c.startClientSpan(ctx, cc.Target(), method)
+
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			finishClientSpan(span, err)
+			span.End()
+			return nil, err
+		}
+
+		s := &clientStream{
+			ClientStream:  cs,
+			span:          span,
+			serverStreams: desc.ServerStreams,
+			done:          make(chan struct{}),
+		}
+		go s.endOnDone(ctx)
+		return s, nil
+	}
+}
+
+// clientStream ends the span of the wrapped stream once it is done.
+type clientStream struct {
+	grpc.ClientStream
+	span          tracer.Span
+	serverStreams bool
+	once          sync.Once
+	done          chan struct{} // closed once the span ends
+}
+
+// SendMsg sends the message, ending the span if sending fails. An io.EOF
+// error means the server ended the stream, its status is then returned by
+// RecvMsg.
+func (s *clientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.end(err)
+	}
+	return err
+}
+
+// CloseSend closes the sending direction, ending the span if closing fails.
+func (s *clientStream) CloseSend() error {
+	err := s.ClientStream.CloseSend()
+	if err != nil {
+		s.end(err)
+	}
+	return err
+}
+
+// RecvMsg receives the message, ending the span once the stream is done.
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case errors.Is(err, io.EOF):
+		s.end(nil)
+	case err != nil:
+		s.end(err)
+	case !s.serverStreams:
+		s.end(nil)
+	}
+	return err
+}
+
+// endOnDone ends the span with the error of ctx once it is done, unless the
+// stream has ended it before.
+func (s *clientStream) endOnDone(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		s.end(status.FromContextError(ctx.Err()).Err())
+	case <-s.done:
+	}
+}
+
+func (s *clientStream) end(err error) {
+	s.once.Do(func() {
+		finishClientSpan(s.span, err)
+		s.span.End()
+		close(s.done)
+	})
+}
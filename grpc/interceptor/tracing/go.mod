@@ -0,0 +1,20 @@
+module github.com/rmscoal/tengcorux/grpc/interceptor/tracing
+
+go 1.21
+
+require (
+	github.com/rmscoal/tengcorux/reqid v0.1.0
+	github.com/rmscoal/tengcorux/tracer v0.1.5-0.20261017175312-3ec99204e181
+	google.golang.org/grpc v1.65.0
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	golang.org/x/net v0.25.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	golang.org/x/text v0.15.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
+)
+
+replace github.com/rmscoal/tengcorux/tracer => ../../../tracer
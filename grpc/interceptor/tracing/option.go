@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"github.com/rmscoal/tengcorux/tracer"
+)
+
+type Option func(*config)
+
+// WithTracer uses the given tracer instead of the global tracer.
+func WithTracer(t tracer.Tracer) Option {
+	return func(c *config) {
+		c.tracer = t
+	}
+}
+
+// WithSpanNameGenerator replaces the default span name generator, which
+// returns the full method without its leading slash, for example
+// "helloworld.Greeter/SayHello".
+func WithSpanNameGenerator(gen SpanNameGenerator) Option {
+	return func(c *config) {
+		if gen != nil {
+			c.spanNameGenerator = gen
+		}
+	}
+}
+
+// WithFilter skips tracing for calls for which the filter returns false,
+// for example health checks. The filter receives the full method such as
+// "/grpc.health.v1.Health/Check".
+func WithFilter(filter func(fullMethod string) bool) Option {
+	return func(c *config) {
+		c.filter = filter
+	}
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/rmscoal/tengcorux/reqid"
+)
+
+// UnaryServerInterceptor returns an interceptor serving every unary call
+// within an entry span. The span continues the trace propagated by the
+// incoming metadata, the request id is taken from the x-request-id metadata
+// or generated, injected into the context and echoed in the header. For
+// example:
+//
+//	server := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor()),
+//		grpc.ChainStreamInterceptor(tracing.StreamServerInterceptor()),
+//	)
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	c := newConfig(opts...)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if c.skip(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, span := c.startServerSpan(ctx, info.FullMethod)
+		defer span.End()
+		defer recordPanic(span)
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey,
+			reqid.RetrieveFromContext(ctx)))
+
+		resp, err := handler(ctx, req)
+		finishServerSpan(span, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns an interceptor serving every streaming
+// call within an entry span, see UnaryServerInterceptor. The span ends once
+// the handler returns.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	c := newConfig(opts...)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if c.skip(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, span := c.startServerSpan(ss.Context(), info.FullMethod)
+		defer span.End()
+		defer recordPanic(span)
+
+		_ = ss.SetHeader(metadata.Pairs(requestIDKey,
+			reqid.RetrieveFromContext(ctx)))
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		finishServerSpan(span, err)
+		return err
+	}
+}
+
+// serverStream overrides the context of the wrapped stream with the one
+// holding the span and the request id.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
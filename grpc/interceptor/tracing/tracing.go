@@ -0,0 +1,201 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// SpanNameGenerator returns the name of the span of a call given its full
+// method, such as "/helloworld.Greeter/SayHello".
+type SpanNameGenerator func(fullMethod string) string
+
+var _defaultSpanNameGenerator = func(fullMethod string) string {
+	return strings.TrimPrefix(fullMethod, "/")
+}
+
+// requestIDKey is the metadata key carrying the request id. Metadata keys
+// are lowercase, hence the lowercased reqid.HeaderKey.
+var requestIDKey = strings.ToLower(reqid.HeaderKey)
+
+// serverErrorCodes are the status codes that mark a server span as failed.
+// The other codes are the caller's fault, similar to the 4xx HTTP statuses.
+var serverErrorCodes = map[codes.Code]struct{}{
+	codes.Unknown:          {},
+	codes.DeadlineExceeded: {},
+	codes.Unimplemented:    {},
+	codes.Internal:         {},
+	codes.Unavailable:      {},
+	codes.DataLoss:         {},
+}
+
+// config holds the configuration shared by the interceptors.
+type config struct {
+	tracer            tracer.Tracer
+	spanNameGenerator SpanNameGenerator
+	filter            func(fullMethod string) bool
+}
+
+func newConfig(opts ...Option) *config {
+	c := &config{spanNameGenerator: _defaultSpanNameGenerator}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *config) getTracer() tracer.Tracer {
+	if c.tracer != nil {
+		return c.tracer
+	}
+	return tracer.GetGlobalTracer()
+}
+
+func (c *config) skip(fullMethod string) bool {
+	return c.filter != nil && !c.filter(fullMethod)
+}
+
+// startServerSpan starts the entry span of an inbound call. It continues
+// the trace propagated by the incoming metadata and injects the request id
+// of the metadata, or a generated one, into the returned context.
+func (c *config) startServerSpan(ctx context.Context, fullMethod string) (context.Context, tracer.Span) {
+	t := c.getTracer()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if propagator, ok := t.(tracer.Propagator); ok {
+		ctx = propagator.Extract(ctx, metadataCarrier(md))
+	}
+
+	if requestID := metadataCarrier(md).Get(requestIDKey); requestID != "" {
+		ctx = reqid.InjectValue(ctx, requestID)
+	} else {
+		ctx = reqid.Inject(ctx)
+	}
+
+	ctx, span := t.StartSpan(ctx, c.spanNameGenerator(fullMethod),
+		tracer.WithSpanType(tracer.SpanTypeEntry),
+		tracer.WithSpanLayer(tracer.SpanLayerRPC),
+	)
+	span.SetAttributes(rpcAttributes(fullMethod)...)
+	span.SetAttributes(attribute.RequestID(reqid.RetrieveFromContext(ctx)))
+	return ctx, span
+}
+
+// startClientSpan starts the exit span of an outbound call to the target
+// and propagates its trace context and the request id through the outgoing
+// metadata. The target is recorded as the peer before the trace context is
+// propagated, since some backends propagate the peer along.
+func (c *config) startClientSpan(ctx context.Context, target, fullMethod string) (context.Context, tracer.Span) {
+	t := c.getTracer()
+
+	ctx, span := t.StartSpan(ctx, c.spanNameGenerator(fullMethod),
+		tracer.WithSpanType(tracer.SpanTypeExit),
+		tracer.WithSpanLayer(tracer.SpanLayerRPC),
+	)
+	span.SetAttributes(rpcAttributes(fullMethod)...)
+	span.SetAttributes(peerAttributes(target)...)
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	if propagator, ok := t.(tracer.Propagator); ok {
+		propagator.Inject(ctx, metadataCarrier(md))
+	}
+	if requestID := reqid.RetrieveFromContext(ctx); requestID != "" {
+		md.Set(requestIDKey, requestID)
+		span.SetAttributes(attribute.RequestID(requestID))
+	}
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// finishServerSpan records the status of the call. Only the server error
+// codes mark the span as failed.
+func finishServerSpan(span tracer.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(attribute.RPCGRPCStatusCode(int(code)))
+	if _, ok := serverErrorCodes[code]; ok {
+		span.RecordError(err)
+		span.SetStatus(tracer.StatusError, code.String())
+	}
+}
+
+// finishClientSpan records the status of the call. Any code other than OK
+// marks the span as failed.
+func finishClientSpan(span tracer.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(attribute.RPCGRPCStatusCode(int(code)))
+	if code != codes.OK {
+		span.RecordError(err)
+		span.SetStatus(tracer.StatusError, code.String())
+	}
+}
+
+// recordPanic marks the span as failed with the recovered value and panics
+// again, leaving the recovery to the outer interceptors.
+func recordPanic(span tracer.Span) {
+	if recovered := recover(); recovered != nil {
+		err := fmt.Errorf("panic: %v", recovered)
+		span.RecordError(err)
+		span.SetStatus(tracer.StatusError, err.Error())
+		panic(recovered)
+	}
+}
+
+// rpcAttributes returns the rpc.* attributes of the full method, formatted
+// as "/package.Service/Method".
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	service, method := splitFullMethod(fullMethod)
+	attrs := []attribute.KeyValue{attribute.RPCSystem("grpc")}
+	if service != "" {
+		attrs = append(attrs, attribute.RPCService(service))
+	}
+	if method != "" {
+		attrs = append(attrs, attribute.RPCMethod(method))
+	}
+	return attrs
+}
+
+// peerAttributes returns the server.address and server.port attributes of
+// the target of a client connection, such as "dns:///localhost:50051" or
+// "localhost:50051".
+func peerAttributes(target string) []attribute.KeyValue {
+	endpoint := target
+	if _, rest, ok := strings.Cut(target, "://"); ok {
+		// The endpoint follows the optional authority of the scheme.
+		_, endpoint, _ = strings.Cut(rest, "/")
+	}
+	if endpoint == "" {
+		return nil
+	}
+
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return []attribute.KeyValue{attribute.HTTPServerAddress(endpoint)}
+	}
+	attrs := []attribute.KeyValue{attribute.HTTPServerAddress(host)}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, attribute.HTTPServerPort(p))
+	}
+	return attrs
+}
+
+func splitFullMethod(fullMethod string) (service, method string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "", fullMethod
+}
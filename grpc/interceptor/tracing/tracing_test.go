@@ -0,0 +1,361 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"github.com/rmscoal/tengcorux/tracer/tracetest"
+)
+
+// startTestingServer serves the health service over an in-memory listener
+// with the interceptors on both ends, and returns the client.
+func startTestingServer(t *testing.T, tr tracer.Tracer) healthpb.HealthClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(WithTracer(tr))),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(WithTracer(tr))),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor(WithTracer(tr))),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor(WithTracer(tr))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+// endedSpans waits until the recorder holds n ended spans, since the server
+// may end its span after the client has returned.
+func endedSpans(t *testing.T, tr *tracetest.Tracer, n int) (client, server *tracetest.ReadOnlySpan) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(tr.Recorder().EndedSpans()) < n && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	spans := tr.Recorder().EndedSpans()
+	if len(spans) != n {
+		t.Fatalf("expected %d spans, got %d", n, len(spans))
+	}
+	for _, span := range spans {
+		if span.Type == tracer.SpanTypeExit {
+			client = span
+		} else {
+			server = span
+		}
+	}
+	if client == nil || server == nil {
+		t.Fatal("expected both a client and a server span")
+	}
+	return client, server
+}
+
+func TestInterceptors_Unary(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		client := startTestingServer(t, tr)
+
+		var header metadata.MD
+		ctx := reqid.InjectValue(context.Background(), "request-id")
+		_, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := header.Get(requestIDKey); len(got) != 1 || got[0] != "request-id" {
+			t.Errorf("expected the request id to be echoed, got %v", got)
+		}
+
+		clientSpan, serverSpan := endedSpans(t, tr, 2)
+		if serverSpan.TraceID != clientSpan.TraceID ||
+			serverSpan.ParentSpanID != clientSpan.SpanID {
+			t.Error("expected the server span to continue the client trace")
+		}
+		if serverSpan.Layer != tracer.SpanLayerRPC || clientSpan.Layer != tracer.SpanLayerRPC {
+			t.Error("expected rpc layer spans")
+		}
+
+		for _, span := range []*tracetest.ReadOnlySpan{clientSpan, serverSpan} {
+			if span.Name != "grpc.health.v1.Health/Check" {
+				t.Errorf("unexpected span name %s", span.Name)
+			} else if span.Status != tracer.StatusUnset {
+				t.Errorf("expected unset status, got %s", span.Status)
+			}
+
+			tests := map[attribute.Key]attribute.Value{
+				attribute.RPCSystemKey:         attribute.StringValue("grpc"),
+				attribute.RPCServiceKey:        attribute.StringValue("grpc.health.v1.Health"),
+				attribute.RPCMethodKey:         attribute.StringValue("Check"),
+				attribute.RPCGRPCStatusCodeKey: attribute.IntValue(int(codes.OK)),
+				attribute.RequestIDKey:         attribute.StringValue("request-id"),
+			}
+			for key, want := range tests {
+				if got, _ := span.Attribute(key); got != want {
+					t.Errorf("%s: want %v, got %v", key, want, got)
+				}
+			}
+		}
+
+		if got, _ := clientSpan.Attribute(attribute.HTTPServerAddressKey); got.AsString() != "bufconn" {
+			t.Errorf("expected the target to be the client peer, got %v", got)
+		}
+		if _, ok := serverSpan.Attribute(attribute.HTTPServerAddressKey); ok {
+			t.Error("expected the server span to have no peer")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		client := startTestingServer(t, tr)
+
+		_, err := client.Check(context.Background(),
+			&healthpb.HealthCheckRequest{Service: "unknown"})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected NotFound, got %v", err)
+		}
+
+		clientSpan, serverSpan := endedSpans(t, tr, 2)
+		if clientSpan.Status != tracer.StatusError || clientSpan.Error == nil {
+			t.Error("expected the client span to be failed")
+		}
+		if serverSpan.Status != tracer.StatusUnset || serverSpan.Error != nil {
+			t.Error("expected NotFound to not fail the server span")
+		}
+		if got, _ := serverSpan.Attribute(attribute.RPCGRPCStatusCodeKey); got.AsInt64() != int64(codes.NotFound) {
+			t.Errorf("expected status code NotFound, got %v", got)
+		}
+		if got, _ := serverSpan.Attribute(attribute.RequestIDKey); got.AsString() == "" {
+			t.Error("expected a request id to be generated")
+		}
+	})
+}
+
+func TestInterceptors_Stream(t *testing.T) {
+	tr := tracetest.NewTracer()
+	client := startTestingServer(t, tr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	} else if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("unexpected status %s", resp.Status)
+	}
+	if len(tr.Recorder().EndedSpans()) != 0 {
+		t.Error("expected the spans to be open while streaming")
+	}
+
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Fatalf("expected Canceled, got %v", err)
+	}
+
+	clientSpan, serverSpan := endedSpans(t, tr, 2)
+	if serverSpan.ParentSpanID != clientSpan.SpanID {
+		t.Error("expected the server span to continue the client trace")
+	}
+	if clientSpan.Name != "grpc.health.v1.Health/Watch" {
+		t.Errorf("unexpected span name %s", clientSpan.Name)
+	}
+	if got, _ := clientSpan.Attribute(attribute.RPCGRPCStatusCodeKey); got.AsInt64() != int64(codes.Canceled) {
+		t.Errorf("expected status code Canceled, got %v", got)
+	}
+	if clientSpan.Status != tracer.StatusError {
+		t.Errorf("expected error status, got %s", clientSpan.Status)
+	}
+}
+
+func TestInterceptors_Stream_Abandoned(t *testing.T) {
+	tr := tracetest.NewTracer()
+	client := startTestingServer(t, tr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The stream is left without receiving again.
+	cancel()
+
+	clientSpan, _ := endedSpans(t, tr, 2)
+	if got, _ := clientSpan.Attribute(attribute.RPCGRPCStatusCodeKey); got.AsInt64() != int64(codes.Canceled) {
+		t.Errorf("expected status code Canceled, got %v", got)
+	}
+	if clientSpan.Status != tracer.StatusError {
+		t.Errorf("expected error status, got %s", clientSpan.Status)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/helloworld.Greeter/SayHello"}
+
+	tests := []struct {
+		name string
+		err  error
+		want tracer.StatusCode
+	}{
+		{"OK", nil, tracer.StatusUnset},
+		{"Client Error", status.Error(codes.InvalidArgument, "bad"), tracer.StatusUnset},
+		{"Server Error", status.Error(codes.Internal, "boom"), tracer.StatusError},
+		{"Plain Error", errors.New("boom"), tracer.StatusError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := tracetest.NewTracer()
+			interceptor := UnaryServerInterceptor(WithTracer(tr))
+			_, err := interceptor(context.Background(), nil, info,
+				func(ctx context.Context, req any) (any, error) {
+					return nil, tc.err
+				})
+			if err != tc.err {
+				t.Errorf("expected the handler error, got %v", err)
+			}
+
+			span := tr.Recorder().EndedSpans()[0]
+			if span.Status != tc.want {
+				t.Errorf("expected status %s, got %s", tc.want, span.Status)
+			}
+			if got, _ := span.Attribute(attribute.RPCGRPCStatusCodeKey); got.AsInt64() != int64(status.Code(tc.err)) {
+				t.Errorf("expected status code %s, got %v", status.Code(tc.err), got)
+			}
+		})
+	}
+
+	t.Run("Panic", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		interceptor := UnaryServerInterceptor(WithTracer(tr))
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected the panic to be propagated")
+				}
+			}()
+			_, _ = interceptor(context.Background(), nil, info,
+				func(ctx context.Context, req any) (any, error) {
+					panic("boom")
+				})
+		}()
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Error == nil || span.Status != tracer.StatusError {
+			t.Error("expected the panic to be recorded")
+		}
+	})
+}
+
+func TestOptions(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+
+	t.Run("WithSpanNameGenerator", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		interceptor := UnaryServerInterceptor(WithTracer(tr),
+			WithSpanNameGenerator(func(fullMethod string) string { return "check" }))
+		_, _ = interceptor(context.Background(), nil, info, handler)
+
+		if name := tr.Recorder().EndedSpans()[0].Name; name != "check" {
+			t.Errorf("expected span name check, got %s", name)
+		}
+	})
+
+	t.Run("WithFilter", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		interceptor := UnaryServerInterceptor(WithTracer(tr),
+			WithFilter(func(fullMethod string) bool {
+				return fullMethod != "/grpc.health.v1.Health/Check"
+			}))
+		_, _ = interceptor(context.Background(), nil, info, handler)
+
+		if len(tr.Recorder().StartedSpans()) != 0 {
+			t.Error("expected the filtered call to not be traced")
+		}
+	})
+}
+
+func TestSplitFullMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod, service, method string
+	}{
+		{"/helloworld.Greeter/SayHello", "helloworld.Greeter", "SayHello"},
+		{"helloworld.Greeter/SayHello", "helloworld.Greeter", "SayHello"},
+		{"SayHello", "", "SayHello"},
+		{"", "", ""},
+	}
+
+	for _, tc := range tests {
+		service, method := splitFullMethod(tc.fullMethod)
+		if service != tc.service || method != tc.method {
+			t.Errorf("%q: want %q %q, got %q %q", tc.fullMethod, tc.service,
+				tc.method, service, method)
+		}
+	}
+}
+
+func TestPeerAttributes(t *testing.T) {
+	tests := []struct {
+		target string
+		want   []attribute.KeyValue
+	}{
+		{"dns:///localhost:50051", []attribute.KeyValue{
+			attribute.HTTPServerAddress("localhost"),
+			attribute.HTTPServerPort(50051),
+		}},
+		{"dns://8.8.8.8/example.com:443", []attribute.KeyValue{
+			attribute.HTTPServerAddress("example.com"),
+			attribute.HTTPServerPort(443),
+		}},
+		{"localhost:50051", []attribute.KeyValue{
+			attribute.HTTPServerAddress("localhost"),
+			attribute.HTTPServerPort(50051),
+		}},
+		{"passthrough:///bufconn", []attribute.KeyValue{
+			attribute.HTTPServerAddress("bufconn"),
+		}},
+		{"", nil},
+	}
+
+	for _, tc := range tests {
+		if got := peerAttributes(tc.target); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: want %v, got %v", tc.target, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+package tracing
+
+func Version() string {
+	return "v0.1.0"
+}
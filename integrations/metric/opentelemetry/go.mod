@@ -0,0 +1,24 @@
+module github.com/rmscoal/tengcorux/integrations/metric/opentelemetry
+
+go 1.21
+
+require (
+	github.com/rmscoal/tengcorux/metric v0.0.0-20261017174934-22fc87409d0a
+	github.com/rmscoal/tengcorux/tracer v0.1.5-0.20261017175312-3ec99204e181
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/sdk/metric v1.28.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/otel/trace v1.28.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+)
+
+replace github.com/rmscoal/tengcorux/tracer => ../../../tracer
+
+replace github.com/rmscoal/tengcorux/metric => ../../../metric
@@ -0,0 +1,72 @@
+package opentelemetry
+
+import (
+	"context"
+
+	tengcoruxMetric "github.com/rmscoal/tengcorux/metric"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type int64Counter struct {
+	counter metric.Int64Counter
+}
+
+func (c *int64Counter) Add(ctx context.Context, incr int64, opts ...tengcoruxMetric.RecordOption) {
+	c.counter.Add(ctx, incr, measurementOption(opts))
+}
+
+type float64Counter struct {
+	counter metric.Float64Counter
+}
+
+func (c *float64Counter) Add(ctx context.Context, incr float64, opts ...tengcoruxMetric.RecordOption) {
+	c.counter.Add(ctx, incr, measurementOption(opts))
+}
+
+type int64UpDownCounter struct {
+	counter metric.Int64UpDownCounter
+}
+
+func (c *int64UpDownCounter) Add(ctx context.Context, incr int64, opts ...tengcoruxMetric.RecordOption) {
+	c.counter.Add(ctx, incr, measurementOption(opts))
+}
+
+type float64UpDownCounter struct {
+	counter metric.Float64UpDownCounter
+}
+
+func (c *float64UpDownCounter) Add(ctx context.Context, incr float64, opts ...tengcoruxMetric.RecordOption) {
+	c.counter.Add(ctx, incr, measurementOption(opts))
+}
+
+type int64Histogram struct {
+	histogram metric.Int64Histogram
+}
+
+func (h *int64Histogram) Record(ctx context.Context, value int64, opts ...tengcoruxMetric.RecordOption) {
+	h.histogram.Record(ctx, value, measurementOption(opts))
+}
+
+type float64Histogram struct {
+	histogram metric.Float64Histogram
+}
+
+func (h *float64Histogram) Record(ctx context.Context, value float64, opts ...tengcoruxMetric.RecordOption) {
+	h.histogram.Record(ctx, value, measurementOption(opts))
+}
+
+type int64Gauge struct {
+	gauge metric.Int64Gauge
+}
+
+func (g *int64Gauge) Record(ctx context.Context, value int64, opts ...tengcoruxMetric.RecordOption) {
+	g.gauge.Record(ctx, value, measurementOption(opts))
+}
+
+type float64Gauge struct {
+	gauge metric.Float64Gauge
+}
+
+func (g *float64Gauge) Record(ctx context.Context, value float64, opts ...tengcoruxMetric.RecordOption) {
+	g.gauge.Record(ctx, value, measurementOption(opts))
+}
@@ -0,0 +1,149 @@
+package opentelemetry
+
+import (
+	"context"
+
+	tengcoruxMetric "github.com/rmscoal/tengcorux/metric"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+var _ tengcoruxMetric.Meter = (*Meter)(nil)
+
+type Meter struct {
+	meter       metric.Meter
+	provider    metric.MeterProvider
+	readers     []sdkmetric.Reader
+	shutdowns   []func(context.Context) error
+	serviceName string
+	environment string
+}
+
+// Int64Counter creates an OpenTelemetry int64 counter.
+func (m *Meter) Int64Counter(name string,
+	opts ...tengcoruxMetric.InstrumentOption,
+) (tengcoruxMetric.Int64Counter, error) {
+	cfg := tengcoruxMetric.NewInstrumentConfig(opts...)
+	counter, err := m.meter.Int64Counter(name,
+		metric.WithDescription(cfg.Description), metric.WithUnit(cfg.Unit))
+	if err != nil {
+		return nil, err
+	}
+	return &int64Counter{counter: counter}, nil
+}
+
+// Float64Counter creates an OpenTelemetry float64 counter.
+func (m *Meter) Float64Counter(name string,
+	opts ...tengcoruxMetric.InstrumentOption,
+) (tengcoruxMetric.Float64Counter, error) {
+	cfg := tengcoruxMetric.NewInstrumentConfig(opts...)
+	counter, err := m.meter.Float64Counter(name,
+		metric.WithDescription(cfg.Description), metric.WithUnit(cfg.Unit))
+	if err != nil {
+		return nil, err
+	}
+	return &float64Counter{counter: counter}, nil
+}
+
+// Int64UpDownCounter creates an OpenTelemetry int64 up-down counter.
+func (m *Meter) Int64UpDownCounter(name string,
+	opts ...tengcoruxMetric.InstrumentOption,
+) (tengcoruxMetric.Int64UpDownCounter, error) {
+	cfg := tengcoruxMetric.NewInstrumentConfig(opts...)
+	counter, err := m.meter.Int64UpDownCounter(name,
+		metric.WithDescription(cfg.Description), metric.WithUnit(cfg.Unit))
+	if err != nil {
+		return nil, err
+	}
+	return &int64UpDownCounter{counter: counter}, nil
+}
+
+// Float64UpDownCounter creates an OpenTelemetry float64 up-down counter.
+func (m *Meter) Float64UpDownCounter(name string,
+	opts ...tengcoruxMetric.InstrumentOption,
+) (tengcoruxMetric.Float64UpDownCounter, error) {
+	cfg := tengcoruxMetric.NewInstrumentConfig(opts...)
+	counter, err := m.meter.Float64UpDownCounter(name,
+		metric.WithDescription(cfg.Description), metric.WithUnit(cfg.Unit))
+	if err != nil {
+		return nil, err
+	}
+	return &float64UpDownCounter{counter: counter}, nil
+}
+
+// Int64Histogram creates an OpenTelemetry int64 histogram, with the explicit
+// bucket boundaries if given.
+func (m *Meter) Int64Histogram(name string,
+	opts ...tengcoruxMetric.InstrumentOption,
+) (tengcoruxMetric.Int64Histogram, error) {
+	cfg := tengcoruxMetric.NewInstrumentConfig(opts...)
+	histogramOpts := []metric.Int64HistogramOption{
+		metric.WithDescription(cfg.Description), metric.WithUnit(cfg.Unit),
+	}
+	if len(cfg.Buckets) > 0 {
+		histogramOpts = append(histogramOpts,
+			metric.WithExplicitBucketBoundaries(cfg.Buckets...))
+	}
+	histogram, err := m.meter.Int64Histogram(name, histogramOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &int64Histogram{histogram: histogram}, nil
+}
+
+// Float64Histogram creates an OpenTelemetry float64 histogram, with the
+// explicit bucket boundaries if given.
+func (m *Meter) Float64Histogram(name string,
+	opts ...tengcoruxMetric.InstrumentOption,
+) (tengcoruxMetric.Float64Histogram, error) {
+	cfg := tengcoruxMetric.NewInstrumentConfig(opts...)
+	histogramOpts := []metric.Float64HistogramOption{
+		metric.WithDescription(cfg.Description), metric.WithUnit(cfg.Unit),
+	}
+	if len(cfg.Buckets) > 0 {
+		histogramOpts = append(histogramOpts,
+			metric.WithExplicitBucketBoundaries(cfg.Buckets...))
+	}
+	histogram, err := m.meter.Float64Histogram(name, histogramOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &float64Histogram{histogram: histogram}, nil
+}
+
+// Int64Gauge creates an OpenTelemetry int64 gauge.
+func (m *Meter) Int64Gauge(name string,
+	opts ...tengcoruxMetric.InstrumentOption,
+) (tengcoruxMetric.Int64Gauge, error) {
+	cfg := tengcoruxMetric.NewInstrumentConfig(opts...)
+	gauge, err := m.meter.Int64Gauge(name,
+		metric.WithDescription(cfg.Description), metric.WithUnit(cfg.Unit))
+	if err != nil {
+		return nil, err
+	}
+	return &int64Gauge{gauge: gauge}, nil
+}
+
+// Float64Gauge creates an OpenTelemetry float64 gauge.
+func (m *Meter) Float64Gauge(name string,
+	opts ...tengcoruxMetric.InstrumentOption,
+) (tengcoruxMetric.Float64Gauge, error) {
+	cfg := tengcoruxMetric.NewInstrumentConfig(opts...)
+	gauge, err := m.meter.Float64Gauge(name,
+		metric.WithDescription(cfg.Description), metric.WithUnit(cfg.Unit))
+	if err != nil {
+		return nil, err
+	}
+	return &float64Gauge{gauge: gauge}, nil
+}
+
+// Shutdown flushes and shuts down the provider created by the meter. A
+// provider given through WithMeterProvider is left to its owner.
+func (m *Meter) Shutdown(ctx context.Context) error {
+	for _, shutdown := range m.shutdowns {
+		if err := shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	tengcoruxMetric "github.com/rmscoal/tengcorux/metric"
+	tengcoruxAttribute "github.com/rmscoal/tengcorux/tracer/attribute"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Metrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect: %v", err)
+	}
+
+	metrics := make(map[string]metricdata.Metrics)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+	return metrics
+}
+
+func TestMeter_Instruments(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	meter := NewMeter("test-service", WithReader(reader))
+	defer meter.Shutdown(ctx)
+
+	method := tengcoruxMetric.WithAttributes(tengcoruxAttribute.HTTPRequestMethod("GET"))
+	wantAttributes := attribute.NewSet(attribute.String("http.request.method", "GET"))
+
+	int64Counter, err := meter.Int64Counter("int64.counter",
+		tengcoruxMetric.WithDescription("An int64 counter."), tengcoruxMetric.WithUnit("1"))
+	if err != nil {
+		t.Fatalf("expected nil error but got %v", err)
+	}
+	int64Counter.Add(ctx, 2, method)
+	int64Counter.Add(ctx, 3, method)
+
+	float64Counter, err := meter.Float64Counter("float64.counter")
+	if err != nil {
+		t.Fatalf("expected nil error but got %v", err)
+	}
+	float64Counter.Add(ctx, 1.5, method)
+
+	int64UpDownCounter, err := meter.Int64UpDownCounter("int64.updown")
+	if err != nil {
+		t.Fatalf("expected nil error but got %v", err)
+	}
+	int64UpDownCounter.Add(ctx, 2)
+	int64UpDownCounter.Add(ctx, -3)
+
+	float64UpDownCounter, err := meter.Float64UpDownCounter("float64.updown")
+	if err != nil {
+		t.Fatalf("expected nil error but got %v", err)
+	}
+	float64UpDownCounter.Add(ctx, -0.5)
+
+	int64Histogram, err := meter.Int64Histogram("int64.histogram",
+		tengcoruxMetric.WithBuckets(10, 100))
+	if err != nil {
+		t.Fatalf("expected nil error but got %v", err)
+	}
+	int64Histogram.Record(ctx, 5, method)
+	int64Histogram.Record(ctx, 50, method)
+
+	float64Histogram, err := meter.Float64Histogram("float64.histogram",
+		tengcoruxMetric.WithUnit("s"))
+	if err != nil {
+		t.Fatalf("expected nil error but got %v", err)
+	}
+	float64Histogram.Record(ctx, 0.25)
+
+	int64Gauge, err := meter.Int64Gauge("int64.gauge")
+	if err != nil {
+		t.Fatalf("expected nil error but got %v", err)
+	}
+	int64Gauge.Record(ctx, 4)
+	int64Gauge.Record(ctx, 7)
+
+	float64Gauge, err := meter.Float64Gauge("float64.gauge")
+	if err != nil {
+		t.Fatalf("expected nil error but got %v", err)
+	}
+	float64Gauge.Record(ctx, 0.75)
+
+	metrics := collect(t, reader)
+
+	t.Run("Counter", func(t *testing.T) {
+		m := metrics["int64.counter"]
+		if m.Description != "An int64 counter." || m.Unit != "1" {
+			t.Errorf("unexpected description %q or unit %q", m.Description, m.Unit)
+		}
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		if !ok || !sum.IsMonotonic || len(sum.DataPoints) != 1 {
+			t.Fatalf("unexpected data %+v", m.Data)
+		}
+		if sum.DataPoints[0].Value != 5 {
+			t.Errorf("expected 5 but got %d", sum.DataPoints[0].Value)
+		}
+		if !sum.DataPoints[0].Attributes.Equals(&wantAttributes) {
+			t.Errorf("unexpected attributes %v", sum.DataPoints[0].Attributes)
+		}
+
+		floatSum, ok := metrics["float64.counter"].Data.(metricdata.Sum[float64])
+		if !ok || floatSum.DataPoints[0].Value != 1.5 {
+			t.Errorf("unexpected data %+v", metrics["float64.counter"].Data)
+		}
+	})
+	t.Run("UpDownCounter", func(t *testing.T) {
+		sum, ok := metrics["int64.updown"].Data.(metricdata.Sum[int64])
+		if !ok || sum.IsMonotonic || sum.DataPoints[0].Value != -1 {
+			t.Errorf("unexpected data %+v", metrics["int64.updown"].Data)
+		}
+
+		floatSum, ok := metrics["float64.updown"].Data.(metricdata.Sum[float64])
+		if !ok || floatSum.IsMonotonic || floatSum.DataPoints[0].Value != -0.5 {
+			t.Errorf("unexpected data %+v", metrics["float64.updown"].Data)
+		}
+	})
+	t.Run("Histogram", func(t *testing.T) {
+		histogram, ok := metrics["int64.histogram"].Data.(metricdata.Histogram[int64])
+		if !ok || len(histogram.DataPoints) != 1 {
+			t.Fatalf("unexpected data %+v", metrics["int64.histogram"].Data)
+		}
+		dp := histogram.DataPoints[0]
+		if dp.Count != 2 || dp.Sum != 55 {
+			t.Errorf("expected count 2 and sum 55 but got %d and %d", dp.Count, dp.Sum)
+		}
+		if len(dp.Bounds) != 2 || dp.Bounds[0] != 10 || dp.Bounds[1] != 100 {
+			t.Errorf("expected bounds [10 100] but got %v", dp.Bounds)
+		}
+		if !dp.Attributes.Equals(&wantAttributes) {
+			t.Errorf("unexpected attributes %v", dp.Attributes)
+		}
+
+		if metrics["float64.histogram"].Unit != "s" {
+			t.Errorf("expected unit s but got %q", metrics["float64.histogram"].Unit)
+		}
+		floatHistogram, ok := metrics["float64.histogram"].Data.(metricdata.Histogram[float64])
+		if !ok || floatHistogram.DataPoints[0].Count != 1 {
+			t.Errorf("unexpected data %+v", metrics["float64.histogram"].Data)
+		}
+	})
+	t.Run("Gauge", func(t *testing.T) {
+		gauge, ok := metrics["int64.gauge"].Data.(metricdata.Gauge[int64])
+		if !ok || gauge.DataPoints[0].Value != 7 {
+			t.Errorf("unexpected data %+v", metrics["int64.gauge"].Data)
+		}
+
+		floatGauge, ok := metrics["float64.gauge"].Data.(metricdata.Gauge[float64])
+		if !ok || floatGauge.DataPoints[0].Value != 0.75 {
+			t.Errorf("unexpected data %+v", metrics["float64.gauge"].Data)
+		}
+	})
+}
+
+func TestMeter_InvalidInstrument(t *testing.T) {
+	meter := NewMeter("test-service", WithReader(sdkmetric.NewManualReader()))
+	defer meter.Shutdown(context.Background())
+
+	if _, err := meter.Int64Counter("invalid name!"); err == nil {
+		t.Error("expected error but got nil")
+	}
+}
@@ -0,0 +1,84 @@
+// Package opentelemetry integrates tengcorux's metric package with
+// OpenTelemetry, recording the measurements through an OpenTelemetry
+// [metric.MeterProvider].
+package opentelemetry
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// NewMeter creates a Meter named after the service. The measurements are
+// exported by the readers given through WithReader, or recorded by the
+// provider given through WithMeterProvider. Otherwise, the global
+// OpenTelemetry meter provider is used.
+func NewMeter(serviceName string, opts ...Option) *Meter {
+	meter := &Meter{
+		serviceName: serviceName,
+	}
+
+	for _, opt := range opts {
+		opt(meter)
+	}
+
+	if meter.provider == nil && len(meter.readers) > 0 {
+		attrs := []attribute.KeyValue{
+			attribute.String("service.name", meter.serviceName),
+		}
+		if meter.environment != "" {
+			attrs = append(attrs,
+				attribute.String("deployment.environment", meter.environment))
+		}
+		providerOpts := []sdkmetric.Option{
+			sdkmetric.WithResource(resource.NewSchemaless(attrs...)),
+		}
+		for _, reader := range meter.readers {
+			providerOpts = append(providerOpts, sdkmetric.WithReader(reader))
+		}
+		provider := sdkmetric.NewMeterProvider(providerOpts...)
+		meter.shutdowns = append(meter.shutdowns, provider.Shutdown)
+		meter.provider = provider
+	}
+	if meter.provider == nil {
+		meter.provider = otel.GetMeterProvider()
+	}
+
+	meter.meter = meter.provider.Meter(meter.serviceName)
+
+	return meter
+}
+
+// Version returns the current meter's version
+func (m *Meter) Version() string {
+	return "v0.1.0"
+}
+
+type Option func(*Meter)
+
+// WithReader exports the measurements through the given reader, such as a
+// periodic reader wrapping an exporter. Multiple readers may be given.
+func WithReader(reader sdkmetric.Reader) Option {
+	return func(meter *Meter) {
+		meter.readers = append(meter.readers, reader)
+	}
+}
+
+// WithMeterProvider records the measurements through an existing provider,
+// which is owned by the caller and is not shut down by the meter. It takes
+// precedence over WithReader.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(meter *Meter) {
+		meter.provider = provider
+	}
+}
+
+// WithEnvironment sets the "deployment.environment" resource attribute of
+// the meter provider built along WithReader.
+func WithEnvironment(env string) Option {
+	return func(meter *Meter) {
+		meter.environment = env
+	}
+}
@@ -0,0 +1,89 @@
+package opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewMeter(t *testing.T) {
+	t.Run("Global Provider", func(t *testing.T) {
+		meter := NewMeter("test-service")
+		if meter.provider != otel.GetMeterProvider() {
+			t.Error("expected the global meter provider")
+		}
+		if len(meter.shutdowns) != 0 {
+			t.Error("expected no shutdowns")
+		}
+	})
+	t.Run("With Reader", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		meter := NewMeter("test-service", WithReader(reader))
+		if len(meter.shutdowns) != 1 {
+			t.Fatalf("expected 1 shutdown but got %d", len(meter.shutdowns))
+		}
+
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		name, ok := rm.Resource.Set().Value("service.name")
+		if !ok || name.AsString() != "test-service" {
+			t.Errorf("expected service.name test-service but got %v", name.AsString())
+		}
+
+		if err := meter.Shutdown(context.Background()); err != nil {
+			t.Errorf("expected nil error but got %v", err)
+		}
+		if err := reader.Collect(context.Background(), &rm); err == nil {
+			t.Error("expected reader to be shut down")
+		}
+	})
+	t.Run("With Meter Provider", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		meter := NewMeter("test-service", WithMeterProvider(provider),
+			WithReader(sdkmetric.NewManualReader()))
+		if meter.provider != provider {
+			t.Error("expected the given meter provider")
+		}
+
+		if err := meter.Shutdown(context.Background()); err != nil {
+			t.Errorf("expected nil error but got %v", err)
+		}
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Errorf("expected the given provider to remain open but got %v", err)
+		}
+	})
+	t.Run("With Environment", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		meter := NewMeter("test-service", WithEnvironment("staging"),
+			WithReader(reader))
+		if meter.serviceName != "test-service" {
+			t.Errorf("expected test-service but got %s", meter.serviceName)
+		}
+
+		var rm metricdata.ResourceMetrics
+		if err := reader.Collect(context.Background(), &rm); err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		name, _ := rm.Resource.Set().Value("service.name")
+		if name.AsString() != "test-service" {
+			t.Errorf("expected service.name test-service but got %v", name.AsString())
+		}
+		env, ok := rm.Resource.Set().Value("deployment.environment")
+		if !ok || env.AsString() != "staging" {
+			t.Errorf("expected deployment.environment staging but got %v", env.AsString())
+		}
+	})
+}
+
+func TestMeter_Version(t *testing.T) {
+	if version := NewMeter("test-service").Version(); version != "v0.1.0" {
+		t.Errorf("expected v0.1.0 but got %s", version)
+	}
+}
@@ -0,0 +1,46 @@
+package opentelemetry
+
+import (
+	tengcoruxMetric "github.com/rmscoal/tengcorux/metric"
+	tengcoruxAttribute "github.com/rmscoal/tengcorux/tracer/attribute"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// measurementOption maps the record options into an OpenTelemetry
+// measurement option carrying the attribute set.
+func measurementOption(opts []tengcoruxMetric.RecordOption) metric.MeasurementOption {
+	cfg := tengcoruxMetric.NewRecordConfig(opts...)
+	return metric.WithAttributeSet(attribute.NewSet(mapAttributes(cfg.Attributes)...))
+}
+
+// mapAttributes maps tengcorux attributes into OpenTelemetry attributes.
+func mapAttributes(tengcoruxAttributes []tengcoruxAttribute.KeyValue) []attribute.KeyValue {
+	var attributes []attribute.KeyValue
+
+	for _, attr := range tengcoruxAttributes {
+		key := string(attr.Key)
+		switch attr.Value.Type() {
+		case tengcoruxAttribute.TypeBool:
+			attributes = append(attributes, attribute.Bool(key, attr.Value.AsBool()))
+		case tengcoruxAttribute.TypeInt64:
+			attributes = append(attributes, attribute.Int64(key, attr.Value.AsInt64()))
+		case tengcoruxAttribute.TypeFloat64:
+			attributes = append(attributes, attribute.Float64(key, attr.Value.AsFloat64()))
+		case tengcoruxAttribute.TypeString:
+			attributes = append(attributes, attribute.String(key, attr.Value.AsString()))
+		case tengcoruxAttribute.TypeBoolSlice:
+			attributes = append(attributes, attribute.BoolSlice(key, attr.Value.AsBoolSlice()))
+		case tengcoruxAttribute.TypeInt64Slice:
+			attributes = append(attributes, attribute.Int64Slice(key, attr.Value.AsInt64Slice()))
+		case tengcoruxAttribute.TypeFloat64Slice:
+			attributes = append(attributes, attribute.Float64Slice(key, attr.Value.AsFloat64Slice()))
+		case tengcoruxAttribute.TypeStringSlice:
+			attributes = append(attributes, attribute.StringSlice(key, attr.Value.AsStringSlice()))
+		default:
+			// Invalid values, such as nil, have no OpenTelemetry counterpart.
+		}
+	}
+
+	return attributes
+}
@@ -0,0 +1,41 @@
+package opentelemetry
+
+import (
+	"testing"
+
+	tengcoruxAttribute "github.com/rmscoal/tengcorux/tracer/attribute"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestMapAttributes(t *testing.T) {
+	attributes := mapAttributes([]tengcoruxAttribute.KeyValue{
+		tengcoruxAttribute.Bool("bool", true),
+		tengcoruxAttribute.Int64("int64", 1),
+		tengcoruxAttribute.Float64("float64", 1.5),
+		tengcoruxAttribute.String("string", "value"),
+		tengcoruxAttribute.BoolSlice("bool_slice", []bool{true}),
+		tengcoruxAttribute.Int64Slice("int64_slice", []int64{1}),
+		tengcoruxAttribute.Float64Slice("float64_slice", []float64{1.5}),
+		tengcoruxAttribute.StringSlice("string_slice", []string{"value"}),
+		{Key: "invalid"},
+	})
+
+	want := map[string]attribute.Type{
+		"bool":          attribute.BOOL,
+		"int64":         attribute.INT64,
+		"float64":       attribute.FLOAT64,
+		"string":        attribute.STRING,
+		"bool_slice":    attribute.BOOLSLICE,
+		"int64_slice":   attribute.INT64SLICE,
+		"float64_slice": attribute.FLOAT64SLICE,
+		"string_slice":  attribute.STRINGSLICE,
+	}
+	if len(attributes) != len(want) {
+		t.Fatalf("expected %d attributes but got %d", len(want), len(attributes))
+	}
+	for _, attr := range attributes {
+		if typ, ok := want[string(attr.Key)]; !ok || typ != attr.Value.Type() {
+			t.Errorf("unexpected attribute %s of type %s", attr.Key, attr.Value.Type())
+		}
+	}
+}
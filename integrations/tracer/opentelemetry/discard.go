@@ -0,0 +1,35 @@
+package opentelemetry
+
+import (
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// discardingProcessor wraps the span processor exporting the spans of the
+// tracer provider owned by a Tracer to leave out the spans discarded by
+// Span.Discard. Discarded spans are still ended, such that the SDK releases
+// them.
+type discardingProcessor struct {
+	sdktrace.SpanProcessor
+
+	discarded sync.Map // trace.SpanID -> struct{}
+}
+
+// Make sure that discardingProcessor implements sdktrace.SpanProcessor
+// during compile time.
+var _ sdktrace.SpanProcessor = (*discardingProcessor)(nil)
+
+// discard marks the span to be left out once ended.
+func (p *discardingProcessor) discard(spanID trace.SpanID) {
+	p.discarded.Store(spanID, struct{}{})
+}
+
+// OnEnd hands the span over to the wrapped processor unless discarded.
+func (p *discardingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if _, discarded := p.discarded.LoadAndDelete(s.SpanContext().SpanID()); discarded {
+		return
+	}
+	p.SpanProcessor.OnEnd(s)
+}
@@ -3,8 +3,9 @@ module github.com/rmscoal/tengcorux/integrations/tracer/opentelemetry
 go 1.21
 
 require (
-	github.com/rmscoal/tengcorux/tracer v0.1.4
+	github.com/rmscoal/tengcorux/tracer v0.1.5-0.20261017175312-3ec99204e181
 	go.opentelemetry.io/otel v1.25.0
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.25.0
 	go.opentelemetry.io/otel/sdk v1.25.0
 	go.opentelemetry.io/otel/trace v1.25.0
 )
@@ -12,7 +13,8 @@ require (
 require (
 	github.com/go-logr/logr v1.4.1 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
-	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.25.0 // indirect
 	go.opentelemetry.io/otel/metric v1.25.0 // indirect
 	golang.org/x/sys v0.18.0 // indirect
 )
+
+replace github.com/rmscoal/tengcorux/tracer => ../../../tracer
@@ -1,14 +1,25 @@
 package opentelemetry
 
 import (
-	"fmt"
+	"context"
 	"time"
 
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+	tengcoruxAttribute "github.com/rmscoal/tengcorux/tracer/attribute"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// NewTracer creates a Tracer of the given service. The spans are recorded by
+// the tracer provider of WithTracerProvider, else by a tracer provider owned
+// by the Tracer exporting to WithExporter, else by the global tracer provider.
+// The otel globals are left untouched unless WithGlobal is given, such that
+// multiple tracers may live within the same process.
 func NewTracer(serviceName string, opts ...Option) *Tracer {
 	tracer := &Tracer{
 		serviceName: serviceName,
@@ -18,19 +29,95 @@ func NewTracer(serviceName string, opts ...Option) *Tracer {
 		opt(tracer)
 	}
 
+	if tracer.provider == nil && tracer.exporter != nil {
+		provider := sdktrace.NewTracerProvider(tracer.providerOptions()...)
+		tracer.shutdowns = append(tracer.shutdowns, provider.Shutdown)
+		tracer.provider = provider
+	}
+	if tracer.provider == nil {
+		tracer.provider = otel.GetTracerProvider()
+	}
+
 	// Propagation
-	otel.SetTextMapPropagator(
-		propagation.NewCompositeTextMapPropagator(
+	if tracer.propagator == nil {
+		tracer.propagator = propagation.NewCompositeTextMapPropagator(
 			propagation.TraceContext{},
 			propagation.Baggage{},
-		),
-	)
+		)
+	}
+
+	if tracer.global {
+		otel.SetTracerProvider(tracer.provider)
+		otel.SetTextMapPropagator(tracer.propagator)
+	}
+
 	// Start the tracer
-	tracer.tracer = otel.Tracer(tracer.serviceName)
+	tracer.tracer = tracer.provider.Tracer(tracer.serviceName)
 
 	return tracer
 }
 
+// providerOptions returns the options of the tracer provider owned by the
+// tracer.
+func (t *Tracer) providerOptions() []sdktrace.TracerProviderOption {
+	batchOpts := append([]sdktrace.BatchSpanProcessorOption{
+		sdktrace.WithBatchTimeout(time.Second),
+	}, t.batchOptions...)
+
+	t.discarder = &discardingProcessor{
+		SpanProcessor: sdktrace.NewBatchSpanProcessor(t.exporter, batchOpts...),
+	}
+	providerOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSpanProcessor(t.discarder),
+		sdktrace.WithResource(t.resource()),
+	}
+	if t.sdkSampler != nil {
+		providerOpts = append(providerOpts, sdktrace.WithSampler(t.sdkSampler))
+	} else if t.sampler != nil {
+		providerOpts = append(providerOpts,
+			sdktrace.WithSampler(&sampler{sampler: t.sampler}))
+	}
+	if t.sdkLimits != nil {
+		providerOpts = append(providerOpts, sdktrace.WithSpanLimits(*t.sdkLimits))
+	}
+	if t.idGenerator != nil {
+		providerOpts = append(providerOpts, sdktrace.WithIDGenerator(t.idGenerator))
+	}
+	return providerOpts
+}
+
+// resource describes the service of the tracer. The attributes given by the
+// options take precedence over the OTEL_SERVICE_NAME and
+// OTEL_RESOURCE_ATTRIBUTES environment variables.
+func (t *Tracer) resource() *resource.Resource {
+	attrs := []attribute.KeyValue{semconv.ServiceName(t.serviceName)}
+	if t.serviceVersion != "" {
+		attrs = append(attrs, semconv.ServiceVersion(t.serviceVersion))
+	}
+	if t.environment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironment(t.environment))
+	}
+	attrs = append(attrs, mapAttributes(t.resourceAttributes)...)
+
+	opts := []resource.Option{
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	}
+	opts = append(opts, t.resourceOptions...)
+	opts = append(opts, resource.WithAttributes(attrs...))
+
+	res, err := resource.New(context.Background(), opts...)
+	if err != nil {
+		// The resource holds the attributes that were detected.
+		otel.Handle(err)
+	}
+	if res == nil {
+		return resource.Default()
+	}
+	return res
+}
+
 // Version returns the current tracer's version
 func (t *Tracer) Version() string {
 	return "v0.1.1"
@@ -39,22 +126,126 @@ func (t *Tracer) Version() string {
 type Option func(*Tracer)
 
 // WithExporter we'll use the given exporter are the tracer provider. This enables
-// easy extensibility for library that adheres to OpenTelemetry.
+// easy extensibility for library that adheres to OpenTelemetry. The tracer
+// provider is owned by the tracer, hence it is shut down along the tracer.
 func WithExporter(exporter sdktrace.SpanExporter) Option {
 	return func(tracer *Tracer) {
-		provider := sdktrace.NewTracerProvider(
-			sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(time.Second)),
-		)
-		tracer.shutdowns = append(tracer.shutdowns, provider.Shutdown)
-		otel.SetTracerProvider(provider)
+		tracer.exporter = exporter
+	}
+}
+
+// WithTracerProvider records the spans with an existing tracer provider. It
+// takes precedence over WithExporter and WithSampler, and the tracer
+// provider is not shut down along the tracer.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(tracer *Tracer) {
+		tracer.provider = provider
+	}
+}
+
+// WithPropagator sets the propagator injecting and extracting the span
+// context. Defaults to the W3C trace context and baggage propagators.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(tracer *Tracer) {
+		tracer.propagator = propagator
+	}
+}
+
+// WithGlobal registers the tracer provider and the propagator of the tracer
+// as the otel globals, such that libraries instrumented with otel directly
+// share them. Only one tracer of a process should be given this option.
+func WithGlobal() Option {
+	return func(tracer *Tracer) {
+		tracer.global = true
+	}
+}
+
+// WithSampler sets the sampler deciding which spans are recorded and exported.
+// It only takes effect along with WithExporter, and defaults to OpenTelemetry's
+// parent based always on sampler.
+func WithSampler(sampler tengcoruxTracer.Sampler) Option {
+	return func(tracer *Tracer) {
+		tracer.sampler = sampler
+		tracer.sdkSampler = nil
+	}
+}
+
+// WithSDKSampler is like WithSampler, but takes an OpenTelemetry sampler such
+// as sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0.1)). The last one of
+// WithSampler and WithSDKSampler takes effect.
+func WithSDKSampler(sampler sdktrace.Sampler) Option {
+	return func(tracer *Tracer) {
+		tracer.sdkSampler = sampler
+		tracer.sampler = nil
+	}
+}
+
+// WithBatchOptions configures the batch span processor exporting to the
+// exporter of WithExporter, such as sdktrace.WithMaxExportBatchSize,
+// sdktrace.WithMaxQueueSize and sdktrace.WithBatchTimeout. The batch timeout
+// defaults to a second.
+func WithBatchOptions(opts ...sdktrace.BatchSpanProcessorOption) Option {
+	return func(tracer *Tracer) {
+		tracer.batchOptions = append(tracer.batchOptions, opts...)
+	}
+}
+
+// WithIDGenerator sets the generator of the trace and span ids. It only takes
+// effect along with WithExporter, and defaults to random ids.
+func WithIDGenerator(generator sdktrace.IDGenerator) Option {
+	return func(tracer *Tracer) {
+		tracer.idGenerator = generator
+	}
+}
+
+// WithSpanLimits sets the limits enforced on the spans of the tracer before
+// they reach OpenTelemetry. Defaults to the global
+// [tengcoruxTracer.GetSpanLimits] at span start.
+func WithSpanLimits(limits tengcoruxTracer.SpanLimits) Option {
+	return func(tracer *Tracer) {
+		tracer.limits = &limits
+	}
+}
+
+// WithSDKSpanLimits sets the limits enforced by the tracer provider built
+// along WithExporter, such as the link limits which WithSpanLimits lacks.
+// A value length limit below the one of WithSpanLimits cuts the truncation
+// marker off. Defaults to OpenTelemetry's span limits.
+func WithSDKSpanLimits(limits sdktrace.SpanLimits) Option {
+	return func(tracer *Tracer) {
+		tracer.sdkLimits = &limits
 	}
 }
 
-// WithEnvironment exports the environment settings later in the trace
-// that will be embedded into the service name with a ":" separator.
+// WithEnvironment sets the "deployment.environment" resource attribute of
+// the tracer provider built along WithExporter.
 func WithEnvironment(env string) Option {
 	return func(tracer *Tracer) {
 		tracer.environment = env
-		tracer.serviceName = fmt.Sprintf("%s:%s", tracer.serviceName, env)
+	}
+}
+
+// WithServiceVersion sets the "service.version" resource attribute of the
+// tracer provider built along WithExporter.
+func WithServiceVersion(version string) Option {
+	return func(tracer *Tracer) {
+		tracer.serviceVersion = version
+	}
+}
+
+// WithResourceAttributes adds the given attributes to the resource of the
+// tracer provider built along WithExporter.
+func WithResourceAttributes(attrs ...tengcoruxAttribute.KeyValue) Option {
+	return func(tracer *Tracer) {
+		tracer.resourceAttributes = append(tracer.resourceAttributes, attrs...)
+	}
+}
+
+// WithResourceOptions adds the given options building the resource of the
+// tracer provider built along WithExporter, such as resource.WithHost and
+// resource.WithProcess detecting the host and the process attributes.
+func WithResourceOptions(opts ...resource.Option) Option {
+	return func(tracer *Tracer) {
+		tracer.resourceOptions = append(tracer.resourceOptions, opts...)
 	}
 }
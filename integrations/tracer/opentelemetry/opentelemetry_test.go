@@ -1,16 +1,101 @@
 package opentelemetry
 
 import (
+	"context"
+	"reflect"
+	"sort"
 	"testing"
 
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"go.opentelemetry.io/otel"
+	otelAttribute "go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestOpentelemetry_NewTracer(t *testing.T) {
 	t.Run("WithEnvironment", func(t *testing.T) {
 		tracer := NewTracer("some_service_name", WithEnvironment("STAGING"))
-		if tracer.serviceName != "some_service_name:STAGING" {
-			t.Errorf("expected service name to be 'some_service_name:STAGING', got %s", tracer.serviceName)
+		if tracer.serviceName != "some_service_name" {
+			t.Errorf("expected service name to be 'some_service_name', got %s", tracer.serviceName)
+		}
+	})
+	t.Run("Resource", func(t *testing.T) {
+		exporter := &inMemoryExporter{tracetest.NewInMemoryExporter()}
+		tracer := NewTracer("some_service_name", WithExporter(exporter),
+			WithEnvironment("STAGING"),
+			WithServiceVersion("v1.2.3"),
+			WithResourceAttributes(attribute.String("team", "payments")),
+			WithResourceOptions(resource.WithHost()),
+		)
+
+		_, span := tracer.StartSpan(context.Background(), "some_span")
+		span.End()
+		if err := tracer.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		spans := exporter.GetSpans()
+		if len(spans) != 1 {
+			t.Fatalf("expected 1 span but got %d", len(spans))
+		}
+		attrs := attributeSet(spans[0].Resource.Attributes())
+		want := map[string]string{
+			"service.name":           "some_service_name",
+			"service.version":        "v1.2.3",
+			"deployment.environment": "STAGING",
+			"team":                   "payments",
+		}
+		for key, value := range want {
+			if got := attrs[otelAttribute.Key(key)].AsString(); got != value {
+				t.Errorf("expected %s to be %s, got %s", key, value, got)
+			}
+		}
+		if attrs["host.name"].AsString() == "" {
+			t.Error("expected the host to be detected")
+		}
+	})
+	t.Run("Provider Options", func(t *testing.T) {
+		exporter := &inMemoryExporter{tracetest.NewInMemoryExporter()}
+		tracer := NewTracer("some_service_name", WithExporter(exporter),
+			WithSampler(tengcoruxTracer.NeverSample()),
+			WithSDKSampler(sdktrace.AlwaysSample()),
+			WithBatchOptions(sdktrace.WithMaxExportBatchSize(1)),
+			WithIDGenerator(&fixedIDGenerator{}),
+			WithSDKSpanLimits(sdktrace.SpanLimits{LinkCountLimit: 1}),
+		)
+
+		_, linked := tracer.StartSpan(context.Background(), "linked")
+		linked.End()
+		linkedCtx := trace.ContextWithSpanContext(context.Background(),
+			trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: trace.TraceID{2},
+				SpanID:  trace.SpanID{2},
+			}))
+		_, span := tracer.StartSpan(context.Background(), "some_span",
+			tengcoruxTracer.WithLinks(
+				tengcoruxTracer.Link{Context: linkedCtx},
+				tengcoruxTracer.Link{Context: linkedCtx},
+			))
+		span.End()
+		if err := tracer.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		spans := exporter.GetSpans()
+		if len(spans) != 2 {
+			t.Fatalf("expected the sdk sampler to sample 2 spans but got %d", len(spans))
+		}
+		if spans[0].SpanContext.TraceID() != (trace.TraceID{1}) {
+			t.Errorf("expected the generated trace id, got %s", spans[0].SpanContext.TraceID())
+		}
+		if len(spans[1].Links) != 1 {
+			t.Errorf("expected the links to be limited to 1, got %d", len(spans[1].Links))
 		}
 	})
 	t.Run("WithExporter", func(t *testing.T) {
@@ -23,4 +108,113 @@ func TestOpentelemetry_NewTracer(t *testing.T) {
 			t.Error("expected at least one shutdown")
 		}
 	})
+	t.Run("WithSampler", func(t *testing.T) {
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			t.Fatal(err)
+		}
+		tracer := NewTracer("some_service_name", WithExporter(exporter),
+			WithSampler(tengcoruxTracer.NeverSample()))
+		if tracer.sampler == nil {
+			t.Error("expected sampler to be set")
+		}
+
+		_, span := tracer.StartSpan(context.Background(), "dropped")
+		defer span.End()
+		if span.IsRecording() {
+			t.Error("expected dropped span to not be recording")
+		}
+	})
+	t.Run("Isolated", func(t *testing.T) {
+		globalProvider := otel.GetTracerProvider()
+		globalPropagator := otel.GetTextMapPropagator()
+
+		exporterA := &inMemoryExporter{tracetest.NewInMemoryExporter()}
+		exporterB := &inMemoryExporter{tracetest.NewInMemoryExporter()}
+		tracerA := NewTracer("service_a", WithExporter(exporterA))
+		tracerB := NewTracer("service_b", WithExporter(exporterB))
+
+		_, spanA := tracerA.StartSpan(context.Background(), "span_a")
+		spanA.End()
+		_, spanB := tracerB.StartSpan(context.Background(), "span_b")
+		spanB.End()
+
+		if err := tracerA.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if err := tracerB.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if spans := exporterA.GetSpans(); len(spans) != 1 || spans[0].Name != "span_a" {
+			t.Errorf("expected only span_a to be exported by tracer a, got %v", spans)
+		}
+		if spans := exporterB.GetSpans(); len(spans) != 1 || spans[0].Name != "span_b" {
+			t.Errorf("expected only span_b to be exported by tracer b, got %v", spans)
+		}
+		if otel.GetTracerProvider() != globalProvider {
+			t.Error("expected the global tracer provider to be untouched")
+		}
+		if !reflect.DeepEqual(otel.GetTextMapPropagator(), globalPropagator) {
+			t.Error("expected the global propagator to be untouched")
+		}
+	})
+	t.Run("WithTracerProvider", func(t *testing.T) {
+		exporter := tracetest.NewInMemoryExporter()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		defer provider.Shutdown(context.Background())
+
+		tracer := NewTracer("some_service_name", WithTracerProvider(provider),
+			WithExporter(tracetest.NewInMemoryExporter()))
+		if len(tracer.shutdowns) != 0 {
+			t.Error("expected the given tracer provider to not be shut down")
+		}
+
+		_, span := tracer.StartSpan(context.Background(), "some_span")
+		span.End()
+		if spans := exporter.GetSpans(); len(spans) != 1 {
+			t.Errorf("expected 1 span recorded by the given provider, got %d", len(spans))
+		}
+	})
+	t.Run("WithPropagator", func(t *testing.T) {
+		tracer := NewTracer("some_service_name",
+			WithPropagator(propagation.TraceContext{}))
+		if _, ok := tracer.propagator.(propagation.TraceContext); !ok {
+			t.Errorf("expected the given propagator, got %T", tracer.propagator)
+		}
+	})
+	t.Run("WithGlobal", func(t *testing.T) {
+		globalProvider := otel.GetTracerProvider()
+		globalPropagator := otel.GetTextMapPropagator()
+		defer func() {
+			otel.SetTracerProvider(globalProvider)
+			otel.SetTextMapPropagator(globalPropagator)
+		}()
+
+		provider := sdktrace.NewTracerProvider()
+		defer provider.Shutdown(context.Background())
+
+		tracer := NewTracer("some_service_name", WithTracerProvider(provider),
+			WithGlobal())
+		if otel.GetTracerProvider() != provider {
+			t.Error("expected the global tracer provider to be replaced")
+		}
+		fields, want := otel.GetTextMapPropagator().Fields(), tracer.propagator.Fields()
+		sort.Strings(fields)
+		sort.Strings(want)
+		if !reflect.DeepEqual(fields, want) {
+			t.Errorf("expected the global propagator to be replaced, got %v", fields)
+		}
+	})
+}
+
+// fixedIDGenerator generates the same trace and span ids.
+type fixedIDGenerator struct{}
+
+func (*fixedIDGenerator) NewIDs(context.Context) (trace.TraceID, trace.SpanID) {
+	return trace.TraceID{1}, trace.SpanID{1}
+}
+
+func (*fixedIDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	return trace.SpanID{1}
 }
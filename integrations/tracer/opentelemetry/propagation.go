@@ -0,0 +1,20 @@
+package opentelemetry
+
+import (
+	"context"
+
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+)
+
+// Inject writes the W3C traceparent, tracestate and baggage of the given
+// context into the carrier.
+func (t *Tracer) Inject(ctx context.Context, carrier tengcoruxTracer.Carrier) {
+	t.propagator.Inject(ctx, carrier)
+}
+
+// Extract reads the W3C traceparent, tracestate and baggage from the carrier
+// and returns a copy of ctx holding the remote span context. Spans started
+// from the returned context become children of the remote span.
+func (t *Tracer) Extract(ctx context.Context, carrier tengcoruxTracer.Carrier) context.Context {
+	return t.propagator.Extract(ctx, carrier)
+}
@@ -0,0 +1,63 @@
+package opentelemetry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer_Inject(t *testing.T) {
+	tracer := NewTracer("testing", WithExporter(tracetest.NewNoopExporter()))
+
+	t.Run("EmptyContext", func(t *testing.T) {
+		carrier := tengcoruxTracer.MapCarrier{}
+		tracer.Inject(context.Background(), carrier)
+		if carrier.Get("traceparent") != "" {
+			t.Errorf("expected no traceparent but got %s",
+				carrier.Get("traceparent"))
+		}
+	})
+
+	t.Run("ActiveSpan", func(t *testing.T) {
+		ctx, span := tracer.StartSpan(context.Background(), "test")
+		defer span.End()
+
+		header := http.Header{}
+		tracer.Inject(ctx, tengcoruxTracer.HeaderCarrier(header))
+
+		want := "00-" + span.Context().TraceID() + "-" +
+			span.Context().SpanID() + "-01"
+		if got := header.Get("traceparent"); got != want {
+			t.Errorf("expected traceparent %s but got %s", want, got)
+		}
+	})
+}
+
+func TestTracer_Extract(t *testing.T) {
+	tracer := NewTracer("testing", WithExporter(tracetest.NewNoopExporter()))
+
+	t.Run("EmptyCarrier", func(t *testing.T) {
+		ctx := tracer.Extract(context.Background(), tengcoruxTracer.MapCarrier{})
+		if traceID := tracer.SpanFromContext(ctx).Context().TraceID(); traceID != "00000000000000000000000000000000" {
+			t.Errorf("expected an invalid trace id but got %s", traceID)
+		}
+	})
+
+	t.Run("ContinueRemoteSpan", func(t *testing.T) {
+		traceID := "5b8aa5a2d2c872e8321cf37308d69df2"
+		carrier := tengcoruxTracer.MapCarrier{
+			"traceparent": "00-" + traceID + "-051581bf3cb55c13-01",
+		}
+
+		ctx := tracer.Extract(context.Background(), carrier)
+		_, span := tracer.StartSpan(ctx, "test")
+		defer span.End()
+
+		if got := span.Context().TraceID(); got != traceID {
+			t.Errorf("expected trace id %s but got %s", traceID, got)
+		}
+	})
+}
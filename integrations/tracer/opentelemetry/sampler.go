@@ -0,0 +1,61 @@
+package opentelemetry
+
+import (
+	"context"
+
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type startSpanConfigContextKey struct{}
+
+// startSpanConfigKey is the key holding the *tengcoruxTracer.StartSpanConfig
+// of the span being started, such that the sampler can read its type and layer.
+var startSpanConfigKey startSpanConfigContextKey
+
+// sampler adapts a tengcorux Sampler into an OpenTelemetry sdktrace.Sampler.
+type sampler struct {
+	sampler tengcoruxTracer.Sampler
+}
+
+var _ sdktrace.Sampler = (*sampler)(nil)
+
+// ShouldSample converts the OpenTelemetry sampling parameters and delegates
+// the decision to the underlying tengcorux Sampler.
+func (s *sampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := trace.SpanContextFromContext(p.ParentContext)
+
+	params := tengcoruxTracer.SamplingParameters{
+		TraceID:       p.TraceID.String(),
+		Name:          p.Name,
+		HasParent:     parent.IsValid(),
+		ParentSampled: parent.IsSampled(),
+	}
+	if cfg, ok := p.ParentContext.Value(startSpanConfigKey).(*tengcoruxTracer.StartSpanConfig); ok {
+		params.SpanType = cfg.SpanType
+		params.SpanLayer = cfg.SpanLayer
+	}
+
+	decision := sdktrace.Drop
+	if s.sampler.ShouldSample(params) == tengcoruxTracer.RecordAndSample {
+		decision = sdktrace.RecordAndSample
+	}
+
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: parent.TraceState(),
+	}
+}
+
+// Description returns the description of the underlying tengcorux Sampler.
+func (s *sampler) Description() string {
+	return s.sampler.Description()
+}
+
+// contextWithStartSpanConfig stores the start span config in the context.
+func contextWithStartSpanConfig(ctx context.Context,
+	cfg *tengcoruxTracer.StartSpanConfig,
+) context.Context {
+	return context.WithValue(ctx, startSpanConfigKey, cfg)
+}
@@ -0,0 +1,69 @@
+package opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type recordingSampler struct {
+	params tengcoruxTracer.SamplingParameters
+}
+
+func (s *recordingSampler) ShouldSample(params tengcoruxTracer.SamplingParameters) tengcoruxTracer.SamplingDecision {
+	s.params = params
+	return tengcoruxTracer.Drop
+}
+
+func (s *recordingSampler) Description() string { return "recordingSampler" }
+
+func TestSampler(t *testing.T) {
+	t.Run("ShouldSample", func(t *testing.T) {
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		parent := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+			Remote:     true,
+		})
+
+		ctx := trace.ContextWithSpanContext(context.Background(), parent)
+		ctx = contextWithStartSpanConfig(ctx, &tengcoruxTracer.StartSpanConfig{
+			SpanType:  tengcoruxTracer.SpanTypeExit,
+			SpanLayer: tengcoruxTracer.SpanLayerDatabase,
+		})
+
+		rec := &recordingSampler{}
+		result := (&sampler{sampler: rec}).ShouldSample(sdktrace.SamplingParameters{
+			ParentContext: ctx,
+			TraceID:       traceID,
+			Name:          "SELECT",
+		})
+
+		if result.Decision != sdktrace.Drop {
+			t.Errorf("expected drop decision but got %v", result.Decision)
+		}
+		if rec.params.TraceID != traceID.String() {
+			t.Errorf("expected trace id %s but got %s", traceID, rec.params.TraceID)
+		} else if rec.params.Name != "SELECT" {
+			t.Errorf("expected name SELECT but got %s", rec.params.Name)
+		} else if !rec.params.HasParent || !rec.params.ParentSampled {
+			t.Error("expected a sampled parent")
+		} else if rec.params.SpanType != tengcoruxTracer.SpanTypeExit {
+			t.Errorf("expected exit span type but got %d", rec.params.SpanType)
+		} else if rec.params.SpanLayer != tengcoruxTracer.SpanLayerDatabase {
+			t.Errorf("expected database span layer but got %d", rec.params.SpanLayer)
+		}
+	})
+
+	t.Run("Description", func(t *testing.T) {
+		s := &sampler{sampler: tengcoruxTracer.AlwaysSample()}
+		if s.Description() != "AlwaysOnSampler" {
+			t.Errorf("unexpected description %s", s.Description())
+		}
+	})
+}
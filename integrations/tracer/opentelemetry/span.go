@@ -2,8 +2,7 @@ package opentelemetry
 
 import (
 	"context"
-	"fmt"
-	"time"
+	"runtime/debug"
 
 	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
 	tengcoruxAttribute "github.com/rmscoal/tengcorux/tracer/attribute"
@@ -12,10 +11,15 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// Make sure that Span implements [tengcoruxTracer.DiscardableSpan] during
+// compile time.
+var _ tengcoruxTracer.DiscardableSpan = (*Span)(nil)
+
 type Span struct {
 	tracer      *Tracer
 	span        trace.Span
 	spanContext *SpanContext
+	limiter     *tengcoruxTracer.SpanLimiter
 }
 
 // End ends the current span.
@@ -23,59 +27,62 @@ func (s *Span) End() {
 	s.span.End()
 }
 
-// SetAttributes sets attributes to the current span.
-func (s *Span) SetAttributes(tengcoruxAttributes ...tengcoruxAttribute.KeyValue) {
-	var attributes []attribute.KeyValue
-
-	for _, attr := range tengcoruxAttributes {
-		key := string(attr.Key)
-		switch val := attr.Value.(type) {
-		case bool:
-			attributes = append(attributes, attribute.Bool(key, val))
-		case string:
-			attributes = append(attributes, attribute.String(key, val))
-		case int:
-			attributes = append(attributes, attribute.Int(key, val))
-		case int32:
-			attributes = append(attributes, attribute.Int(key, int(val)))
-		case int64:
-			attributes = append(attributes, attribute.Int64(key, val))
-		case float32:
-			attributes = append(attributes, attribute.Float64(key, float64(val)))
-		case float64:
-			attributes = append(attributes, attribute.Float64(key, val))
-		case []int:
-			attributes = append(attributes, attribute.IntSlice(key, val))
-		case []int64:
-			attributes = append(attributes, attribute.Int64Slice(key, val))
-		case []float64:
-			attributes = append(attributes, attribute.Float64Slice(key, val))
-		case struct{}, map[string]interface{}, map[string]string:
-			attributes = append(attributes, attribute.String(key, fmt.Sprintf("%+v", val)))
-		default:
-			attributes = append(attributes, attribute.String(key, fmt.Sprintf("%v", val)))
-		}
+// Discard ends the current span without exporting it, when the tracer owns
+// its tracer provider through WithExporter. The processors of other tracer
+// providers are out of reach, hence their spans are ended and exported as
+// usual.
+func (s *Span) Discard() {
+	if s.tracer != nil && s.tracer.discarder != nil && s.span.IsRecording() {
+		s.tracer.discarder.discard(s.span.SpanContext().SpanID())
 	}
+	s.span.End()
+}
 
-	s.span.SetAttributes(attributes...)
+// SetAttributes sets attributes to the current span within the span limits.
+func (s *Span) SetAttributes(tengcoruxAttributes ...tengcoruxAttribute.KeyValue) {
+	tengcoruxAttributes = s.limiter.LimitAttributes(tengcoruxAttributes...)
+	s.span.SetAttributes(mapAttributes(tengcoruxAttributes)...)
 }
 
-// RecordError records an error to the current span.
+// RecordError records an error to the current span as an exception event.
+// It does not change the status of the span.
 func (s *Span) RecordError(err error) {
 	if err == nil {
 		return
 	}
 	s.span.RecordError(err)
-	s.span.SetStatus(codes.Error, err.Error())
 }
 
-// AddEvent adds an event to the current span at current timeframe.
-func (s *Span) AddEvent(events ...string) {
-	for _, event := range events {
-		s.span.AddEvent(event,
-			trace.WithTimestamp(time.Now()),
-			trace.WithStackTrace(true))
+// SetStatus sets the status of the current span.
+func (s *Span) SetStatus(code tengcoruxTracer.StatusCode, description string) {
+	s.span.SetStatus(mapStatusCode(code), description)
+}
+
+// AddEvent adds an event to the current span. The stack trace, when asked,
+// is stored as the "code.stacktrace" attribute of the event since
+// OpenTelemetry only captures it for exceptions. Events beyond the span
+// limits are dropped.
+func (s *Span) AddEvent(name string, opts ...tengcoruxTracer.EventOption) {
+	cfg := tengcoruxTracer.NewEventConfig(opts...)
+
+	eventAttributes, ok := s.limiter.LimitEvent(cfg.Attributes)
+	if !ok {
+		return
 	}
+	attributes := mapAttributes(eventAttributes)
+	if cfg.StackTrace {
+		attributes = append(attributes,
+			attribute.String("code.stacktrace", string(debug.Stack())))
+	}
+
+	s.span.AddEvent(name,
+		trace.WithTimestamp(cfg.Timestamp),
+		trace.WithAttributes(attributes...))
+}
+
+// IsRecording returns true if the span is sampled and has not ended.
+func (s *Span) IsRecording() bool {
+	return s.span.IsRecording()
 }
 
 // Context returns SpanContext.
@@ -116,6 +123,49 @@ func (sc *SpanContext) Context() context.Context {
 	return sc.ctx
 }
 
+// mapAttributes maps tengcorux attributes into OpenTelemetry attributes.
+func mapAttributes(tengcoruxAttributes []tengcoruxAttribute.KeyValue) []attribute.KeyValue {
+	var attributes []attribute.KeyValue
+
+	for _, attr := range tengcoruxAttributes {
+		key := string(attr.Key)
+		switch attr.Value.Type() {
+		case tengcoruxAttribute.TypeBool:
+			attributes = append(attributes, attribute.Bool(key, attr.Value.AsBool()))
+		case tengcoruxAttribute.TypeInt64:
+			attributes = append(attributes, attribute.Int64(key, attr.Value.AsInt64()))
+		case tengcoruxAttribute.TypeFloat64:
+			attributes = append(attributes, attribute.Float64(key, attr.Value.AsFloat64()))
+		case tengcoruxAttribute.TypeString:
+			attributes = append(attributes, attribute.String(key, attr.Value.AsString()))
+		case tengcoruxAttribute.TypeBoolSlice:
+			attributes = append(attributes, attribute.BoolSlice(key, attr.Value.AsBoolSlice()))
+		case tengcoruxAttribute.TypeInt64Slice:
+			attributes = append(attributes, attribute.Int64Slice(key, attr.Value.AsInt64Slice()))
+		case tengcoruxAttribute.TypeFloat64Slice:
+			attributes = append(attributes, attribute.Float64Slice(key, attr.Value.AsFloat64Slice()))
+		case tengcoruxAttribute.TypeStringSlice:
+			attributes = append(attributes, attribute.StringSlice(key, attr.Value.AsStringSlice()))
+		default:
+			// Invalid values, such as nil, have no OpenTelemetry counterpart.
+		}
+	}
+
+	return attributes
+}
+
+// mapStatusCode maps tengcorux StatusCode into OpenTelemetry codes.Code.
+func mapStatusCode(code tengcoruxTracer.StatusCode) codes.Code {
+	switch code {
+	case tengcoruxTracer.StatusOk:
+		return codes.Ok
+	case tengcoruxTracer.StatusError:
+		return codes.Error
+	default:
+		return codes.Unset
+	}
+}
+
 // mapSpanKind maps a given span type and layer to open telemetry span kind.
 func mapSpanKind(
 	spanType tengcoruxTracer.SpanType,
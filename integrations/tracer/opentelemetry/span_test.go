@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
 	"github.com/rmscoal/tengcorux/tracer/attribute"
+	otelAttribute "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktracetest "go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -25,6 +29,12 @@ func TestSpan(t *testing.T) {
 	tr := NewTracer("testing", WithExporter(exporter))
 	_, span := tr.StartSpan(context.Background(), "test")
 
+	t.Run("IsRecording", func(t *testing.T) {
+		if !span.IsRecording() {
+			t.Error("expected the span to be recording")
+		}
+	})
+
 	t.Run("End", func(t *testing.T) {
 		span.End()
 		if span.(*Span).span.IsRecording() {
@@ -47,8 +57,10 @@ func TestSpan(t *testing.T) {
 	})
 
 	t.Run("AddEvent", func(t *testing.T) {
-		span.AddEvent("hello 1", "hello 2", "hello 3")
-		span.AddEvent()
+		span.AddEvent("hello 1")
+		span.AddEvent("hello 2",
+			tengcoruxTracer.WithEventAttributes(attribute.KeyValuePair("key", "value")),
+			tengcoruxTracer.WithStackTrace())
 	})
 
 	t.Run("Context", func(t *testing.T) {
@@ -59,6 +71,229 @@ func TestSpan(t *testing.T) {
 	})
 }
 
+// inMemoryExporter keeps the exported spans after shutdown, such that
+// they can be asserted once the tracer flushed them.
+type inMemoryExporter struct {
+	*sdktracetest.InMemoryExporter
+}
+
+func (*inMemoryExporter) Shutdown(context.Context) error { return nil }
+
+func TestSpan_SetStatus(t *testing.T) {
+	exporter := &inMemoryExporter{sdktracetest.NewInMemoryExporter()}
+	tr := NewTracer("testing", WithExporter(exporter))
+
+	_, failed := tr.StartSpan(context.Background(), "failed")
+	failed.SetStatus(tengcoruxTracer.StatusError, "some_error")
+	failed.End()
+
+	_, logged := tr.StartSpan(context.Background(), "logged")
+	logged.RecordError(errors.New("some_error"))
+	logged.End()
+
+	if err := tr.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans but got %d", len(spans))
+	}
+	for _, span := range spans {
+		switch span.Name {
+		case "failed":
+			if span.Status.Code != codes.Error {
+				t.Errorf("expected %s but got %s", codes.Error, span.Status.Code)
+			} else if span.Status.Description != "some_error" {
+				t.Errorf("expected some_error but got %s", span.Status.Description)
+			}
+		case "logged":
+			if span.Status.Code != codes.Unset {
+				t.Errorf("expected %s but got %s", codes.Unset, span.Status.Code)
+			} else if len(span.Events) != 1 {
+				t.Errorf("expected 1 exception event but got %d", len(span.Events))
+			}
+		}
+	}
+}
+
+// dropProcessor drops every span on end.
+type dropProcessor struct{}
+
+func (dropProcessor) OnStart(context.Context, tengcoruxTracer.ReadWriteSpan) {}
+func (dropProcessor) OnEnd(span tengcoruxTracer.ReadWriteSpan)               { span.Drop() }
+func (dropProcessor) ForceFlush(context.Context) error                       { return nil }
+func (dropProcessor) Shutdown(context.Context) error                         { return nil }
+
+func TestSpan_Discard(t *testing.T) {
+	t.Run("Discard", func(t *testing.T) {
+		exporter := &inMemoryExporter{sdktracetest.NewInMemoryExporter()}
+		tr := NewTracer("testing", WithExporter(exporter))
+
+		_, discarded := tr.StartSpan(context.Background(), "discarded")
+		discarded.(tengcoruxTracer.DiscardableSpan).Discard()
+		if discarded.IsRecording() {
+			t.Error("expected the discarded span to be ended")
+		}
+
+		_, ended := tr.StartSpan(context.Background(), "ended")
+		ended.End()
+
+		if err := tr.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		spans := exporter.GetSpans()
+		if len(spans) != 1 || spans[0].Name != "ended" {
+			t.Errorf("expected only the ended span to be exported but got %v", spans)
+		}
+	})
+
+	t.Run("Dropped By Processor", func(t *testing.T) {
+		exporter := &inMemoryExporter{sdktracetest.NewInMemoryExporter()}
+		tr := NewTracer("testing", WithExporter(exporter))
+		pt := tengcoruxTracer.NewProcessingTracer(tr, dropProcessor{})
+
+		_, span := pt.StartSpan(context.Background(), "dropped")
+		span.End()
+
+		if err := pt.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if spans := exporter.GetSpans(); len(spans) != 0 {
+			t.Errorf("expected the dropped span to not be exported but got %v", spans)
+		}
+	})
+}
+
+func TestSpan_AddEvent(t *testing.T) {
+	exporter := &inMemoryExporter{sdktracetest.NewInMemoryExporter()}
+	tr := NewTracer("testing", WithExporter(exporter))
+
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, span := tr.StartSpan(context.Background(), "test")
+	span.AddEvent("plain")
+	span.AddEvent("structured",
+		tengcoruxTracer.WithEventAttributes(
+			attribute.KeyValuePair("key", "value"),
+			attribute.KeyValuePair("count", 3),
+		),
+		tengcoruxTracer.WithTimestamp(timestamp),
+		tengcoruxTracer.WithStackTrace(),
+	)
+	span.End()
+
+	if err := tr.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span but got %d", len(spans))
+	}
+	events := spans[0].Events
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events but got %d", len(events))
+	}
+
+	if events[0].Name != "plain" {
+		t.Errorf("expected plain but got %s", events[0].Name)
+	} else if len(events[0].Attributes) != 0 {
+		t.Errorf("expected no attributes but got %v", events[0].Attributes)
+	}
+
+	if events[1].Name != "structured" {
+		t.Errorf("expected structured but got %s", events[1].Name)
+	} else if !events[1].Time.Equal(timestamp) {
+		t.Errorf("expected %s but got %s", timestamp, events[1].Time)
+	}
+	attrs := attributeSet(events[1].Attributes)
+	if attrs["key"].AsString() != "value" {
+		t.Errorf("expected value but got %s", attrs["key"].AsString())
+	} else if attrs["count"].AsInt64() != 3 {
+		t.Errorf("expected 3 but got %d", attrs["count"].AsInt64())
+	} else if attrs["code.stacktrace"].AsString() == "" {
+		t.Error("expected a stack trace")
+	}
+}
+
+func TestSpan_Limits(t *testing.T) {
+	exporter := &inMemoryExporter{sdktracetest.NewInMemoryExporter()}
+	tr := NewTracer("testing", WithExporter(exporter),
+		WithSpanLimits(tengcoruxTracer.SpanLimits{
+			AttributeCountLimit:       1,
+			AttributeValueLengthLimit: 4,
+			EventCountLimit:           1,
+		}))
+
+	ctx, span := tr.StartSpan(context.Background(), "test")
+	span.SetAttributes(attribute.HTTPRequestBody("some long body"))
+	// The limits are kept by the span retrieved from the context.
+	tr.SpanFromContext(ctx).SetAttributes(attribute.String("dropped", "value"))
+	span.AddEvent("first")
+	span.AddEvent("second")
+	span.End()
+
+	if err := tr.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span but got %d", len(spans))
+	}
+	attrs := attributeSet(spans[0].Attributes)
+	if len(attrs) != 1 {
+		t.Errorf("expected 1 attribute but got %v", spans[0].Attributes)
+	} else if got := attrs["http.request.body"].AsString(); got != "some"+tengcoruxTracer.TruncationMarker {
+		t.Errorf("expected the body to be truncated but got %s", got)
+	}
+	if len(spans[0].Events) != 1 {
+		t.Errorf("expected 1 event but got %d", len(spans[0].Events))
+	}
+}
+
+func TestMapAttributes(t *testing.T) {
+	got := attributeSet(mapAttributes([]attribute.KeyValue{
+		attribute.Bool("bool", true),
+		attribute.Int("int", 1),
+		attribute.Float64("float", 4.5),
+		attribute.String("string", "value"),
+		attribute.BoolSlice("bools", []bool{true}),
+		attribute.IntSlice("ints", []int{1, 2}),
+		attribute.Float64Slice("floats", []float64{1.5}),
+		attribute.StringSlice("strings", []string{"a"}),
+		attribute.KeyValuePair("nil", nil),
+	}))
+
+	want := map[otelAttribute.Key]otelAttribute.Value{
+		"bool":    otelAttribute.BoolValue(true),
+		"int":     otelAttribute.Int64Value(1),
+		"float":   otelAttribute.Float64Value(4.5),
+		"string":  otelAttribute.StringValue("value"),
+		"bools":   otelAttribute.BoolSliceValue([]bool{true}),
+		"ints":    otelAttribute.Int64SliceValue([]int64{1, 2}),
+		"floats":  otelAttribute.Float64SliceValue([]float64{1.5}),
+		"strings": otelAttribute.StringSliceValue([]string{"a"}),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d attributes but got %d", len(want), len(got))
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s: expected %s but got %s", key, value.Emit(), got[key].Emit())
+		}
+	}
+}
+
+func attributeSet(kvs []otelAttribute.KeyValue) map[otelAttribute.Key]otelAttribute.Value {
+	set := make(map[otelAttribute.Key]otelAttribute.Value, len(kvs))
+	for _, kv := range kvs {
+		set[kv.Key] = kv.Value
+	}
+	return set
+}
+
 func TestSpanContext(t *testing.T) {
 	exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
 	if err != nil {
@@ -84,6 +319,19 @@ func TestSpanContext(t *testing.T) {
 	})
 }
 
+func TestMapStatusCode(t *testing.T) {
+	tests := map[tengcoruxTracer.StatusCode]codes.Code{
+		tengcoruxTracer.StatusUnset: codes.Unset,
+		tengcoruxTracer.StatusOk:    codes.Ok,
+		tengcoruxTracer.StatusError: codes.Error,
+	}
+	for code, expected := range tests {
+		if got := mapStatusCode(code); got != expected {
+			t.Errorf("expected %s but got %s", expected, got)
+		}
+	}
+}
+
 func TestMapSpanKind(t *testing.T) {
 	t.Run("SpanTypeLocal", func(t *testing.T) {
 		if kind := mapSpanKind(tengcoruxTracer.SpanTypeLocal,
@@ -120,6 +368,10 @@ func TestMapSpanKind(t *testing.T) {
 			tengcoruxTracer.SpanLayerMQ); kind != trace.SpanKindConsumer {
 			t.Errorf("expected %s but got %s", trace.SpanKindConsumer, kind)
 		}
+		if kind := mapSpanKind(tengcoruxTracer.SpanTypeEntry,
+			tengcoruxTracer.SpanLayerRPC); kind != trace.SpanKindServer {
+			t.Errorf("expected %s but got %s", trace.SpanKindServer, kind)
+		}
 	})
 	t.Run("SpanTypeExit", func(t *testing.T) {
 		if kind := mapSpanKind(tengcoruxTracer.SpanTypeExit,
@@ -138,5 +390,9 @@ func TestMapSpanKind(t *testing.T) {
 			tengcoruxTracer.SpanLayerMQ); kind != trace.SpanKindProducer {
 			t.Errorf("expected %s but got %s", trace.SpanKindProducer, kind)
 		}
+		if kind := mapSpanKind(tengcoruxTracer.SpanTypeExit,
+			tengcoruxTracer.SpanLayerRPC); kind != trace.SpanKindClient {
+			t.Errorf("expected %s but got %s", trace.SpanKindClient, kind)
+		}
 	})
 }
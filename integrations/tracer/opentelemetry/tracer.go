@@ -4,14 +4,39 @@ import (
 	"context"
 
 	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+	tengcoruxAttribute "github.com/rmscoal/tengcorux/tracer/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
 
+var (
+	_ tengcoruxTracer.Tracer     = (*Tracer)(nil)
+	_ tengcoruxTracer.Propagator = (*Tracer)(nil)
+)
+
 type Tracer struct {
-	tracer      trace.Tracer
-	shutdowns   []func(context.Context) error
-	serviceName string
-	environment string
+	tracer     trace.Tracer
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+	exporter   sdktrace.SpanExporter
+	sampler    tengcoruxTracer.Sampler
+	sdkSampler sdktrace.Sampler
+	limits     *tengcoruxTracer.SpanLimits
+	shutdowns  []func(context.Context) error
+	global     bool
+	discarder  *discardingProcessor
+
+	// tracer provider built along the exporter
+	batchOptions       []sdktrace.BatchSpanProcessorOption
+	sdkLimits          *sdktrace.SpanLimits
+	idGenerator        sdktrace.IDGenerator
+	resourceOptions    []resource.Option
+	resourceAttributes []tengcoruxAttribute.KeyValue
+	serviceName        string
+	serviceVersion     string
+	environment        string
 }
 
 // StartSpan starts a new Span with the given name and option.
@@ -23,16 +48,28 @@ func (t *Tracer) StartSpan(ctx context.Context, name string,
 		opt(startSpanConfig)
 	}
 
-	ctx, span := t.tracer.Start(
-		generateContextFromStartSpanConfig(ctx, startSpanConfig),
+	// The start span config is only visible to the sampler, the returned
+	// context derives from the one without it.
+	ctx = generateContextFromStartSpanConfig(ctx, startSpanConfig)
+	_, span := t.tracer.Start(
+		contextWithStartSpanConfig(ctx, startSpanConfig),
 		name,
 		trace.WithSpanKind(mapSpanKind(startSpanConfig.SpanType,
 			startSpanConfig.SpanLayer)),
+		trace.WithLinks(mapLinks(startSpanConfig.Links)...),
 	)
+	ctx = trace.ContextWithSpan(ctx, span)
+
+	limiter := tengcoruxTracer.NewSpanLimiter(t.spanLimits())
+	ctx = context.WithValue(ctx, spanLimiterKey, &spanLimiter{
+		spanID:  span.SpanContext().SpanID(),
+		limiter: limiter,
+	})
 
 	return ctx, &Span{
-		tracer: t,
-		span:   span,
+		tracer:  t,
+		span:    span,
+		limiter: limiter,
 		spanContext: &SpanContext{
 			ctx: ctx,
 		},
@@ -62,14 +99,73 @@ func (t *Tracer) SpanFromContext(ctx context.Context) tengcoruxTracer.Span {
 	// if !span.IsRecording() { return nil }
 
 	return &Span{
-		tracer: t,
-		span:   span,
+		tracer:  t,
+		span:    span,
+		limiter: t.limiterFromContext(ctx, span),
 		spanContext: &SpanContext{
 			ctx: ctx,
 		},
 	}
 }
 
+type spanLimiterContextKey struct{}
+
+// spanLimiterKey is the key that holds the *spanLimiter of the span started
+// by StartSpan inside a context.
+var spanLimiterKey spanLimiterContextKey
+
+// spanLimiter ties the SpanLimiter of a span to its span id, such that
+// the span limits are kept across SpanFromContext calls.
+type spanLimiter struct {
+	spanID  trace.SpanID
+	limiter *tengcoruxTracer.SpanLimiter
+}
+
+// limiterFromContext returns the SpanLimiter of the span if it was started
+// by StartSpan, else a new one.
+func (t *Tracer) limiterFromContext(ctx context.Context, span trace.Span) *tengcoruxTracer.SpanLimiter {
+	if ctx == nil {
+		return tengcoruxTracer.NewSpanLimiter(t.spanLimits())
+	}
+
+	stored, ok := ctx.Value(spanLimiterKey).(*spanLimiter)
+	if ok && span.SpanContext().HasSpanID() &&
+		stored.spanID == span.SpanContext().SpanID() {
+		return stored.limiter
+	}
+	return tengcoruxTracer.NewSpanLimiter(t.spanLimits())
+}
+
+// spanLimits returns the limits of the tracer, else the global ones.
+func (t *Tracer) spanLimits() tengcoruxTracer.SpanLimits {
+	if t.limits != nil {
+		return *t.limits
+	}
+	return tengcoruxTracer.GetSpanLimits()
+}
+
+// mapLinks maps tengcorux links into OpenTelemetry links. Links whose
+// context holds no valid span context are skipped.
+func mapLinks(links []tengcoruxTracer.Link) []trace.Link {
+	var otelLinks []trace.Link
+	for _, link := range links {
+		if link.Context == nil {
+			continue
+		}
+
+		spanContext := trace.SpanContextFromContext(link.Context)
+		if !spanContext.IsValid() {
+			continue
+		}
+
+		otelLinks = append(otelLinks, trace.Link{
+			SpanContext: spanContext,
+			Attributes:  mapAttributes(link.Attributes),
+		})
+	}
+	return otelLinks
+}
+
 // generateContextFromStartSpanConfig generates a new context only if
 // the start span config given includes a TraceID and/or ParentSpanID.
 func generateContextFromStartSpanConfig(ctx context.Context,
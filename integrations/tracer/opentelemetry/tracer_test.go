@@ -2,10 +2,11 @@ package opentelemetry
 
 import (
 	"context"
-	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
 	"testing"
 	"time"
 
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
@@ -77,6 +78,45 @@ func TestTracer_StartSpan(t *testing.T) {
 			t.Fatal("expected a valid trace ID to be generated for invalid input")
 		}
 	})
+
+	t.Run("WithLinks", func(t *testing.T) {
+		localCtx, local := tracer.StartSpan(context.TODO(), "local")
+		defer local.End()
+		remoteCtx := tracer.Extract(context.TODO(), tengcoruxTracer.MapCarrier{
+			"traceparent": "00-5b8aa5a2d2c872e8321cf37308d69df2-051581bf3cb55c13-01",
+		})
+
+		_, span := tracer.StartSpan(context.TODO(), "batch",
+			tengcoruxTracer.WithLinks(
+				tengcoruxTracer.Link{Context: localCtx},
+				tengcoruxTracer.Link{
+					Context: remoteCtx,
+					Attributes: []attribute.KeyValue{
+						attribute.KeyValuePair("messaging.message.id", "abc"),
+					},
+				},
+				tengcoruxTracer.Link{Context: context.TODO()},
+			))
+
+		links := span.(*Span).span.(sdktrace.ReadWriteSpan).Links()
+		if len(links) != 2 {
+			t.Fatalf("expected 2 links but got %d", len(links))
+		}
+		if links[0].SpanContext.SpanID().String() != local.Context().SpanID() {
+			t.Errorf("expected first link to refer the local span but got %s",
+				links[0].SpanContext.SpanID())
+		}
+		if links[1].SpanContext.TraceID().String() != "5b8aa5a2d2c872e8321cf37308d69df2" {
+			t.Errorf("expected second link to refer the remote trace but got %s",
+				links[1].SpanContext.TraceID())
+		} else if len(links[1].Attributes) != 1 {
+			t.Errorf("expected 1 link attribute but got %d",
+				len(links[1].Attributes))
+		}
+		if span.Context().TraceID() == "5b8aa5a2d2c872e8321cf37308d69df2" {
+			t.Error("expected links to not change the trace of the span")
+		}
+	})
 }
 
 func TestTracer_Shutdown(t *testing.T) {
@@ -0,0 +1,49 @@
+package skywalking
+
+import (
+	"strings"
+	"sync"
+)
+
+// componentLibraries maps the "db.system" and "mq.system" attributes of a
+// span to the component library of the client, overriding the one of its
+// layer. The systems are case-insensitive.
+var componentLibraries = struct {
+	sync.RWMutex
+	systems map[string]ComponentLibrary
+}{
+	systems: map[string]ComponentLibrary{
+		"kafka":      GoKafka,
+		"rabbitmq":   RabbitMQ,
+		"redis":      GoRedis,
+		"mysql":      GoMysql,
+		"postgres":   PostgreSQL,
+		"postgresql": PostgreSQL,
+		"sqlite":     SQLite,
+	},
+}
+
+// RegisterComponentLibrary registers the component library of spans whose
+// "db.system" or "mq.system" attribute is the given system, replacing the
+// previously registered one. For example:
+//
+//	skywalking.RegisterComponentLibrary("mongodb", skywalking.ComponentLibrary(42))
+//
+// The known systems are "kafka", "rabbitmq", "redis", "mysql", "postgres",
+// "postgresql" and "sqlite". Other systems, such as the "SQL Server" and
+// "Microsoft SQL Server" of the gorm plugin, keep the component library of
+// the span layer unless registered. It is safe to be called concurrently.
+func RegisterComponentLibrary(system string, component ComponentLibrary) {
+	componentLibraries.Lock()
+	defer componentLibraries.Unlock()
+	componentLibraries.systems[strings.ToLower(system)] = component
+}
+
+// lookupComponentLibrary returns the component library registered for the
+// given system, if any.
+func lookupComponentLibrary(system string) (ComponentLibrary, bool) {
+	componentLibraries.RLock()
+	defer componentLibraries.RUnlock()
+	component, ok := componentLibraries.systems[strings.ToLower(system)]
+	return component, ok
+}
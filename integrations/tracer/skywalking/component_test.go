@@ -0,0 +1,45 @@
+package skywalking
+
+import "testing"
+
+func TestLookupComponentLibrary(t *testing.T) {
+	tests := map[string]ComponentLibrary{
+		"kafka":      GoKafka,
+		"rabbitmq":   RabbitMQ,
+		"redis":      GoRedis,
+		"MySQL":      GoMysql,
+		"postgres":   PostgreSQL,
+		"PostgreSQL": PostgreSQL,
+		// The systems emitted by the gorm plugin.
+		"SQLite": SQLite,
+	}
+	for system, want := range tests {
+		if got, ok := lookupComponentLibrary(system); !ok || got != want {
+			t.Errorf("%s: expects %v but got %v", system, want, got)
+		}
+	}
+
+	for _, system := range []string{"nats", "SQL Server", "Microsoft SQL Server"} {
+		if _, ok := lookupComponentLibrary(system); ok {
+			t.Errorf("%s: expects unknown systems to not be mapped", system)
+		}
+	}
+}
+
+func TestRegisterComponentLibrary(t *testing.T) {
+	t.Run("New System", func(t *testing.T) {
+		RegisterComponentLibrary("MongoDB", ComponentLibrary(42))
+		if got, ok := lookupComponentLibrary("mongodb"); !ok || got != 42 {
+			t.Errorf("expects %v but got %v", ComponentLibrary(42), got)
+		}
+	})
+
+	t.Run("Replace System", func(t *testing.T) {
+		defer RegisterComponentLibrary("mysql", GoMysql)
+
+		RegisterComponentLibrary("mysql", ComponentLibrary(5))
+		if got, _ := lookupComponentLibrary("mysql"); got != 5 {
+			t.Errorf("expects %v but got %v", ComponentLibrary(5), got)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package skywalking
+
+import (
+	"sync"
+
+	"github.com/SkyAPM/go2sky"
+)
+
+// discardKey identifies a span within the segments reported by a tracer.
+type discardKey struct {
+	segmentID string
+	spanID    int32
+}
+
+// discardingReporter wraps the reporter of a Tracer to leave out the spans
+// discarded by Span.Discard. go2sky reports the spans of a segment together
+// once all of them have ended, hence discarded spans are ended and filtered
+// out when their segment is sent.
+type discardingReporter struct {
+	go2sky.Reporter
+
+	discarded sync.Map // discardKey -> struct{}
+}
+
+// Make sure that discardingReporter implements go2sky.Reporter during
+// compile time.
+var _ go2sky.Reporter = (*discardingReporter)(nil)
+
+// discard marks the span to be left out of its segment.
+func (r *discardingReporter) discard(span go2sky.ReportedSpan) {
+	r.discarded.Store(discardKey{
+		segmentID: span.Context().SegmentID,
+		spanID:    span.Context().SpanID,
+	}, struct{}{})
+}
+
+// Send reports the spans that are not discarded. The whole segment is left
+// out when its first span is discarded, since SkyWalking ties the other
+// spans of the segment to it.
+func (r *discardingReporter) Send(spans []go2sky.ReportedSpan) {
+	kept := make([]go2sky.ReportedSpan, 0, len(spans))
+	dropSegment := false
+	for _, span := range spans {
+		_, discarded := r.discarded.LoadAndDelete(discardKey{
+			segmentID: span.Context().SegmentID,
+			spanID:    span.Context().SpanID,
+		})
+		if !discarded {
+			kept = append(kept, span)
+		} else if span.Context().ParentSpanID == -1 {
+			dropSegment = true
+		}
+	}
+
+	if dropSegment || len(kept) == 0 {
+		return
+	}
+	r.Reporter.Send(kept)
+}
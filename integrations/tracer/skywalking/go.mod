@@ -4,7 +4,7 @@ go 1.21
 
 require (
 	github.com/SkyAPM/go2sky v1.5.0
-	github.com/rmscoal/tengcorux/tracer v0.1.4
+	github.com/rmscoal/tengcorux/tracer v0.1.5-0.20261017175312-3ec99204e181
 	skywalking.apache.org/repo/goapi v0.0.0-20220401015832-2c9eee9481eb
 )
 
@@ -19,3 +19,5 @@ require (
 	google.golang.org/grpc v1.40.0 // indirect
 	google.golang.org/protobuf v1.28.0 // indirect
 )
+
+replace github.com/rmscoal/tengcorux/tracer => ../../../tracer
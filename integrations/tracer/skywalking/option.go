@@ -0,0 +1,90 @@
+package skywalking
+
+import (
+	"github.com/SkyAPM/go2sky"
+	"github.com/SkyAPM/go2sky/reporter"
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+)
+
+// config holds the settings of the Tracer built by New.
+type config struct {
+	reporter      go2sky.Reporter
+	newReporter   func(*config) (go2sky.Reporter, error)
+	instance      string
+	environment   string
+	samplingRate  *float64
+	limits        *tengcoruxTracer.SpanLimits
+	tracerOptions []go2sky.TracerOption
+}
+
+type Option func(*config)
+
+// WithReporter reports the segments to the given reporter, such as the
+// in-memory reporter of the skywalkingtest package.
+func WithReporter(r go2sky.Reporter) Option {
+	return func(cfg *config) {
+		cfg.reporter = r
+		cfg.newReporter = nil
+	}
+}
+
+// WithGRPCReporter reports the segments to the OAP server at exportAddr
+// through gRPC.
+func WithGRPCReporter(exportAddr string, opts ...reporter.GRPCReporterOption) Option {
+	return func(cfg *config) {
+		cfg.reporter = nil
+		cfg.newReporter = newGRPCReporter(exportAddr, opts)
+	}
+}
+
+// WithLogReporter logs the segments to the standard logger instead of
+// reporting them to an OAP server, which is handy during development.
+func WithLogReporter() Option {
+	return func(cfg *config) {
+		cfg.reporter = nil
+		cfg.newReporter = newLogReporter
+	}
+}
+
+// WithInstance sets the name of the service instance. Defaults to a random
+// id suffixed by the IPv4 address of the host.
+func WithInstance(name string) Option {
+	return func(cfg *config) {
+		cfg.instance = name
+	}
+}
+
+// WithEnvironment groups the service by the given environment, following
+// the "group::service" naming of SkyWalking. The gRPC reporter also reports
+// it as the "environment" instance property.
+func WithEnvironment(env string) Option {
+	return func(cfg *config) {
+		cfg.environment = env
+	}
+}
+
+// WithSamplingRate samples the given ratio of the segments, between 0 and 1.
+// It is overridden by WithSampler given through WithTracerOptions. Defaults
+// to sampling every segment.
+func WithSamplingRate(rate float64) Option {
+	return func(cfg *config) {
+		cfg.samplingRate = &rate
+	}
+}
+
+// WithSpanLimits sets the limits enforced on the spans of the tracer before
+// they reach go2sky. Defaults to the global [tengcoruxTracer.GetSpanLimits]
+// at span start.
+func WithSpanLimits(limits tengcoruxTracer.SpanLimits) Option {
+	return func(cfg *config) {
+		cfg.limits = &limits
+	}
+}
+
+// WithTracerOptions passes the given options to the go2sky tracer, such as
+// WithSampler or go2sky.WithCorrelation.
+func WithTracerOptions(opts ...go2sky.TracerOption) Option {
+	return func(cfg *config) {
+		cfg.tracerOptions = append(cfg.tracerOptions, opts...)
+	}
+}
@@ -0,0 +1,111 @@
+package skywalking
+
+import (
+	"context"
+
+	"github.com/SkyAPM/go2sky"
+	"github.com/SkyAPM/go2sky/propagation"
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+)
+
+type remoteSpanContextKey struct{}
+
+// remoteSpanKey is the key that holds the *propagation.SpanContext extracted
+// from a carrier inside a context.
+var remoteSpanKey remoteSpanContextKey
+
+// Inject writes the sw8 and sw8-correlation headers of the active span found
+// in the given context into the carrier. The sample flag follows the one of
+// the remote segment continued by the span, since go2sky samples every
+// segment continuing a remote one. Otherwise it is set, as only sampled
+// spans are reported hence injected.
+func (t *Tracer) Inject(ctx context.Context, carrier tengcoruxTracer.Carrier) {
+	spanContext := activeSpanContext(ctx)
+	if spanContext == nil {
+		return
+	}
+
+	_ = spanContext.Encode(func(headerKey, headerValue string) error {
+		carrier.Set(headerKey, headerValue)
+		return nil
+	})
+}
+
+// Extract reads the sw8 and sw8-correlation headers from the carrier and
+// returns a copy of ctx holding the remote span context. The next span
+// started from the returned context without an active span will reference
+// the remote segment.
+func (t *Tracer) Extract(ctx context.Context, carrier tengcoruxTracer.Carrier) context.Context {
+	spanContext := &propagation.SpanContext{}
+	err := spanContext.Decode(func(headerKey string) (string, error) {
+		return carrier.Get(headerKey), nil
+	})
+	if err != nil || !spanContext.Valid {
+		return ctx
+	}
+
+	return context.WithValue(ctx, remoteSpanKey, spanContext)
+}
+
+// activeSpanContext builds the propagation.SpanContext referring the active
+// span found in the given context. It returns nil if there are none.
+func activeSpanContext(ctx context.Context) *propagation.SpanContext {
+	span, ok := go2sky.ActiveSpan(ctx).(go2sky.ReportedSpan)
+	if !ok {
+		return nil
+	}
+
+	segmentContext := span.Context()
+	return &propagation.SpanContext{
+		Sample:                sampleFlag(ctx, segmentContext.TraceID),
+		TraceID:               segmentContext.TraceID,
+		ParentSegmentID:       segmentContext.SegmentID,
+		ParentSpanID:          segmentContext.SpanID,
+		ParentService:         go2sky.ServiceName(ctx),
+		ParentServiceInstance: go2sky.ServiceInstanceName(ctx),
+		ParentEndpoint:        segmentContext.FirstSpan.GetOperationName(),
+		AddressUsedAtClient:   span.Peer(),
+		CorrelationContext:    segmentContext.CorrelationContext,
+	}
+}
+
+// sampleFlag returns the sample flag of the remote span context of the trace
+// found in the given context, else 1.
+func sampleFlag(ctx context.Context, traceID string) int8 {
+	if remote := remoteSpanContextFromContext(ctx); remote != nil &&
+		remote.TraceID == traceID {
+		return remote.Sample
+	}
+	return 1
+}
+
+// remoteSpanContextFromContext returns the extracted remote span context
+// inside the given context. It returns nil if there are none.
+func remoteSpanContextFromContext(ctx context.Context) *propagation.SpanContext {
+	spanContext, _ := ctx.Value(remoteSpanKey).(*propagation.SpanContext)
+	return spanContext
+}
+
+// remoteExtractor returns an extractor reading the sw8 and sw8-correlation
+// headers of the remote span context inside the given context. Like the
+// other spans, only the first span of the segment continues it.
+func remoteExtractor(ctx context.Context) propagation.Extractor {
+	headers := make(map[string]string)
+	if go2sky.ActiveSpan(ctx) == nil {
+		if remote := remoteSpanContextFromContext(ctx); remote != nil {
+			_ = remote.Encode(func(headerKey, headerValue string) error {
+				headers[headerKey] = headerValue
+				return nil
+			})
+		}
+	}
+
+	return func(headerKey string) (string, error) {
+		return headers[headerKey], nil
+	}
+}
+
+// discardInjector is an injector discarding the headers.
+func discardInjector(string, string) error {
+	return nil
+}
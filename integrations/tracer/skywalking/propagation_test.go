@@ -0,0 +1,155 @@
+package skywalking
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/SkyAPM/go2sky"
+	"github.com/SkyAPM/go2sky/propagation"
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+)
+
+func TestSkyWalkingTracer_Inject(t *testing.T) {
+	defer recoverPanic(t)()
+
+	tracer, err := startTestingTracer()
+	if err != nil {
+		t.Fatal("unable to start testing tracer")
+	}
+
+	t.Run("EmptyContext", func(t *testing.T) {
+		carrier := tengcoruxTracer.MapCarrier{}
+		tracer.Inject(context.Background(), carrier)
+		if len(carrier) != 0 {
+			t.Errorf("carrier should be empty, but got %v", carrier)
+		}
+	})
+
+	t.Run("ActiveSpan", func(t *testing.T) {
+		ctx, span := tracer.StartSpan(context.Background(), "GET /hello")
+		defer span.End()
+
+		header := http.Header{}
+		tracer.Inject(ctx, tengcoruxTracer.HeaderCarrier(header))
+
+		spanContext := &propagation.SpanContext{}
+		if err := spanContext.DecodeSW8(header.Get(propagation.Header)); err != nil {
+			t.Fatalf("injected sw8 header should be decodable, got %v", err)
+		}
+
+		if spanContext.TraceID != span.Context().TraceID() {
+			t.Errorf("expected trace id %s but got %s",
+				span.Context().TraceID(), spanContext.TraceID)
+		}
+		if spanContext.ParentSegmentID != go2sky.TraceSegmentID(ctx) {
+			t.Errorf("expected parent segment id %s but got %s",
+				go2sky.TraceSegmentID(ctx), spanContext.ParentSegmentID)
+		}
+		if spanContext.ParentService != serviceName {
+			t.Errorf("expected parent service %s but got %s",
+				serviceName, spanContext.ParentService)
+		}
+		if spanContext.ParentEndpoint != "GET /hello" {
+			t.Errorf("expected parent endpoint GET /hello but got %s",
+				spanContext.ParentEndpoint)
+		}
+		if spanContext.Sample != 1 {
+			t.Errorf("expected the sampled span to be flagged but got %d",
+				spanContext.Sample)
+		}
+	})
+
+	t.Run("Remote Sample Flag", func(t *testing.T) {
+		for _, sample := range []int8{0, 1} {
+			remote := &propagation.SpanContext{
+				Sample:                sample,
+				TraceID:               "remote-trace-id",
+				ParentSegmentID:       "remote-segment-id",
+				ParentService:         "remote-service",
+				ParentServiceInstance: "remote-instance",
+				ParentEndpoint:        "GET /remote",
+				AddressUsedAtClient:   "localhost:8080",
+			}
+			ctx := tracer.Extract(context.Background(), tengcoruxTracer.MapCarrier{
+				propagation.Header: remote.EncodeSW8(),
+			})
+			ctx, span := tracer.StartSpan(ctx, "GET /hello",
+				tengcoruxTracer.WithSpanType(tengcoruxTracer.SpanTypeEntry))
+			ctx, child := tracer.StartSpan(ctx, "GET /downstream",
+				tengcoruxTracer.WithSpanType(tengcoruxTracer.SpanTypeExit))
+
+			header := http.Header{}
+			tracer.Inject(ctx, tengcoruxTracer.HeaderCarrier(header))
+			child.End()
+			span.End()
+
+			spanContext := &propagation.SpanContext{}
+			if err := spanContext.DecodeSW8(header.Get(propagation.Header)); err != nil {
+				t.Fatalf("injected sw8 header should be decodable, got %v", err)
+			}
+			if spanContext.TraceID != "remote-trace-id" {
+				t.Errorf("expected the remote trace to be continued but got %s",
+					spanContext.TraceID)
+			}
+			if spanContext.Sample != sample {
+				t.Errorf("expected the remote sample flag %d but got %d", sample,
+					spanContext.Sample)
+			}
+		}
+	})
+}
+
+func TestSkyWalkingTracer_Extract(t *testing.T) {
+	defer recoverPanic(t)()
+
+	tracer, err := startTestingTracer()
+	if err != nil {
+		t.Fatal("unable to start testing tracer")
+	}
+
+	t.Run("EmptyCarrier", func(t *testing.T) {
+		ctx := context.Background()
+		if tracer.Extract(ctx, tengcoruxTracer.MapCarrier{}) != ctx {
+			t.Error("ctx should be returned as is")
+		}
+	})
+
+	t.Run("InvalidCarrier", func(t *testing.T) {
+		ctx := context.Background()
+		carrier := tengcoruxTracer.MapCarrier{propagation.Header: "invalid"}
+		if tracer.Extract(ctx, carrier) != ctx {
+			t.Error("ctx should be returned as is")
+		}
+	})
+
+	t.Run("ContinueRemoteSegment", func(t *testing.T) {
+		upstreamCtx, upstreamSpan := tracer.StartSpan(context.Background(),
+			"upstream")
+		defer upstreamSpan.End()
+
+		carrier := tengcoruxTracer.MapCarrier{}
+		tracer.Inject(upstreamCtx, carrier)
+
+		ctx := tracer.Extract(context.Background(), carrier)
+		_, span := tracer.StartSpan(ctx, "downstream")
+		defer span.End()
+
+		if span.Context().TraceID() != upstreamSpan.Context().TraceID() {
+			t.Errorf("expected trace id %s but got %s",
+				upstreamSpan.Context().TraceID(), span.Context().TraceID())
+		}
+
+		reportedSpan, ok := span.(*Span).span.(go2sky.ReportedSpan)
+		if !ok {
+			t.Fatal("span should be a reported span")
+		}
+		if refs := reportedSpan.Refs(); len(refs) != 1 {
+			t.Errorf("expected the span to have 1 segment ref, but got %d",
+				len(refs))
+		} else if refs[0].ParentSegmentID != go2sky.TraceSegmentID(upstreamCtx) {
+			t.Errorf("expected ref parent segment id %s but got %s",
+				go2sky.TraceSegmentID(upstreamCtx), refs[0].ParentSegmentID)
+		}
+	})
+}
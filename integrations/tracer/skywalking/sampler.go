@@ -0,0 +1,30 @@
+package skywalking
+
+import (
+	"github.com/SkyAPM/go2sky"
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+)
+
+// sampler adapts a tengcorux Sampler into a go2sky Sampler.
+type sampler struct {
+	sampler tengcoruxTracer.Sampler
+}
+
+var _ go2sky.Sampler = (*sampler)(nil)
+
+// IsSampled delegates the decision to the underlying tengcorux Sampler.
+// go2sky only consults its sampler for the first span of a segment that
+// does not continue another one, before any trace id is generated. Hence,
+// only the operation name is available to the tengcorux Sampler.
+func (s *sampler) IsSampled(operation string) bool {
+	return s.sampler.ShouldSample(tengcoruxTracer.SamplingParameters{
+		Name: operation,
+	}) == tengcoruxTracer.RecordAndSample
+}
+
+// WithSampler sets the tengcorux Sampler deciding which segments are
+// reported. Spans continuing an existing segment or a propagated trace
+// follow the decision of their parent.
+func WithSampler(s tengcoruxTracer.Sampler) go2sky.TracerOption {
+	return go2sky.WithCustomSampler(&sampler{sampler: s})
+}
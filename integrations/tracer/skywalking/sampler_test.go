@@ -0,0 +1,39 @@
+package skywalking
+
+import (
+	"context"
+	"testing"
+
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+)
+
+func TestSampler_IsSampled(t *testing.T) {
+	if !(&sampler{sampler: tengcoruxTracer.AlwaysSample()}).IsSampled("testing") {
+		t.Error("expected operation to be sampled")
+	}
+	if (&sampler{sampler: tengcoruxTracer.NeverSample()}).IsSampled("testing") {
+		t.Error("expected operation to not be sampled")
+	}
+}
+
+func TestWithSampler(t *testing.T) {
+	defer recoverPanic(t)()
+
+	tracer, err := NewTracer(exportAddress, serviceName,
+		WithSampler(tengcoruxTracer.NeverSample()))
+	if err != nil {
+		t.Fatal("unable to start testing tracer")
+	}
+
+	ctx, span := tracer.StartSpan(context.Background(), "dropped")
+	defer span.End()
+	if span.IsRecording() {
+		t.Error("dropped span should not be recording")
+	}
+
+	_, child := tracer.StartSpan(ctx, "child")
+	defer child.End()
+	if child.IsRecording() {
+		t.Error("child of dropped span should not be recording")
+	}
+}
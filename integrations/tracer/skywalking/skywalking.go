@@ -1,21 +1,94 @@
 package skywalking
 
 import (
+	"errors"
+
 	"github.com/SkyAPM/go2sky"
 	"github.com/SkyAPM/go2sky/reporter"
 )
 
-func NewTracer(exportAddr, serviceName string, opts ...go2sky.TracerOption) (*Tracer, error) {
-	r, err := reporter.NewGRPCReporter(exportAddr)
-	if err != nil {
-		return nil, err
+// errNoReporter tells that none of the reporter options were given.
+var errNoReporter = errors.New("skywalking: no reporter given")
+
+// New creates a Tracer reporting the segments of the given service to the
+// reporter of WithReporter, WithGRPCReporter or WithLogReporter. When more
+// than one of them are given, the last one is used. For example:
+//
+//	tracer, err := skywalking.New("my-service",
+//		skywalking.WithGRPCReporter("oap:11800"),
+//		skywalking.WithEnvironment("production"),
+//		skywalking.WithSamplingRate(0.5),
+//	)
+//
+// The reporter is closed once the Tracer is shut down.
+func New(serviceName string, opts ...Option) (*Tracer, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
 	}
 
-	opts = append(opts, go2sky.WithReporter(r))
-	tracer, err := go2sky.NewTracer(serviceName, opts...)
+	r := cfg.reporter
+	if r == nil && cfg.newReporter != nil {
+		var err error
+		if r, err = cfg.newReporter(cfg); err != nil {
+			return nil, err
+		}
+	}
+	if r == nil {
+		return nil, errNoReporter
+	}
+
+	if cfg.environment != "" {
+		serviceName = cfg.environment + "::" + serviceName
+	}
+
+	tracerOpts := make([]go2sky.TracerOption, 0, len(cfg.tracerOptions)+3)
+	if cfg.instance != "" {
+		tracerOpts = append(tracerOpts, go2sky.WithInstance(cfg.instance))
+	}
+	if cfg.samplingRate != nil {
+		tracerOpts = append(tracerOpts, go2sky.WithSampler(*cfg.samplingRate))
+	}
+	tracerOpts = append(tracerOpts, cfg.tracerOptions...)
+	dr := &discardingReporter{Reporter: r}
+	tracerOpts = append(tracerOpts, go2sky.WithReporter(dr))
+
+	tracer, err := go2sky.NewTracer(serviceName, tracerOpts...)
 	if err != nil {
+		r.Close()
 		return nil, err
 	}
 
-	return &Tracer{tracer: tracer, reporter: r}, nil
+	return &Tracer{tracer: tracer, reporter: dr, limits: cfg.limits}, nil
+}
+
+// NewTracer creates a Tracer reporting the segments of the given service to
+// the OAP server at exportAddr through gRPC. It is a shorthand of New with
+// WithGRPCReporter and WithTracerOptions.
+func NewTracer(exportAddr, serviceName string, opts ...go2sky.TracerOption) (*Tracer, error) {
+	return New(serviceName,
+		WithGRPCReporter(exportAddr),
+		WithTracerOptions(opts...),
+	)
+}
+
+// newGRPCReporter returns a constructor of a gRPC reporter to the OAP server
+// at exportAddr. The environment is reported as an instance property, unless
+// the given options replace the instance properties.
+func newGRPCReporter(exportAddr string, opts []reporter.GRPCReporterOption) func(*config) (go2sky.Reporter, error) {
+	return func(cfg *config) (go2sky.Reporter, error) {
+		var grpcOpts []reporter.GRPCReporterOption
+		if cfg.environment != "" {
+			grpcOpts = append(grpcOpts, reporter.WithInstanceProps(map[string]string{
+				"environment": cfg.environment,
+			}))
+		}
+		grpcOpts = append(grpcOpts, opts...)
+		return reporter.NewGRPCReporter(exportAddr, grpcOpts...)
+	}
+}
+
+// newLogReporter is the constructor of a reporter logging the segments.
+func newLogReporter(*config) (go2sky.Reporter, error) {
+	return reporter.NewLogReporter()
 }
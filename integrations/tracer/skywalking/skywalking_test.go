@@ -2,10 +2,14 @@ package skywalking
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/rmscoal/tengcorux/integrations/tracer/skywalking/skywalkingtest"
 	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+	tengcoruxAttribute "github.com/rmscoal/tengcorux/tracer/attribute"
 
 	"github.com/SkyAPM/go2sky"
 )
@@ -36,8 +40,104 @@ func TestSkyWalking_New(t *testing.T) {
 	tengcoruxTracer.SetGlobalTracer(tracer)
 }
 
+func TestSkyWalking_NewWithOptions(t *testing.T) {
+	t.Run("No Reporter", func(t *testing.T) {
+		if _, err := New(serviceName); !errors.Is(err, errNoReporter) {
+			t.Errorf("expected %v but got %v", errNoReporter, err)
+		}
+	})
+
+	t.Run("Log Reporter", func(t *testing.T) {
+		tracer, err := New(serviceName, WithLogReporter())
+		if err != nil {
+			t.Fatalf("should not throw error, got %v", err)
+		}
+		defer tracer.Shutdown(context.Background())
+	})
+
+	t.Run("In-Memory Reporter", func(t *testing.T) {
+		reporter := skywalkingtest.NewReporter()
+		tracer, err := New(serviceName,
+			WithLogReporter(),
+			WithReporter(reporter),
+			WithInstance("testing"),
+			WithEnvironment("staging"),
+		)
+		if err != nil {
+			t.Fatalf("should not throw error, got %v", err)
+		}
+
+		if reporter.Service() != "staging::"+serviceName {
+			t.Errorf("expected service staging::%s but got %s", serviceName,
+				reporter.Service())
+		} else if reporter.Instance() != "testing" {
+			t.Errorf("expected instance testing but got %s", reporter.Instance())
+		}
+
+		_, span := tracer.StartSpan(context.Background(), "GET /hello")
+		span.End()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		segments, err := reporter.WaitForSegments(ctx, 1)
+		if err != nil {
+			t.Fatalf("expected a reported segment but got %v", err)
+		} else if segments[0][0].OperationName() != "GET /hello" {
+			t.Errorf("expected span GET /hello but got %s",
+				segments[0][0].OperationName())
+		}
+
+		_ = tracer.Shutdown(context.Background())
+		if !reporter.Closed() {
+			t.Error("expected shutdown to close the reporter")
+		}
+	})
+
+	t.Run("Sampling Rate", func(t *testing.T) {
+		tracer, err := New(serviceName,
+			WithReporter(skywalkingtest.NewReporter()),
+			WithSamplingRate(0),
+		)
+		if err != nil {
+			t.Fatalf("should not throw error, got %v", err)
+		}
+		defer tracer.Shutdown(context.Background())
+
+		_, span := tracer.StartSpan(context.Background(), "GET /hello")
+		defer span.End()
+		if span.IsRecording() {
+			t.Error("expected the span to not be sampled")
+		}
+	})
+
+	t.Run("Span Limits", func(t *testing.T) {
+		tracer, err := New(serviceName,
+			WithReporter(skywalkingtest.NewReporter()),
+			WithSpanLimits(tengcoruxTracer.SpanLimits{AttributeCountLimit: 1}),
+		)
+		if err != nil {
+			t.Fatalf("should not throw error, got %v", err)
+		}
+		defer tracer.Shutdown(context.Background())
+
+		ctx, span := tracer.StartSpan(context.Background(), "GET /hello")
+		defer span.End()
+		span.SetAttributes(
+			tengcoruxAttribute.String("a", "a"),
+			tengcoruxAttribute.String("b", "b"),
+		)
+		tracer.SpanFromContext(ctx).SetAttributes(
+			tengcoruxAttribute.String("c", "c"))
+
+		tags := span.(*Span).span.(go2sky.ReportedSpan).Tags()
+		if len(tags) != 1 || tags[0].Key != "a" {
+			t.Errorf("expected only the first attribute to be kept but got %v", tags)
+		}
+	})
+}
+
 func TestSkyWalking_EndToEnd(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	tracer, err := startTestingTracer()
 	if err != nil {
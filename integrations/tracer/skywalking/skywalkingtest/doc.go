@@ -0,0 +1,26 @@
+// Package skywalkingtest provides utilities and helpers for unit testing
+// your package that is traced by the SkyWalking integration of tengcorux.
+//
+// The skywalkingtest package simplifies the following features:
+//   - Reports segments without requiring an OAP server to receive them.
+//   - Reads the reported segments and spans back.
+//
+// Furthermore, the Reporter implements go2sky's Reporter. Hence, it may be
+// given to the SkyWalking tracer with skywalking.WithReporter.
+//
+// Example usages:
+//
+//	func TestMyFunction(t *testing.T) {
+//		reporter := skywalkingtest.NewReporter()
+//		tracer, _ := skywalking.New("my-service",
+//			skywalking.WithReporter(reporter))
+//
+//		_, span := tracer.StartSpan(context.Background(), "some_name")
+//		span.End()
+//
+//		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//		defer cancel()
+//		segments, _ := reporter.WaitForSegments(ctx, 1)
+//		_ = segments // do something with it.
+//	}
+package skywalkingtest
@@ -0,0 +1,125 @@
+package skywalkingtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SkyAPM/go2sky"
+)
+
+// Reporter is an in-memory go2sky.Reporter keeping every reported segment.
+// go2sky reports a segment asynchronously once its spans have ended, hence
+// use WaitForSegments before reading the segments.
+type Reporter struct {
+	mu       sync.Mutex
+	service  string
+	instance string
+	closed   bool
+	segments [][]go2sky.ReportedSpan
+	// sent is closed and replaced whenever a segment is reported.
+	sent chan struct{}
+}
+
+// Make sure that Reporter implements go2sky.Reporter during compile time.
+var _ go2sky.Reporter = (*Reporter)(nil)
+
+// NewReporter returns an empty Reporter.
+func NewReporter() *Reporter {
+	return &Reporter{sent: make(chan struct{})}
+}
+
+// Boot records the service and the instance of the tracer.
+func (r *Reporter) Boot(service string, serviceInstance string, _ []go2sky.AgentConfigChangeWatcher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.service = service
+	r.instance = serviceInstance
+}
+
+// Send records the spans of a segment. The root span of the segment comes
+// last.
+func (r *Reporter) Send(spans []go2sky.ReportedSpan) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+
+	r.segments = append(r.segments, spans)
+	close(r.sent)
+	r.sent = make(chan struct{})
+}
+
+// Close stops recording the segments, the recorded ones remain readable.
+func (r *Reporter) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+}
+
+// Service returns the service name the reporter was booted with.
+func (r *Reporter) Service() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.service
+}
+
+// Instance returns the instance name the reporter was booted with.
+func (r *Reporter) Instance() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.instance
+}
+
+// Closed reports whether the reporter was closed.
+func (r *Reporter) Closed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closed
+}
+
+// Segments returns a copy of the reported segments.
+func (r *Reporter) Segments() [][]go2sky.ReportedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]go2sky.ReportedSpan(nil), r.segments...)
+}
+
+// Spans returns the spans of every reported segment.
+func (r *Reporter) Spans() []go2sky.ReportedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var spans []go2sky.ReportedSpan
+	for _, segment := range r.segments {
+		spans = append(spans, segment...)
+	}
+	return spans
+}
+
+// WaitForSegments waits until at least n segments were reported and returns
+// them, or the error of the context once it is done.
+func (r *Reporter) WaitForSegments(ctx context.Context, n int) ([][]go2sky.ReportedSpan, error) {
+	for {
+		r.mu.Lock()
+		segments, sent := r.segments, r.sent
+		r.mu.Unlock()
+
+		if len(segments) >= n {
+			return append([][]go2sky.ReportedSpan(nil), segments...), nil
+		}
+
+		select {
+		case <-sent:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Reset removes the reported segments.
+func (r *Reporter) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.segments = nil
+}
@@ -0,0 +1,85 @@
+package skywalkingtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SkyAPM/go2sky"
+)
+
+func TestReporter(t *testing.T) {
+	reporter := NewReporter()
+	tracer, err := go2sky.NewTracer("testing_service",
+		go2sky.WithInstance("testing"),
+		go2sky.WithReporter(reporter),
+	)
+	if err != nil {
+		t.Fatalf("unable to create tracer: %v", err)
+	}
+
+	t.Run("Boot", func(t *testing.T) {
+		if reporter.Service() != "testing_service" {
+			t.Errorf("expected service testing_service but got %s", reporter.Service())
+		} else if reporter.Instance() != "testing" {
+			t.Errorf("expected instance testing but got %s", reporter.Instance())
+		}
+	})
+
+	t.Run("Send", func(t *testing.T) {
+		defer reporter.Reset()
+
+		span, ctx, err := tracer.CreateLocalSpan(context.Background(),
+			go2sky.WithOperationName("parent"))
+		if err != nil {
+			t.Fatalf("unable to create span: %v", err)
+		}
+		child, _, _ := tracer.CreateLocalSpan(ctx, go2sky.WithOperationName("child"))
+		child.End()
+		span.End()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		segments, err := reporter.WaitForSegments(ctx, 1)
+		if err != nil {
+			t.Fatalf("expected a segment but got %v", err)
+		} else if len(segments) != 1 {
+			t.Fatalf("expected 1 segment but got %d", len(segments))
+		}
+
+		spans := reporter.Spans()
+		if len(spans) != 2 {
+			t.Fatalf("expected 2 spans but got %d", len(spans))
+		} else if spans[1].OperationName() != "parent" {
+			t.Errorf("expected the root span last but got %s", spans[1].OperationName())
+		}
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		reporter.Send([]go2sky.ReportedSpan{})
+		reporter.Reset()
+		if len(reporter.Segments()) != 0 {
+			t.Errorf("expected no segment but got %d", len(reporter.Segments()))
+		}
+	})
+
+	t.Run("WaitForSegments Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := reporter.WaitForSegments(ctx, 1); err != context.DeadlineExceeded {
+			t.Errorf("expected %v but got %v", context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		reporter.Close()
+		if !reporter.Closed() {
+			t.Error("expected the reporter to be closed")
+		}
+
+		reporter.Send([]go2sky.ReportedSpan{})
+		if len(reporter.Segments()) != 0 {
+			t.Error("expected a closed reporter to drop the segments")
+		}
+	})
+}
@@ -3,7 +3,9 @@ package skywalking
 import (
 	"context"
 	"fmt"
-	"go/types"
+	"net"
+	"net/url"
+	"runtime/debug"
 	"strconv"
 	"time"
 
@@ -12,7 +14,9 @@ import (
 	"github.com/rmscoal/tengcorux/tracer/attribute"
 )
 
-var _ tengcoruxTracer.Span = (*Span)(nil)
+// Make sure that Span implements [tengcoruxTracer.DiscardableSpan] during
+// compile time.
+var _ tengcoruxTracer.DiscardableSpan = (*Span)(nil)
 
 // Span represents a unit of work in a distributed trace.
 //
@@ -25,6 +29,12 @@ type Span struct {
 	tracer  *Tracer
 	context *SpanContext
 	name    string
+	limiter *tengcoruxTracer.SpanLimiter
+
+	// peer attributes of an exit span
+	serverAddress string
+	serverPort    string
+	urlHost       string
 }
 
 // End ends the current span.
@@ -32,32 +42,122 @@ func (s *Span) End() {
 	s.span.End()
 }
 
-// SetAttributes sets attributes to the current span.
+// Discard ends the current span without reporting it. Since the spans of a
+// segment are reported together, discarding the first span of a segment
+// discards the whole segment.
+func (s *Span) Discard() {
+	reported, ok := s.span.(go2sky.ReportedSpan)
+	if ok && s.tracer != nil && s.tracer.reporter != nil {
+		s.tracer.reporter.discard(reported)
+	}
+	s.span.End()
+}
+
+// SetAttributes sets attributes to the current span within the span limits.
+// The "db.system" and "mq.system" attributes also set the component library
+// registered by RegisterComponentLibrary, and the "server.address",
+// "server.port" and "url.full" attributes set the peer of an exit span.
 func (s *Span) SetAttributes(attributes ...attribute.KeyValue) {
-	for _, attr := range attributes {
-		var value string
-
-		switch attr.Value.(type) {
-		case int, int32, int64, uint, uint32, uint64, float32, float64:
-			value = fmt.Sprintf("%d", attr.Value)
-		case string:
-			value = attr.Value.(string)
-		case struct{}, types.Slice:
-			value = fmt.Sprintf("%v", attr.Value)
+	for _, attr := range s.limiter.LimitAttributes(attributes...) {
+		s.span.Tag(go2sky.Tag(attr.Key), attr.Value.Emit())
+		switch attr.Key {
+		case attribute.DBSystemKey, attribute.MQSystemKey:
+			if component, ok := lookupComponentLibrary(attr.Value.Emit()); ok {
+				s.span.SetComponent(component.AsInt32())
+			}
+		case attribute.HTTPServerAddressKey, attribute.HTTPServerPortKey, attribute.HTTPUrlKey:
+			s.setPeer(attr)
+		}
+	}
+}
+
+// setPeer sets the peer of an exit span from the attribute. The server
+// address, with the port if any, takes precedence over the host of the url.
+func (s *Span) setPeer(attr attribute.KeyValue) {
+	if !s.span.IsExit() {
+		return
+	}
+
+	switch attr.Key {
+	case attribute.HTTPServerAddressKey:
+		s.serverAddress = attr.Value.Emit()
+	case attribute.HTTPServerPortKey:
+		s.serverPort = attr.Value.Emit()
+	case attribute.HTTPUrlKey:
+		if u, err := url.Parse(attr.Value.Emit()); err == nil {
+			s.urlHost = u.Host
 		}
+	}
 
-		s.span.Tag(go2sky.Tag(attr.Key), value)
+	peer := s.urlHost
+	if s.serverAddress != "" {
+		peer = s.serverAddress
+		if s.serverPort != "" {
+			peer = net.JoinHostPort(s.serverAddress, s.serverPort)
+		}
+	}
+	if peer != "" {
+		s.span.SetPeer(peer)
 	}
 }
 
-// RecordError records an error to the current span at current timeframe.
+// RecordError logs an error to the current span at current timeframe. It
+// does not mark the span as an error span, use SetStatus for that.
 func (s *Span) RecordError(err error) {
-	s.span.Error(time.Now(), err.Error())
+	if err == nil {
+		return
+	}
+	s.span.Log(time.Now(),
+		"event", "error",
+		"error.kind", fmt.Sprintf("%T", err),
+		"message", err.Error(),
+	)
 }
 
-// AddEvent adds an event to the current span at current timeframe.
-func (s *Span) AddEvent(descriptions ...string) {
-	s.span.Log(time.Now(), descriptions...)
+// SetStatus marks the current span as an error span when the code is
+// StatusError. SkyWalking has no notion of Unset and Ok status, hence
+// the other codes do nothing.
+func (s *Span) SetStatus(code tengcoruxTracer.StatusCode, description string) {
+	if code != tengcoruxTracer.StatusError {
+		return
+	}
+
+	logs := []string{"event", "error"}
+	if description != "" {
+		logs = append(logs, "message", description)
+	}
+	s.span.Error(time.Now(), logs...)
+}
+
+// AddEvent adds an event to the current span as a log whose fields are
+// the event name, the attributes and optionally the stack trace. Events
+// beyond the span limits are dropped.
+func (s *Span) AddEvent(name string, opts ...tengcoruxTracer.EventOption) {
+	cfg := tengcoruxTracer.NewEventConfig(opts...)
+
+	attributes, ok := s.limiter.LimitEvent(cfg.Attributes)
+	if !ok {
+		return
+	}
+
+	fields := make([]string, 0, 2*len(attributes)+4)
+	fields = append(fields, "event", name)
+	for _, attr := range attributes {
+		fields = append(fields, string(attr.Key), attr.Value.Emit())
+	}
+	if cfg.StackTrace {
+		fields = append(fields, "stack", string(debug.Stack()))
+	}
+
+	s.span.Log(cfg.Timestamp, fields...)
+}
+
+// IsRecording returns true if the span is sampled and has not ended.
+func (s *Span) IsRecording() bool {
+	if _, noop := s.span.(*go2sky.NoopSpan); noop {
+		return false
+	}
+	return s.span.IsValid()
 }
 
 // Context returns SpanContext.
@@ -121,7 +221,9 @@ const (
 	Unknown      ComponentLibrary = 0
 	GoRedis      ComponentLibrary = 7
 	PostgreSQL   ComponentLibrary = 22
+	GRPC         ComponentLibrary = 23
 	GoKafka      ComponentLibrary = 27
+	SQLite       ComponentLibrary = 31
 	RabbitMQ     ComponentLibrary = 51
 	GoHttpServer ComponentLibrary = 5004
 	GoMysql      ComponentLibrary = 5012
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/SkyAPM/go2sky"
+	"github.com/rmscoal/tengcorux/integrations/tracer/skywalking/skywalkingtest"
+	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
 	tengcoruxAttribute "github.com/rmscoal/tengcorux/tracer/attribute"
 )
 
 func TestSkyWalkingSpan_End(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	tracer, _ := startTestingTracer()
 	ctx, span := tracer.StartSpan(context.Background(), "testing")
@@ -20,8 +24,89 @@ func TestSkyWalkingSpan_End(t *testing.T) {
 	span.End()
 }
 
+// dropProcessor drops every span on end.
+type dropProcessor struct{}
+
+func (dropProcessor) OnStart(context.Context, tengcoruxTracer.ReadWriteSpan) {}
+func (dropProcessor) OnEnd(span tengcoruxTracer.ReadWriteSpan)               { span.Drop() }
+func (dropProcessor) ForceFlush(context.Context) error                       { return nil }
+func (dropProcessor) Shutdown(context.Context) error                         { return nil }
+
+func TestSkyWalkingSpan_Discard(t *testing.T) {
+	defer recoverPanic(t)()
+
+	reporter := skywalkingtest.NewReporter()
+	tracer, err := New(serviceName, WithReporter(reporter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tracer.Shutdown(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.Run("Child Span", func(t *testing.T) {
+		defer reporter.Reset()
+
+		parentCtx, parent := tracer.StartSpan(context.Background(), "parent")
+		_, child := tracer.StartSpan(parentCtx, "child")
+		child.(tengcoruxTracer.DiscardableSpan).Discard()
+		parent.End()
+
+		segments, err := reporter.WaitForSegments(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(segments[0]) != 1 || segments[0][0].OperationName() != "parent" {
+			t.Errorf("expected only the parent span to be reported but got %v",
+				segments[0])
+		}
+	})
+
+	t.Run("Dropped By Processor", func(t *testing.T) {
+		defer reporter.Reset()
+
+		pt := tengcoruxTracer.NewProcessingTracer(tracer, dropProcessor{})
+		parentCtx, parent := tracer.StartSpan(context.Background(), "parent")
+		_, child := pt.StartSpan(parentCtx, "dropped")
+		child.End()
+		parent.End()
+
+		segments, err := reporter.WaitForSegments(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(segments[0]) != 1 || segments[0][0].OperationName() != "parent" {
+			t.Errorf("expected the dropped span to not be reported but got %v",
+				segments[0])
+		}
+	})
+
+	t.Run("First Span", func(t *testing.T) {
+		defer reporter.Reset()
+
+		parentCtx, parent := tracer.StartSpan(context.Background(), "discarded")
+		_, child := tracer.StartSpan(parentCtx, "child")
+		child.End()
+		parent.(tengcoruxTracer.DiscardableSpan).Discard()
+
+		_, span := tracer.StartSpan(context.Background(), "ended")
+		span.End()
+
+		if _, err := reporter.WaitForSegments(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+		for _, span := range reporter.Spans() {
+			if span.OperationName() != "ended" {
+				t.Errorf("expected the discarded segment to not be reported but got %s",
+					span.OperationName())
+			}
+		}
+	})
+}
+
 func TestSkyWalkingSpan_SetAttributes(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	tracer, _ := startTestingTracer()
 	ctx, span := tracer.StartSpan(context.Background(), "testing")
@@ -29,11 +114,137 @@ func TestSkyWalkingSpan_SetAttributes(t *testing.T) {
 		t.Error("should not throw error")
 	}
 
+	span.SetAttributes(
+		tengcoruxAttribute.DBSystem("some_system"),
+		tengcoruxAttribute.Float64("float", 4.5),
+		tengcoruxAttribute.Bool("bool", true),
+		tengcoruxAttribute.StringSlice("strings", []string{"a", "b"}),
+	)
+
+	want := map[string]string{
+		"db.system": "some_system",
+		"float":     "4.5",
+		"bool":      "true",
+		"strings":   `["a","b"]`,
+	}
+	tags := span.(*Span).span.(go2sky.ReportedSpan).Tags()
+	if len(tags) != len(want) {
+		t.Fatalf("expected %d tags but got %d", len(want), len(tags))
+	}
+	for _, tag := range tags {
+		if want[tag.Key] != tag.Value {
+			t.Errorf("%s: expected %q but got %q", tag.Key, want[tag.Key], tag.Value)
+		}
+	}
+}
+
+func TestSkyWalkingSpan_SetAttributes_MQSystem(t *testing.T) {
+	defer recoverPanic(t)()
+
+	tracer, _ := startTestingTracer()
+	_, span := tracer.StartSpan(context.Background(), "testing",
+		tengcoruxTracer.WithSpanLayer(tengcoruxTracer.SpanLayerMQ))
+
+	reported := span.(*Span).span.(go2sky.ReportedSpan)
+	if reported.ComponentID() != GoKafka.AsInt32() {
+		t.Errorf("expected the layer component %d but got %d", GoKafka, reported.ComponentID())
+	}
+
+	span.SetAttributes(tengcoruxAttribute.MQSystem("rabbitmq"))
+	if reported.ComponentID() != RabbitMQ.AsInt32() {
+		t.Errorf("expected the component %d but got %d", RabbitMQ, reported.ComponentID())
+	}
+}
+
+func TestSkyWalkingSpan_SetAttributes_DBSystem(t *testing.T) {
+	defer recoverPanic(t)()
+
+	tracer, _ := startTestingTracer()
+	_, span := tracer.StartSpan(context.Background(), "testing",
+		tengcoruxTracer.WithSpanLayer(tengcoruxTracer.SpanLayerDatabase))
+
+	reported := span.(*Span).span.(go2sky.ReportedSpan)
+	if reported.ComponentID() != GoMysql.AsInt32() {
+		t.Errorf("expected the layer component %d but got %d", GoMysql, reported.ComponentID())
+	}
+
+	span.SetAttributes(tengcoruxAttribute.DBSystem("PostgreSQL"))
+	if reported.ComponentID() != PostgreSQL.AsInt32() {
+		t.Errorf("expected the component %d but got %d", PostgreSQL, reported.ComponentID())
+	}
+
 	span.SetAttributes(tengcoruxAttribute.DBSystem("some_system"))
+	if reported.ComponentID() != PostgreSQL.AsInt32() {
+		t.Errorf("expected unknown systems to keep the component %d but got %d",
+			PostgreSQL, reported.ComponentID())
+	}
+
+	_, span = tracer.StartSpan(context.Background(), "testing",
+		tengcoruxTracer.WithSpanLayer(tengcoruxTracer.SpanLayerDatabase))
+	reported = span.(*Span).span.(go2sky.ReportedSpan)
+
+	span.SetAttributes(tengcoruxAttribute.DBSystem("SQL Server"))
+	if reported.ComponentID() != GoMysql.AsInt32() {
+		t.Errorf("expected the layer component %d but got %d", GoMysql, reported.ComponentID())
+	}
+
+	span.SetAttributes(tengcoruxAttribute.DBSystem("SQLite"))
+	if reported.ComponentID() != SQLite.AsInt32() {
+		t.Errorf("expected the component %d but got %d", SQLite, reported.ComponentID())
+	}
+}
+
+func TestSkyWalkingSpan_SetAttributes_Peer(t *testing.T) {
+	defer recoverPanic(t)()
+
+	tracer, _ := startTestingTracer()
+
+	t.Run("URL", func(t *testing.T) {
+		_, span := tracer.StartSpan(context.Background(), "HTTP GET Request",
+			tengcoruxTracer.WithSpanType(tengcoruxTracer.SpanTypeExit))
+		reported := span.(*Span).span.(go2sky.ReportedSpan)
+		if reported.Peer() != unknownPeer {
+			t.Errorf("expected peer %s but got %s", unknownPeer, reported.Peer())
+		}
+
+		span.SetAttributes(tengcoruxAttribute.HTTPUrl("http://users.svc:8080/users?id=1"))
+		if reported.Peer() != "users.svc:8080" {
+			t.Errorf("expected peer users.svc:8080 but got %s", reported.Peer())
+		}
+	})
+
+	t.Run("Server Address", func(t *testing.T) {
+		_, span := tracer.StartSpan(context.Background(), "redis.get",
+			tengcoruxTracer.WithSpanType(tengcoruxTracer.SpanTypeExit))
+		reported := span.(*Span).span.(go2sky.ReportedSpan)
+
+		span.SetAttributes(
+			tengcoruxAttribute.HTTPServerAddress("redis"),
+			tengcoruxAttribute.HTTPUrl("http://ignored:1"),
+		)
+		if reported.Peer() != "redis" {
+			t.Errorf("expected peer redis but got %s", reported.Peer())
+		}
+
+		span.SetAttributes(tengcoruxAttribute.KeyValuePair("server.port", 6379))
+		if reported.Peer() != "redis:6379" {
+			t.Errorf("expected peer redis:6379 but got %s", reported.Peer())
+		}
+	})
+
+	t.Run("Not Exit Span", func(t *testing.T) {
+		_, span := tracer.StartSpan(context.Background(), "local")
+		span.SetAttributes(tengcoruxAttribute.HTTPServerAddress("redis"))
+
+		reported := span.(*Span).span.(go2sky.ReportedSpan)
+		if reported.Peer() != "" {
+			t.Errorf("expected no peer but got %s", reported.Peer())
+		}
+	})
 }
 
 func TestSkyWalkingSpan_RecordError(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	tracer, _ := startTestingTracer()
 	ctx, span := tracer.StartSpan(context.Background(), "testing")
@@ -42,10 +253,38 @@ func TestSkyWalkingSpan_RecordError(t *testing.T) {
 	}
 
 	span.RecordError(errors.New("some_error"))
+	span.RecordError(nil)
+
+	reported := span.(*Span).span.(go2sky.ReportedSpan)
+	if reported.IsError() {
+		t.Error("recording an error should not mark the span as error")
+	} else if len(reported.Logs()) != 1 {
+		t.Errorf("expected 1 log but got %d", len(reported.Logs()))
+	}
+}
+
+func TestSkyWalkingSpan_SetStatus(t *testing.T) {
+	defer recoverPanic(t)()
+
+	tracer, _ := startTestingTracer()
+	_, span := tracer.StartSpan(context.Background(), "testing")
+	reported := span.(*Span).span.(go2sky.ReportedSpan)
+
+	span.SetStatus(tengcoruxTracer.StatusOk, "")
+	if reported.IsError() {
+		t.Error("ok status should not mark the span as error")
+	}
+
+	span.SetStatus(tengcoruxTracer.StatusError, "some_error")
+	if !reported.IsError() {
+		t.Error("error status should mark the span as error")
+	} else if len(reported.Logs()) != 1 {
+		t.Errorf("expected 1 log but got %d", len(reported.Logs()))
+	}
 }
 
 func TestSkyWalkingSpan_AddEvent(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	tracer, _ := startTestingTracer()
 	ctx, span := tracer.StartSpan(context.Background(), "testing")
@@ -54,10 +293,43 @@ func TestSkyWalkingSpan_AddEvent(t *testing.T) {
 	}
 
 	span.AddEvent("some event is happening now")
+
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	span.AddEvent("cache.miss",
+		tengcoruxTracer.WithEventAttributes(
+			tengcoruxAttribute.KeyValuePair("cache.key", "user:1"),
+			tengcoruxAttribute.KeyValuePair("cache.hit", false),
+		),
+		tengcoruxTracer.WithTimestamp(timestamp),
+		tengcoruxTracer.WithStackTrace(),
+	)
+
+	logs := span.(*Span).span.(go2sky.ReportedSpan).Logs()
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 logs but got %d", len(logs))
+	}
+	if logs[1].GetTime() != timestamp.UnixMilli() {
+		t.Errorf("expected log time to be %d but got %d",
+			timestamp.UnixMilli(), logs[1].GetTime())
+	}
+
+	fields := make(map[string]string)
+	for _, data := range logs[1].GetData() {
+		fields[data.GetKey()] = data.GetValue()
+	}
+	if fields["event"] != "cache.miss" {
+		t.Errorf("expected event to be cache.miss but got %s", fields["event"])
+	} else if fields["cache.key"] != "user:1" {
+		t.Errorf("expected cache.key to be user:1 but got %s", fields["cache.key"])
+	} else if fields["cache.hit"] != "false" {
+		t.Errorf("expected cache.hit to be false but got %s", fields["cache.hit"])
+	} else if fields["stack"] == "" {
+		t.Error("expected a stack trace")
+	}
 }
 
 func TestSkyWalkingSpan_Context(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	tracer, _ := startTestingTracer()
 	ctx, span := tracer.StartSpan(context.Background(), "testing")
@@ -71,8 +343,23 @@ func TestSkyWalkingSpan_Context(t *testing.T) {
 	}
 }
 
+func TestSkyWalkingSpan_IsRecording(t *testing.T) {
+	defer recoverPanic(t)()
+
+	tracer, _ := startTestingTracer()
+	_, span := tracer.StartSpan(context.Background(), "testing")
+	if !span.IsRecording() {
+		t.Error("started span should be recording")
+	}
+
+	span.End()
+	if span.IsRecording() {
+		t.Error("ended span should not be recording")
+	}
+}
+
 func TestSkyWalkingSpanContext(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	tracer, _ := startTestingTracer()
 	ctx, span := tracer.StartSpan(context.Background(), "testing")
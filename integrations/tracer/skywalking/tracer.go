@@ -2,15 +2,28 @@ package skywalking
 
 import (
 	"context"
+	"errors"
 
 	"github.com/SkyAPM/go2sky"
 	"github.com/SkyAPM/go2sky/propagation"
 	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
 )
 
+var (
+	_ tengcoruxTracer.Tracer     = (*Tracer)(nil)
+	_ tengcoruxTracer.Propagator = (*Tracer)(nil)
+)
+
+// unknownPeer is the peer of exit spans until their peer attributes are set.
+const unknownPeer = "unknown"
+
+// errLocalSpan tells that the span is neither an entry nor an exit span.
+var errLocalSpan = errors.New("skywalking: local span")
+
 type Tracer struct {
 	tracer   *go2sky.Tracer
-	reporter go2sky.Reporter
+	reporter *discardingReporter
+	limits   *tengcoruxTracer.SpanLimits
 }
 
 func (t *Tracer) StartSpan(ctx context.Context, name string, opts ...tengcoruxTracer.StartSpanOption) (context.Context, tengcoruxTracer.Span) {
@@ -19,17 +32,42 @@ func (t *Tracer) StartSpan(ctx context.Context, name string, opts ...tengcoruxTr
 		opt(startSpanConfig)
 	}
 
-	go2skySpan, ctx, _ := t.tracer.CreateLocalSpan(ctx, t.generateSkywalkSpanOptions(name, startSpanConfig)...)
+	options := t.generateSkywalkSpanOptions(name, startSpanConfig)
+	if startSpanConfig.TraceID == "" && go2sky.ActiveSpan(ctx) == nil {
+		// Continues the remote segment extracted by the propagator
+		// when the span is the first one of this segment.
+		if remote := remoteSpanContextFromContext(ctx); remote != nil {
+			options = append(options, go2sky.WithContext(remote))
+		}
+	}
+
+	// Links are referenced as additional segment refs. SkyWalking refs carry
+	// no attributes, hence link attributes are dropped. When the span has no
+	// parent, the first link decides the trace id of the segment.
+	for _, link := range startSpanConfig.Links {
+		if spanContext := linkedSpanContext(link.Context); spanContext != nil {
+			options = append(options, go2sky.WithContext(spanContext))
+		}
+	}
+
+	go2skySpan, ctx := t.createSpan(ctx, name, startSpanConfig, options)
 	go2skySpan.SetSpanLayer(mapSpanLayer(startSpanConfig.SpanLayer))
 	go2skySpan.SetComponent(mapComponentLibrary(startSpanConfig.SpanLayer).AsInt32())
 
+	limiter := tengcoruxTracer.NewSpanLimiter(t.spanLimits())
+	ctx = context.WithValue(ctx, spanLimiterKey, &spanLimiter{
+		span:    go2skySpan,
+		limiter: limiter,
+	})
+
 	return ctx, &Span{
 		tracer: t,
 		span:   go2skySpan,
 		context: &SpanContext{
 			ctx: ctx,
 		},
-		name: name,
+		name:    name,
+		limiter: limiter,
 	}
 }
 
@@ -50,12 +88,93 @@ func (t *Tracer) SpanFromContext(ctx context.Context) tengcoruxTracer.Span {
 		context: &SpanContext{
 			ctx: ctx,
 		},
-		name: go2skySpan.GetOperationName(),
+		name:    go2skySpan.GetOperationName(),
+		limiter: t.limiterFromContext(ctx, go2skySpan),
 	}
 }
 
 ////////////// Tracer's PRIVATE METHODS //////////////////
 
+type spanLimiterContextKey struct{}
+
+// spanLimiterKey is the key that holds the *spanLimiter of the span started
+// by StartSpan inside a context.
+var spanLimiterKey spanLimiterContextKey
+
+// spanLimiter ties the SpanLimiter of a span to the go2sky span, such that
+// the span limits are kept across SpanFromContext calls.
+type spanLimiter struct {
+	span    go2sky.Span
+	limiter *tengcoruxTracer.SpanLimiter
+}
+
+// limiterFromContext returns the SpanLimiter of the span if it was started
+// by StartSpan, else a new one following the span limits of the tracer.
+func (t *Tracer) limiterFromContext(ctx context.Context, span go2sky.Span) *tengcoruxTracer.SpanLimiter {
+	stored, ok := ctx.Value(spanLimiterKey).(*spanLimiter)
+	if ok && stored.span == span {
+		return stored.limiter
+	}
+	return tengcoruxTracer.NewSpanLimiter(t.spanLimits())
+}
+
+// spanLimits returns the limits of the tracer, else the global ones.
+func (t *Tracer) spanLimits() tengcoruxTracer.SpanLimits {
+	if t.limits != nil {
+		return *t.limits
+	}
+	return tengcoruxTracer.GetSpanLimits()
+}
+
+// linkedSpanContext returns the span context referred by a link context,
+// preferring the active span over the extracted remote span context.
+func linkedSpanContext(ctx context.Context) *propagation.SpanContext {
+	if ctx == nil {
+		return nil
+	}
+	if spanContext := activeSpanContext(ctx); spanContext != nil {
+		return spanContext
+	}
+	return remoteSpanContextFromContext(ctx)
+}
+
+// createSpan creates an entry span continuing the extracted sw8 headers, or
+// an exit span injecting them, so that SkyWalking draws the edges between the
+// services. Spans manually referring a trace or linked to other spans need
+// the given options, hence are created as local spans marked with their type
+// like any other span.
+func (t *Tracer) createSpan(ctx context.Context, name string,
+	startSpanConfig *tengcoruxTracer.StartSpanConfig, options []go2sky.SpanOption,
+) (go2sky.Span, context.Context) {
+	if startSpanConfig.TraceID == "" && len(startSpanConfig.Links) == 0 {
+		var (
+			span    go2sky.Span
+			spanCtx context.Context
+			err     error
+		)
+		switch startSpanConfig.SpanType {
+		case tengcoruxTracer.SpanTypeEntry:
+			span, spanCtx, err = t.tracer.CreateEntrySpan(ctx, name,
+				remoteExtractor(ctx))
+		case tengcoruxTracer.SpanTypeExit:
+			// The peer is rarely known before the span starts, it is
+			// replaced once the span holds the peer attributes. Likewise,
+			// the sw8 headers are written by Inject once the caller holds
+			// its carrier.
+			span, spanCtx, err = t.tracer.CreateExitSpanWithContext(ctx, name,
+				unknownPeer, discardInjector)
+		default:
+			err = errLocalSpan
+		}
+		if err == nil {
+			return span, spanCtx
+		}
+	}
+
+	span, ctx, _ := t.tracer.CreateLocalSpan(ctx, options...)
+	return span, ctx
+}
+
 // generateSkywalkSpanOptions generates a slice of go2sky SpanOptions from a given operation name and start span config.
 func (t *Tracer) generateSkywalkSpanOptions(operationName string, startSpanConfig *tengcoruxTracer.StartSpanConfig) []go2sky.SpanOption {
 	options := []go2sky.SpanOption{
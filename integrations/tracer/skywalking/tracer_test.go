@@ -5,13 +5,15 @@ import (
 	"testing"
 
 	"github.com/SkyAPM/go2sky"
+	"github.com/SkyAPM/go2sky/propagation"
 	v3 "skywalking.apache.org/repo/goapi/collect/language/agent/v3"
 
 	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
 )
 
 func TestSkyWalkingTracer_StartSpan(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	tracer, err := startTestingTracer()
 	if err != nil {
@@ -77,10 +79,150 @@ func TestSkyWalkingTracer_StartSpan(t *testing.T) {
 			t.Errorf("the reported span layer expects %v, but got %v", v3.SpanLayer_Database, layer)
 		}
 	})
+
+	t.Run("WithLinks", func(t *testing.T) {
+		parentCtx, parent := tracer.StartSpan(context.Background(), "parent")
+		defer parent.End()
+
+		localCtx, local := tracer.StartSpan(context.Background(), "local")
+		defer local.End()
+
+		remote := &propagation.SpanContext{
+			Sample:                1,
+			TraceID:               "remote-trace-id",
+			ParentSegmentID:       "remote-segment-id",
+			ParentSpanID:          0,
+			ParentService:         "remote-service",
+			ParentServiceInstance: "remote-instance",
+			ParentEndpoint:        "GET /remote",
+			AddressUsedAtClient:   "localhost:8080",
+		}
+		header := remote.EncodeSW8()
+		remoteCtx := tracer.Extract(context.Background(), tengcoruxTracer.MapCarrier{
+			propagation.Header: header,
+		})
+
+		_, span := tracer.StartSpan(parentCtx, "batch",
+			tengcoruxTracer.WithLinks(
+				tengcoruxTracer.Link{Context: localCtx},
+				tengcoruxTracer.Link{Context: remoteCtx},
+				tengcoruxTracer.Link{Context: context.Background()},
+			))
+		defer span.End()
+
+		reportedSpan := span.(*Span).span.(go2sky.ReportedSpan)
+		refs := reportedSpan.Refs()
+		if len(refs) != 2 {
+			t.Fatalf("expected 2 refs but got %d", len(refs))
+		}
+		if refs[0].TraceID != local.Context().TraceID() {
+			t.Errorf("expected first ref to refer the local trace %s but got %s",
+				local.Context().TraceID(), refs[0].TraceID)
+		}
+		if refs[1].TraceID != "remote-trace-id" {
+			t.Errorf("expected second ref to refer the remote trace but got %s",
+				refs[1].TraceID)
+		}
+		if span.Context().TraceID() != parent.Context().TraceID() {
+			t.Error("expected links to not change the trace of the span")
+		}
+	})
+}
+
+func TestSkyWalkingTracer_StartSpan_EntryAndExit(t *testing.T) {
+	defer recoverPanic(t)()
+
+	tracer, err := startTestingTracer()
+	if err != nil {
+		t.Fatal("unable to start testing tracer")
+	}
+
+	upstreamCtx, upstream := tracer.StartSpan(context.Background(), "GET /users",
+		tengcoruxTracer.WithSpanType(tengcoruxTracer.SpanTypeEntry))
+	defer upstream.End()
+
+	exitCtx, exit := tracer.StartSpan(upstreamCtx, "HTTP GET Request",
+		tengcoruxTracer.WithSpanType(tengcoruxTracer.SpanTypeExit))
+	defer exit.End()
+	exit.SetAttributes(attribute.HTTPUrl("http://downstream:8080/users"))
+
+	t.Run("Exit", func(t *testing.T) {
+		reported := exit.(*Span).span.(go2sky.ReportedSpan)
+		if reported.SpanType() != v3.SpanType_Exit {
+			t.Errorf("expected span type %v but got %v", v3.SpanType_Exit,
+				reported.SpanType())
+		}
+		if reported.Peer() != "downstream:8080" {
+			t.Errorf("expected peer downstream:8080 but got %s", reported.Peer())
+		}
+		if tracer.SpanFromContext(exitCtx) == nil {
+			t.Error("expected the exit span to be active within its context")
+		}
+	})
+
+	t.Run("Entry", func(t *testing.T) {
+		carrier := tengcoruxTracer.MapCarrier{}
+		tracer.Inject(exitCtx, carrier)
+
+		ctx := tracer.Extract(context.Background(), carrier)
+		_, entry := tracer.StartSpan(ctx, "GET /users",
+			tengcoruxTracer.WithSpanType(tengcoruxTracer.SpanTypeEntry))
+		defer entry.End()
+
+		reported := entry.(*Span).span.(go2sky.ReportedSpan)
+		if reported.SpanType() != v3.SpanType_Entry {
+			t.Errorf("expected span type %v but got %v", v3.SpanType_Entry,
+				reported.SpanType())
+		}
+		if entry.Context().TraceID() != upstream.Context().TraceID() {
+			t.Errorf("expected trace id %s but got %s",
+				upstream.Context().TraceID(), entry.Context().TraceID())
+		}
+
+		refs := reported.Refs()
+		if len(refs) != 1 {
+			t.Fatalf("expected 1 segment ref but got %d", len(refs))
+		}
+		if refs[0].AddressUsedAtClient != "downstream:8080" {
+			t.Errorf("expected the ref address downstream:8080 but got %s",
+				refs[0].AddressUsedAtClient)
+		}
+		if refs[0].ParentSpanID != exit.(*Span).span.(go2sky.ReportedSpan).Context().SpanID {
+			t.Error("expected the ref to refer the exit span")
+		}
+	})
+
+	t.Run("Entry Without Remote", func(t *testing.T) {
+		_, entry := tracer.StartSpan(context.Background(), "GET /users",
+			tengcoruxTracer.WithSpanType(tengcoruxTracer.SpanTypeEntry))
+		defer entry.End()
+
+		reported := entry.(*Span).span.(go2sky.ReportedSpan)
+		if len(reported.Refs()) != 0 {
+			t.Errorf("expected no segment ref but got %d", len(reported.Refs()))
+		}
+	})
+
+	t.Run("Entry With Trace ID", func(t *testing.T) {
+		_, entry := tracer.StartSpan(context.Background(), "GET /users",
+			tengcoruxTracer.WithSpanType(tengcoruxTracer.SpanTypeEntry),
+			tengcoruxTracer.WithTraceID("manual-trace-id"))
+		defer entry.End()
+
+		reported := entry.(*Span).span.(go2sky.ReportedSpan)
+		if reported.SpanType() != v3.SpanType_Entry {
+			t.Errorf("expected span type %v but got %v", v3.SpanType_Entry,
+				reported.SpanType())
+		}
+		if entry.Context().TraceID() != "manual-trace-id" {
+			t.Errorf("expected the manual trace id but got %s",
+				entry.Context().TraceID())
+		}
+	})
 }
 
 func TestSkyWalkingTracer_Shutdown(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	tracer, err := startTestingTracer()
 	if err != nil {
@@ -98,7 +240,7 @@ func TestSkyWalkingTracer_Shutdown(t *testing.T) {
 }
 
 func TestSkyWalkingTracer_SpanFromContext(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	t.Run("EmptyContext", func(t *testing.T) {
 		tracer, _ := startTestingTracer()
@@ -143,7 +285,7 @@ func TestSkyWalkingTracer_SpanFromContext(t *testing.T) {
 ////////////// Testing Tracer's PRIVATE METHODS //////////////////
 
 func TestGenerateSkyWalkingOptions(t *testing.T) {
-	defer recoverPanic(t)
+	defer recoverPanic(t)()
 
 	tracer := &Tracer{}
 
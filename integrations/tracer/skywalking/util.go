@@ -40,13 +40,16 @@ func mapSpanLayer(option tengcoruxTracer.SpanLayer) v3.SpanLayer {
 		return v3.SpanLayer_Http
 	case tengcoruxTracer.SpanLayerMQ:
 		return v3.SpanLayer_MQ
+	case tengcoruxTracer.SpanLayerRPC:
+		return v3.SpanLayer_RPCFramework
 	default:
 		return v3.SpanLayer_Unknown
 	}
 }
 
-// mapComponentLibrary maps a go2sky span layer to a component library.
-// TODO: Make it extensible....
+// mapComponentLibrary maps a go2sky span layer to its default component
+// library. The "db.system" and "mq.system" attributes of the span override
+// it with the component library registered by RegisterComponentLibrary.
 func mapComponentLibrary(option tengcoruxTracer.SpanLayer) ComponentLibrary {
 	switch option {
 	case tengcoruxTracer.SpanLayerUnknown:
@@ -55,6 +58,8 @@ func mapComponentLibrary(option tengcoruxTracer.SpanLayer) ComponentLibrary {
 		return GoKafka
 	case tengcoruxTracer.SpanLayerHttp:
 		return GoHttpServer
+	case tengcoruxTracer.SpanLayerRPC:
+		return GRPC
 	case tengcoruxTracer.SpanLayerDatabase:
 		return GoMysql
 	default:
@@ -73,4 +73,24 @@ func TestMapSpanLayer(t *testing.T) {
 	if go2skySpanLayer := mapSpanLayer(tengcoruxTracer.SpanLayerMQ); go2skySpanLayer != v3.SpanLayer_MQ {
 		t.Errorf("expects %v but got %v", v3.SpanLayer_MQ, go2skySpanLayer)
 	}
+
+	if go2skySpanLayer := mapSpanLayer(tengcoruxTracer.SpanLayerRPC); go2skySpanLayer != v3.SpanLayer_RPCFramework {
+		t.Errorf("expects %v but got %v", v3.SpanLayer_RPCFramework, go2skySpanLayer)
+	}
+}
+
+func TestMapComponentLibrary(t *testing.T) {
+	tests := map[tengcoruxTracer.SpanLayer]ComponentLibrary{
+		tengcoruxTracer.SpanLayerUnknown:  Unknown,
+		tengcoruxTracer.SpanLayerDatabase: GoMysql,
+		tengcoruxTracer.SpanLayerHttp:     GoHttpServer,
+		tengcoruxTracer.SpanLayerMQ:       GoKafka,
+		tengcoruxTracer.SpanLayerRPC:      GRPC,
+	}
+
+	for layer, want := range tests {
+		if got := mapComponentLibrary(layer); got != want {
+			t.Errorf("expects %v but got %v", want, got)
+		}
+	}
 }
@@ -0,0 +1,41 @@
+package metric
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	mu          sync.RWMutex
+	globalMeter Meter = &NoopMeter{}
+)
+
+// shutdownTimeout bounds the shutdown of a meter replaced by SetGlobalMeter.
+const shutdownTimeout = 5 * time.Second
+
+// SetGlobalMeter replaces the current meter to the provided. The previous
+// meter is shut down afterwards, flushing what it has recorded within
+// shutdownTimeout. Its shutdown error is ignored, since the previous meter is
+// no longer reachable once replaced.
+func SetGlobalMeter(meter Meter) {
+	mu.Lock()
+	previous := globalMeter
+	globalMeter = meter
+	mu.Unlock()
+
+	if previous == meter {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	_ = previous.Shutdown(ctx)
+}
+
+// GetGlobalMeter retrieves the globalMeter.
+func GetGlobalMeter() Meter {
+	mu.RLock()
+	defer mu.RUnlock()
+	return globalMeter
+}
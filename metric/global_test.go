@@ -0,0 +1,84 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGlobalMeter(t *testing.T) {
+	SetGlobalMeter(&NoopMeter{})
+
+	_, ok := GetGlobalMeter().(*NoopMeter)
+	if !ok {
+		t.Error("meter is not of type *NoopMeter")
+	}
+}
+
+type errorMeter struct {
+	*NoopMeter
+}
+
+func (*errorMeter) Shutdown(_ context.Context) error {
+	return errors.New("always error")
+}
+
+func TestSetGlobalMeter(t *testing.T) {
+	t.Run("Previously Shutdown Successful", func(t *testing.T) {
+		SetGlobalMeter(new(NoopMeter))
+		_, ok := globalMeter.(*NoopMeter)
+		if !ok {
+			t.Error("global meter is not of type *NoopMeter")
+		}
+	})
+	t.Run("Previous Shutdown Return Error", func(t *testing.T) {
+		defer func() {
+			globalMeter = new(NoopMeter)
+		}()
+
+		globalMeter = &errorMeter{}
+		SetGlobalMeter(new(NoopMeter))
+		_, ok := globalMeter.(*NoopMeter)
+		if !ok {
+			t.Error("global meter should be replaced regardless of the error")
+		}
+	})
+	t.Run("Previous Shutdown", func(t *testing.T) {
+		defer func() {
+			globalMeter = new(NoopMeter)
+		}()
+
+		previous := &shutdownMeter{NoopMeter: new(NoopMeter)}
+		globalMeter = previous
+		SetGlobalMeter(new(NoopMeter))
+		if previous.deadline.IsZero() {
+			t.Fatal("previous meter should be shut down")
+		} else if time.Until(previous.deadline) < time.Second {
+			t.Errorf("expected a realistic shutdown timeout, got %s",
+				time.Until(previous.deadline))
+		}
+	})
+	t.Run("Same Meter", func(t *testing.T) {
+		defer func() {
+			globalMeter = new(NoopMeter)
+		}()
+
+		meter := &shutdownMeter{NoopMeter: new(NoopMeter)}
+		globalMeter = meter
+		SetGlobalMeter(meter)
+		if !meter.deadline.IsZero() {
+			t.Error("meter set again should not be shut down")
+		}
+	})
+}
+
+type shutdownMeter struct {
+	*NoopMeter
+	deadline time.Time
+}
+
+func (m *shutdownMeter) Shutdown(ctx context.Context) error {
+	m.deadline, _ = ctx.Deadline()
+	return nil
+}
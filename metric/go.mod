@@ -0,0 +1,7 @@
+module github.com/rmscoal/tengcorux/metric
+
+go 1.21
+
+require github.com/rmscoal/tengcorux/tracer v0.1.5-0.20261017175312-3ec99204e181
+
+replace github.com/rmscoal/tengcorux/tracer => ../tracer
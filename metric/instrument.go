@@ -0,0 +1,59 @@
+package metric
+
+import (
+	"context"
+)
+
+// Int64Counter records increasing int64 values, such as the number of
+// served requests.
+type Int64Counter interface {
+	// Add records the increment, which must not be negative.
+	Add(ctx context.Context, incr int64, opts ...RecordOption)
+}
+
+// Float64Counter records increasing float64 values.
+type Float64Counter interface {
+	// Add records the increment, which must not be negative.
+	Add(ctx context.Context, incr float64, opts ...RecordOption)
+}
+
+// Int64UpDownCounter records int64 values that may increase or decrease,
+// such as the number of in-flight requests.
+type Int64UpDownCounter interface {
+	// Add records the increment or decrement.
+	Add(ctx context.Context, incr int64, opts ...RecordOption)
+}
+
+// Float64UpDownCounter records float64 values that may increase or
+// decrease.
+type Float64UpDownCounter interface {
+	// Add records the increment or decrement.
+	Add(ctx context.Context, incr float64, opts ...RecordOption)
+}
+
+// Int64Histogram records the distribution of int64 values, such as the
+// size of responses.
+type Int64Histogram interface {
+	// Record records the value.
+	Record(ctx context.Context, value int64, opts ...RecordOption)
+}
+
+// Float64Histogram records the distribution of float64 values, such as
+// the duration of requests.
+type Float64Histogram interface {
+	// Record records the value.
+	Record(ctx context.Context, value float64, opts ...RecordOption)
+}
+
+// Int64Gauge records the current int64 value, such as the number of idle
+// connections of a pool.
+type Int64Gauge interface {
+	// Record records the value, replacing the previous one.
+	Record(ctx context.Context, value int64, opts ...RecordOption)
+}
+
+// Float64Gauge records the current float64 value.
+type Float64Gauge interface {
+	// Record records the value, replacing the previous one.
+	Record(ctx context.Context, value float64, opts ...RecordOption)
+}
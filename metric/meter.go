@@ -0,0 +1,63 @@
+// Package metric provides a backend agnostic API to record measurements,
+// alongside the tracer package. Instrumentations create their instruments
+// from a [Meter], by default the global one, and record measurements
+// described by the attributes of the tracer/attribute package. For example:
+//
+//	requests, _ := metric.GetGlobalMeter().Int64Counter("http.server.requests",
+//		metric.WithDescription("Number of served requests."))
+//	requests.Add(ctx, 1, metric.WithAttributes(attribute.HTTPRequestMethod("GET")))
+//
+// The global meter is a [NoopMeter] until a backend, such as the
+// OpenTelemetry integration, is set through SetGlobalMeter.
+package metric
+
+import (
+	"context"
+)
+
+// Meter creates the instruments recording measurements, and exports them to
+// the underlying backend.
+type Meter interface {
+	// Int64Counter creates an instrument recording increasing int64 values.
+	Int64Counter(name string, opts ...InstrumentOption) (Int64Counter, error)
+
+	// Float64Counter creates an instrument recording increasing float64
+	// values.
+	Float64Counter(name string, opts ...InstrumentOption) (Float64Counter, error)
+
+	// Int64UpDownCounter creates an instrument recording int64 values that
+	// may increase or decrease.
+	Int64UpDownCounter(name string, opts ...InstrumentOption) (Int64UpDownCounter, error)
+
+	// Float64UpDownCounter creates an instrument recording float64 values
+	// that may increase or decrease.
+	Float64UpDownCounter(name string, opts ...InstrumentOption) (Float64UpDownCounter, error)
+
+	// Int64Histogram creates an instrument recording the distribution of
+	// int64 values.
+	Int64Histogram(name string, opts ...InstrumentOption) (Int64Histogram, error)
+
+	// Float64Histogram creates an instrument recording the distribution of
+	// float64 values.
+	Float64Histogram(name string, opts ...InstrumentOption) (Float64Histogram, error)
+
+	// Int64Gauge creates an instrument recording the current int64 value.
+	Int64Gauge(name string, opts ...InstrumentOption) (Int64Gauge, error)
+
+	// Float64Gauge creates an instrument recording the current float64
+	// value.
+	Float64Gauge(name string, opts ...InstrumentOption) (Float64Gauge, error)
+
+	// Shutdown flushes the recorded measurements and stops the meter.
+	Shutdown(ctx context.Context) error
+}
+
+// Shutdown shuts the global meter down.
+func Shutdown(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return GetGlobalMeter().Shutdown(ctx)
+	}
+}
@@ -0,0 +1,34 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestShutdown(t *testing.T) {
+	t.Run("Global Meter Shutdown", func(t *testing.T) {
+		if err := Shutdown(context.Background()); err != nil {
+			t.Errorf("expected nil error but got %v", err)
+		}
+	})
+	t.Run("Global Meter Returns Error", func(t *testing.T) {
+		defer func() {
+			globalMeter = new(NoopMeter)
+		}()
+
+		globalMeter = &errorMeter{}
+		if err := Shutdown(context.Background()); err == nil {
+			t.Error("expected error but got nil")
+		}
+	})
+	t.Run("Context Done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Shutdown(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled but got %v", err)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+// Package metrictest provides utilities and helpers for unit testing your
+// package that requires the need of tracking and validating the measurements
+// recorded by your package.
+//
+// The metrictest package simplifies the following features:
+//   - Records measurements without requiring a remote backend to export them.
+//   - Reads the recorded measurements back, filtered by attributes.
+//
+// Furthermore, this package implements tengcorux's metric package. Hence, it
+// may be given wherever a metric.Meter is expected.
+//
+// Example usages:
+//
+//	func TestMyFunction(t *testing.T) {
+//		meter := metrictest.NewMeter()
+//		counter, _ := meter.Int64Counter("requests")
+//		counter.Add(context.Background(), 1,
+//			metric.WithAttributes(attribute.String("method", "GET")))
+//
+//		total := meter.Sum("requests", attribute.String("method", "GET"))
+//		_ = total // do something with it.
+//	}
+package metrictest
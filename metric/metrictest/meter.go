@@ -0,0 +1,271 @@
+package metrictest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rmscoal/tengcorux/metric"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// InstrumentKind is the kind of an instrument.
+type InstrumentKind int
+
+const (
+	KindCounter InstrumentKind = iota + 1
+	KindUpDownCounter
+	KindHistogram
+	KindGauge
+)
+
+// String returns the name of the kind.
+func (k InstrumentKind) String() string {
+	switch k {
+	case KindCounter:
+		return "Counter"
+	case KindUpDownCounter:
+		return "UpDownCounter"
+	case KindHistogram:
+		return "Histogram"
+	case KindGauge:
+		return "Gauge"
+	default:
+		return "Unknown"
+	}
+}
+
+// Instrument describes an instrument created by the Meter.
+type Instrument struct {
+	Name        string
+	Kind        InstrumentKind
+	Description string
+	Unit        string
+	Buckets     []float64
+}
+
+// Measurement is a value recorded by an instrument. Integer values are
+// converted to float64.
+type Measurement struct {
+	Name       string
+	Value      float64
+	Attributes []attribute.KeyValue
+	Time       time.Time
+}
+
+// Meter is an in-memory [metric.Meter] recording every measurement.
+type Meter struct {
+	mu           sync.RWMutex
+	instruments  map[string]Instrument
+	measurements []Measurement
+}
+
+// Make sure that Meter implements [metric.Meter] during compile time.
+var _ metric.Meter = (*Meter)(nil)
+
+// NewMeter returns an empty Meter.
+func NewMeter() *Meter {
+	return &Meter{instruments: make(map[string]Instrument)}
+}
+
+func (m *Meter) register(name string, kind InstrumentKind, opts []metric.InstrumentOption) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.instruments[name]; ok && existing.Kind != kind {
+		return fmt.Errorf("metrictest: instrument %q already registered as a %s",
+			name, existing.Kind)
+	}
+
+	cfg := metric.NewInstrumentConfig(opts...)
+	m.instruments[name] = Instrument{
+		Name:        name,
+		Kind:        kind,
+		Description: cfg.Description,
+		Unit:        cfg.Unit,
+		Buckets:     cfg.Buckets,
+	}
+	return nil
+}
+
+func (m *Meter) record(name string, value float64, opts []metric.RecordOption) {
+	cfg := metric.NewRecordConfig(opts...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.measurements = append(m.measurements, Measurement{
+		Name:       name,
+		Value:      value,
+		Attributes: cfg.Attributes,
+		Time:       time.Now(),
+	})
+}
+
+// Int64Counter creates a counter recording into the meter.
+func (m *Meter) Int64Counter(name string, opts ...metric.InstrumentOption) (metric.Int64Counter, error) {
+	if err := m.register(name, KindCounter, opts); err != nil {
+		return nil, err
+	}
+	return &int64Instrument{meter: m, name: name}, nil
+}
+
+// Float64Counter creates a counter recording into the meter.
+func (m *Meter) Float64Counter(name string, opts ...metric.InstrumentOption) (metric.Float64Counter, error) {
+	if err := m.register(name, KindCounter, opts); err != nil {
+		return nil, err
+	}
+	return &float64Instrument{meter: m, name: name}, nil
+}
+
+// Int64UpDownCounter creates an up-down counter recording into the meter.
+func (m *Meter) Int64UpDownCounter(name string, opts ...metric.InstrumentOption) (metric.Int64UpDownCounter, error) {
+	if err := m.register(name, KindUpDownCounter, opts); err != nil {
+		return nil, err
+	}
+	return &int64Instrument{meter: m, name: name}, nil
+}
+
+// Float64UpDownCounter creates an up-down counter recording into the meter.
+func (m *Meter) Float64UpDownCounter(name string, opts ...metric.InstrumentOption) (metric.Float64UpDownCounter, error) {
+	if err := m.register(name, KindUpDownCounter, opts); err != nil {
+		return nil, err
+	}
+	return &float64Instrument{meter: m, name: name}, nil
+}
+
+// Int64Histogram creates a histogram recording into the meter.
+func (m *Meter) Int64Histogram(name string, opts ...metric.InstrumentOption) (metric.Int64Histogram, error) {
+	if err := m.register(name, KindHistogram, opts); err != nil {
+		return nil, err
+	}
+	return &int64Instrument{meter: m, name: name}, nil
+}
+
+// Float64Histogram creates a histogram recording into the meter.
+func (m *Meter) Float64Histogram(name string, opts ...metric.InstrumentOption) (metric.Float64Histogram, error) {
+	if err := m.register(name, KindHistogram, opts); err != nil {
+		return nil, err
+	}
+	return &float64Instrument{meter: m, name: name}, nil
+}
+
+// Int64Gauge creates a gauge recording into the meter.
+func (m *Meter) Int64Gauge(name string, opts ...metric.InstrumentOption) (metric.Int64Gauge, error) {
+	if err := m.register(name, KindGauge, opts); err != nil {
+		return nil, err
+	}
+	return &int64Instrument{meter: m, name: name}, nil
+}
+
+// Float64Gauge creates a gauge recording into the meter.
+func (m *Meter) Float64Gauge(name string, opts ...metric.InstrumentOption) (metric.Float64Gauge, error) {
+	if err := m.register(name, KindGauge, opts); err != nil {
+		return nil, err
+	}
+	return &float64Instrument{meter: m, name: name}, nil
+}
+
+// Shutdown does nothing, the measurements remain readable.
+func (m *Meter) Shutdown(_ context.Context) error {
+	return nil
+}
+
+// Instrument returns the instrument of the given name, if created.
+func (m *Meter) Instrument(name string) (Instrument, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	instrument, ok := m.instruments[name]
+	return instrument, ok
+}
+
+// Measurements returns a copy of the measurements of the instrument holding
+// every given attribute.
+func (m *Meter) Measurements(name string, attrs ...attribute.KeyValue) []Measurement {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var measurements []Measurement
+	for _, measurement := range m.measurements {
+		if measurement.Name == name && hasAttributes(measurement.Attributes, attrs) {
+			measurements = append(measurements, measurement)
+		}
+	}
+	return measurements
+}
+
+// Sum returns the sum of the measurements of the instrument holding every
+// given attribute, the value of a counter.
+func (m *Meter) Sum(name string, attrs ...attribute.KeyValue) float64 {
+	var sum float64
+	for _, measurement := range m.Measurements(name, attrs...) {
+		sum += measurement.Value
+	}
+	return sum
+}
+
+// Count returns the number of measurements of the instrument holding every
+// given attribute, the count of a histogram.
+func (m *Meter) Count(name string, attrs ...attribute.KeyValue) int {
+	return len(m.Measurements(name, attrs...))
+}
+
+// Last returns the last measurement of the instrument holding every given
+// attribute, the value of a gauge.
+func (m *Meter) Last(name string, attrs ...attribute.KeyValue) (float64, bool) {
+	measurements := m.Measurements(name, attrs...)
+	if len(measurements) == 0 {
+		return 0, false
+	}
+	return measurements[len(measurements)-1].Value, true
+}
+
+// Reset removes the recorded measurements.
+func (m *Meter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.measurements = nil
+}
+
+// hasAttributes reports whether every wanted attribute is within attrs.
+func hasAttributes(attrs, wanted []attribute.KeyValue) bool {
+	for _, want := range wanted {
+		found := false
+		for _, attr := range attrs {
+			if attr.Key == want.Key && attr.Value == want.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+type int64Instrument struct {
+	meter *Meter
+	name  string
+}
+
+func (i *int64Instrument) Add(_ context.Context, incr int64, opts ...metric.RecordOption) {
+	i.meter.record(i.name, float64(incr), opts)
+}
+
+func (i *int64Instrument) Record(_ context.Context, value int64, opts ...metric.RecordOption) {
+	i.meter.record(i.name, float64(value), opts)
+}
+
+type float64Instrument struct {
+	meter *Meter
+	name  string
+}
+
+func (i *float64Instrument) Add(_ context.Context, incr float64, opts ...metric.RecordOption) {
+	i.meter.record(i.name, incr, opts)
+}
+
+func (i *float64Instrument) Record(_ context.Context, value float64, opts ...metric.RecordOption) {
+	i.meter.record(i.name, value, opts)
+}
@@ -0,0 +1,134 @@
+package metrictest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rmscoal/tengcorux/metric"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+func TestMeter_Instruments(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Counter", func(t *testing.T) {
+		meter := NewMeter()
+		counter, err := meter.Int64Counter("requests",
+			metric.WithDescription("Number of requests."))
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		counter.Add(ctx, 1, metric.WithAttributes(attribute.String("method", "GET")))
+		counter.Add(ctx, 2, metric.WithAttributes(attribute.String("method", "POST")))
+
+		if sum := meter.Sum("requests"); sum != 3 {
+			t.Errorf("expected sum 3 but got %v", sum)
+		}
+		if sum := meter.Sum("requests", attribute.String("method", "GET")); sum != 1 {
+			t.Errorf("expected sum 1 but got %v", sum)
+		}
+
+		instrument, ok := meter.Instrument("requests")
+		if !ok {
+			t.Fatal("expected instrument to be registered")
+		}
+		if instrument.Kind != KindCounter {
+			t.Errorf("expected %s but got %s", KindCounter, instrument.Kind)
+		}
+		if instrument.Description != "Number of requests." {
+			t.Errorf("expected description but got %q", instrument.Description)
+		}
+	})
+	t.Run("UpDownCounter", func(t *testing.T) {
+		meter := NewMeter()
+		counter, err := meter.Float64UpDownCounter("active")
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		counter.Add(ctx, 2.5)
+		counter.Add(ctx, -1)
+
+		if sum := meter.Sum("active"); sum != 1.5 {
+			t.Errorf("expected sum 1.5 but got %v", sum)
+		}
+	})
+	t.Run("Histogram", func(t *testing.T) {
+		meter := NewMeter()
+		histogram, err := meter.Float64Histogram("duration",
+			metric.WithUnit("s"), metric.WithBuckets(0.1, 1))
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		histogram.Record(ctx, 0.2)
+		histogram.Record(ctx, 0.4)
+
+		if count := meter.Count("duration"); count != 2 {
+			t.Errorf("expected count 2 but got %d", count)
+		}
+		instrument, _ := meter.Instrument("duration")
+		if instrument.Unit != "s" || len(instrument.Buckets) != 2 {
+			t.Errorf("unexpected instrument %+v", instrument)
+		}
+	})
+	t.Run("Gauge", func(t *testing.T) {
+		meter := NewMeter()
+		gauge, err := meter.Int64Gauge("connections")
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+
+		if _, ok := meter.Last("connections"); ok {
+			t.Error("expected no measurement")
+		}
+		gauge.Record(ctx, 4)
+		gauge.Record(ctx, 2)
+
+		if last, ok := meter.Last("connections"); !ok || last != 2 {
+			t.Errorf("expected last 2 but got %v", last)
+		}
+	})
+	t.Run("Conflicting Kind", func(t *testing.T) {
+		meter := NewMeter()
+		if _, err := meter.Int64Counter("requests"); err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		if _, err := meter.Float64Counter("requests"); err != nil {
+			t.Errorf("expected nil error but got %v", err)
+		}
+		if _, err := meter.Int64Histogram("requests"); err == nil {
+			t.Error("expected error but got nil")
+		}
+	})
+}
+
+func TestMeter_Reset(t *testing.T) {
+	meter := NewMeter()
+	counter, _ := meter.Int64Counter("requests")
+	counter.Add(context.Background(), 1)
+
+	meter.Reset()
+	if count := meter.Count("requests"); count != 0 {
+		t.Errorf("expected no measurements but got %d", count)
+	}
+	if _, ok := meter.Instrument("requests"); !ok {
+		t.Error("expected instrument to remain registered")
+	}
+	if err := meter.Shutdown(context.Background()); err != nil {
+		t.Errorf("expected nil error but got %v", err)
+	}
+}
+
+func TestInstrumentKind_String(t *testing.T) {
+	kinds := map[InstrumentKind]string{
+		KindCounter:        "Counter",
+		KindUpDownCounter:  "UpDownCounter",
+		KindHistogram:      "Histogram",
+		KindGauge:          "Gauge",
+		InstrumentKind(99): "Unknown",
+	}
+	for kind, want := range kinds {
+		if got := kind.String(); got != want {
+			t.Errorf("expected %s but got %s", want, got)
+		}
+	}
+}
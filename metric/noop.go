@@ -0,0 +1,93 @@
+package metric
+
+import (
+	"context"
+)
+
+// NoopMeter a no operation meter that implements [Meter].
+type NoopMeter struct{}
+
+// Make sure that NoopMeter implements [Meter] during compile time.
+var _ Meter = (*NoopMeter)(nil)
+
+// Int64Counter returns a [NoopInt64Instrument].
+func (m *NoopMeter) Int64Counter(string, ...InstrumentOption) (Int64Counter, error) {
+	return NoopInt64Instrument{}, nil
+}
+
+// Float64Counter returns a [NoopFloat64Instrument].
+func (m *NoopMeter) Float64Counter(string, ...InstrumentOption) (Float64Counter, error) {
+	return NoopFloat64Instrument{}, nil
+}
+
+// Int64UpDownCounter returns a [NoopInt64Instrument].
+func (m *NoopMeter) Int64UpDownCounter(string, ...InstrumentOption) (Int64UpDownCounter, error) {
+	return NoopInt64Instrument{}, nil
+}
+
+// Float64UpDownCounter returns a [NoopFloat64Instrument].
+func (m *NoopMeter) Float64UpDownCounter(string, ...InstrumentOption) (Float64UpDownCounter, error) {
+	return NoopFloat64Instrument{}, nil
+}
+
+// Int64Histogram returns a [NoopInt64Instrument].
+func (m *NoopMeter) Int64Histogram(string, ...InstrumentOption) (Int64Histogram, error) {
+	return NoopInt64Instrument{}, nil
+}
+
+// Float64Histogram returns a [NoopFloat64Instrument].
+func (m *NoopMeter) Float64Histogram(string, ...InstrumentOption) (Float64Histogram, error) {
+	return NoopFloat64Instrument{}, nil
+}
+
+// Int64Gauge returns a [NoopInt64Instrument].
+func (m *NoopMeter) Int64Gauge(string, ...InstrumentOption) (Int64Gauge, error) {
+	return NoopInt64Instrument{}, nil
+}
+
+// Float64Gauge returns a [NoopFloat64Instrument].
+func (m *NoopMeter) Float64Gauge(string, ...InstrumentOption) (Float64Gauge, error) {
+	return NoopFloat64Instrument{}, nil
+}
+
+func (m *NoopMeter) Shutdown(_ context.Context) error {
+	return nil
+}
+
+// NoopInt64Instrument a no operation instrument that implements every int64
+// instrument.
+type NoopInt64Instrument struct{}
+
+// Make sure that NoopInt64Instrument implements the int64 instruments during
+// compile time.
+var (
+	_ Int64Counter       = NoopInt64Instrument{}
+	_ Int64UpDownCounter = NoopInt64Instrument{}
+	_ Int64Histogram     = NoopInt64Instrument{}
+	_ Int64Gauge         = NoopInt64Instrument{}
+)
+
+// Add does nothing.
+func (NoopInt64Instrument) Add(context.Context, int64, ...RecordOption) {}
+
+// Record does nothing.
+func (NoopInt64Instrument) Record(context.Context, int64, ...RecordOption) {}
+
+// NoopFloat64Instrument a no operation instrument that implements every
+// float64 instrument.
+type NoopFloat64Instrument struct{}
+
+// Make sure that NoopFloat64Instrument implements the float64 instruments
+// during compile time.
+var (
+	_ Float64Counter       = NoopFloat64Instrument{}
+	_ Float64UpDownCounter = NoopFloat64Instrument{}
+	_ Float64Histogram     = NoopFloat64Instrument{}
+	_ Float64Gauge         = NoopFloat64Instrument{}
+)
+
+// Add does nothing.
+func (NoopFloat64Instrument) Add(context.Context, float64, ...RecordOption) {}
+
+// Record does nothing.
+func (NoopFloat64Instrument) Record(context.Context, float64, ...RecordOption) {}
@@ -0,0 +1,71 @@
+package metric
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNoopMeter(t *testing.T) {
+	noop := &NoopMeter{}
+	ctx := context.Background()
+
+	t.Run("Int64 Instruments", func(t *testing.T) {
+		counter, err := noop.Int64Counter("counter")
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		counter.Add(ctx, 1)
+
+		upDownCounter, err := noop.Int64UpDownCounter("up_down_counter")
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		upDownCounter.Add(ctx, -1)
+
+		histogram, err := noop.Int64Histogram("histogram")
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		histogram.Record(ctx, 1)
+
+		gauge, err := noop.Int64Gauge("gauge")
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		gauge.Record(ctx, 1)
+	})
+	t.Run("Float64 Instruments", func(t *testing.T) {
+		counter, err := noop.Float64Counter("counter")
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		counter.Add(ctx, 1.5)
+
+		upDownCounter, err := noop.Float64UpDownCounter("up_down_counter")
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		upDownCounter.Add(ctx, -1.5)
+
+		histogram, err := noop.Float64Histogram("histogram")
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		histogram.Record(ctx, 1.5)
+
+		gauge, err := noop.Float64Gauge("gauge")
+		if err != nil {
+			t.Fatalf("expected nil error but got %v", err)
+		}
+		gauge.Record(ctx, 1.5)
+	})
+}
+
+func TestNoopMeter_Shutdown(t *testing.T) {
+	noop := &NoopMeter{}
+
+	err := noop.Shutdown(context.Background())
+	if err != nil {
+		t.Error("error should be nil")
+	}
+}
@@ -0,0 +1,82 @@
+package metric
+
+import (
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// InstrumentConfig holds the configuration of an instrument.
+type InstrumentConfig struct {
+	// Description explains what the instrument measures.
+	Description string
+
+	// Unit is the unit of the recorded values, following UCUM such as "s"
+	// or "By".
+	Unit string
+
+	// Buckets are the explicit bucket boundaries of a histogram, leaving
+	// them to the backend when empty. Ignored by the other instruments.
+	Buckets []float64
+}
+
+// InstrumentOption provides options to configure an instrument when
+// creating it.
+type InstrumentOption func(*InstrumentConfig)
+
+// WithDescription sets the description of the instrument.
+func WithDescription(description string) InstrumentOption {
+	return func(cfg *InstrumentConfig) {
+		cfg.Description = description
+	}
+}
+
+// WithUnit sets the unit of the instrument.
+func WithUnit(unit string) InstrumentOption {
+	return func(cfg *InstrumentConfig) {
+		cfg.Unit = unit
+	}
+}
+
+// WithBuckets sets the explicit bucket boundaries of a histogram.
+func WithBuckets(boundaries ...float64) InstrumentOption {
+	return func(cfg *InstrumentConfig) {
+		cfg.Buckets = boundaries
+	}
+}
+
+// NewInstrumentConfig applies the options and returns the resulting
+// InstrumentConfig.
+func NewInstrumentConfig(opts ...InstrumentOption) *InstrumentConfig {
+	cfg := &InstrumentConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// RecordConfig holds the configuration of a recorded measurement.
+type RecordConfig struct {
+	// Attributes describes the measurement. Measurements with the same set
+	// of attributes are aggregated together.
+	Attributes []attribute.KeyValue
+}
+
+// RecordOption provides options to configure a measurement when recording
+// it.
+type RecordOption func(*RecordConfig)
+
+// WithAttributes appends the attributes describing the measurement.
+func WithAttributes(attributes ...attribute.KeyValue) RecordOption {
+	return func(cfg *RecordConfig) {
+		cfg.Attributes = append(cfg.Attributes, attributes...)
+	}
+}
+
+// NewRecordConfig applies the options and returns the resulting
+// RecordConfig.
+func NewRecordConfig(opts ...RecordOption) *RecordConfig {
+	cfg := &RecordConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
@@ -0,0 +1,53 @@
+package metric
+
+import (
+	"testing"
+
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+func TestNewInstrumentConfig(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := NewInstrumentConfig()
+		if cfg.Description != "" || cfg.Unit != "" || cfg.Buckets != nil {
+			t.Errorf("expected empty config but got %+v", cfg)
+		}
+	})
+	t.Run("With Options", func(t *testing.T) {
+		cfg := NewInstrumentConfig(
+			WithDescription("Duration of requests."),
+			WithUnit("s"),
+			WithBuckets(0.1, 0.5, 1),
+		)
+		if cfg.Description != "Duration of requests." {
+			t.Errorf("expected description but got %q", cfg.Description)
+		}
+		if cfg.Unit != "s" {
+			t.Errorf("expected unit s but got %q", cfg.Unit)
+		}
+		if len(cfg.Buckets) != 3 || cfg.Buckets[2] != 1 {
+			t.Errorf("expected 3 buckets but got %v", cfg.Buckets)
+		}
+	})
+}
+
+func TestNewRecordConfig(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := NewRecordConfig()
+		if len(cfg.Attributes) != 0 {
+			t.Errorf("expected no attributes but got %v", cfg.Attributes)
+		}
+	})
+	t.Run("Appends Attributes", func(t *testing.T) {
+		cfg := NewRecordConfig(
+			WithAttributes(attribute.String("a", "1")),
+			WithAttributes(attribute.String("b", "2"), attribute.Int("c", 3)),
+		)
+		if len(cfg.Attributes) != 3 {
+			t.Fatalf("expected 3 attributes but got %d", len(cfg.Attributes))
+		}
+		if cfg.Attributes[2].Key != "c" {
+			t.Errorf("expected key c but got %s", cfg.Attributes[2].Key)
+		}
+	})
+}
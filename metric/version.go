@@ -0,0 +1,5 @@
+package metric
+
+func Version() string {
+	return "v0.1.0"
+}
@@ -0,0 +1,12 @@
+module github.com/rmscoal/tengcorux/nethttp/middleware/tracing
+
+go 1.23
+
+require (
+	github.com/rmscoal/tengcorux/reqid v0.1.0
+	github.com/rmscoal/tengcorux/tracer v0.1.5-0.20261017175312-3ec99204e181
+)
+
+require github.com/google/uuid v1.6.0 // indirect
+
+replace github.com/rmscoal/tengcorux/tracer => ../../../tracer
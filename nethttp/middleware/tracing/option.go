@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/rmscoal/tengcorux/tracer"
+)
+
+type Option func(*middleware)
+
+// WithTracer uses the given tracer instead of the global tracer.
+func WithTracer(t tracer.Tracer) Option {
+	return func(m *middleware) {
+		m.tracer = t
+	}
+}
+
+// WithRouteResolver replaces the default route resolver, which returns the
+// path of the http.ServeMux pattern matched by the request. The resolver is called once the wrapped handler is served,
+// such that routers may have filled the matched pattern into the request.
+// An empty route is not recorded.
+func WithRouteResolver(resolver RouteResolver) Option {
+	return func(m *middleware) {
+		if resolver != nil {
+			m.routeResolver = resolver
+		}
+	}
+}
+
+// WithSpanNameGenerator replaces the default span name generator, which
+// returns "HTTP <method> <route>" when the http.ServeMux pattern is known
+// upon starting the span, and "HTTP <method>" otherwise. The raw path is
+// left out to keep the span names low in cardinality.
+func WithSpanNameGenerator(gen SpanNameGenerator) Option {
+	return func(m *middleware) {
+		if gen != nil {
+			m.spanNameGenerator = gen
+		}
+	}
+}
+
+// WithFilter skips tracing for requests for which the filter returns false,
+// for example health checks.
+func WithFilter(filter func(r *http.Request) bool) Option {
+	return func(m *middleware) {
+		m.filter = filter
+	}
+}
@@ -0,0 +1,207 @@
+package tracing
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// RouteResolver returns the route of a served request, usually the pattern
+// matched by the router such as "/users/{id}".
+type RouteResolver func(r *http.Request) string
+
+// SpanNameGenerator returns the name of the entry span of a request.
+type SpanNameGenerator func(r *http.Request) string
+
+var (
+	_defaultRouteResolver = func(r *http.Request) string {
+		return routeFromPattern(r.Pattern)
+	}
+	_defaultSpanNameGenerator = func(r *http.Request) string {
+		if route := routeFromPattern(r.Pattern); route != "" {
+			return "HTTP " + r.Method + " " + route
+		}
+		return "HTTP " + r.Method
+	}
+)
+
+// routeFromPattern returns the path of an http.ServeMux pattern, leaving out
+// its method and host, e.g. "/users/{id}" of "GET example.com/users/{id}".
+func routeFromPattern(pattern string) string {
+	if i := strings.Index(pattern, "/"); i >= 0 {
+		return pattern[i:]
+	}
+	return ""
+}
+
+// middleware holds the configuration shared by the handlers it wraps.
+type middleware struct {
+	tracer            tracer.Tracer
+	routeResolver     RouteResolver
+	spanNameGenerator SpanNameGenerator
+	filter            func(r *http.Request) bool
+}
+
+// NewMiddleware returns a middleware wrapping handlers with NewHandler. For
+// example:
+//
+//	mux := http.NewServeMux()
+//	http.ListenAndServe(":8080", tracing.NewMiddleware()(mux))
+func NewMiddleware(opts ...Option) func(http.Handler) http.Handler {
+	m := &middleware{
+		routeResolver:     _defaultRouteResolver,
+		spanNameGenerator: _defaultSpanNameGenerator,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return &handler{middleware: m, next: next}
+	}
+}
+
+// NewHandler wraps the handler such that every request is served within an
+// entry span. The span continues the trace propagated by the upstream
+// headers, the request id is taken from the X-Request-Id header or
+// generated, injected into the request context and echoed on the response.
+func NewHandler(next http.Handler, opts ...Option) http.Handler {
+	return NewMiddleware(opts...)(next)
+}
+
+type handler struct {
+	*middleware
+	next http.Handler
+}
+
+// ServeHTTP starts the entry span, serves the request with the wrapped
+// handler and records the response into the span.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.filter != nil && !h.filter(r) {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	t := h.tracer
+	if t == nil {
+		t = tracer.GetGlobalTracer()
+	}
+
+	ctx := r.Context()
+	if propagator, ok := t.(tracer.Propagator); ok {
+		ctx = propagator.Extract(ctx, tracer.HeaderCarrier(r.Header))
+	}
+
+	if requestID := reqid.RetrieveFromHttpHeader(r.Header); requestID != "" {
+		ctx = reqid.InjectValue(ctx, requestID)
+	} else {
+		ctx = reqid.Inject(ctx)
+	}
+	requestID := reqid.RetrieveFromContext(ctx)
+	w.Header().Set(reqid.HeaderKey, requestID)
+
+	// http.ServeMux fills the matched pattern in once serving, hence it is
+	// looked up beforehand for the span to be named after the route.
+	if mux, ok := h.next.(*http.ServeMux); ok && r.Pattern == "" {
+		r = r.WithContext(r.Context())
+		_, r.Pattern = mux.Handler(r)
+	}
+
+	ctx, span := t.StartSpan(ctx, h.spanNameGenerator(r),
+		tracer.WithSpanType(tracer.SpanTypeEntry),
+		tracer.WithSpanLayer(tracer.SpanLayerHttp),
+	)
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.HTTPRequestID(requestID),
+		attribute.HTTPRequestMethod(r.Method),
+		attribute.HTTPUrlPath(r.URL.Path),
+	)
+	if r.URL.RawQuery != "" {
+		span.SetAttributes(attribute.HTTPUrlQuery(r.URL.RawQuery))
+	}
+
+	rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+	r = r.WithContext(ctx)
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err := fmt.Errorf("panic: %v", recovered)
+			span.RecordError(err)
+			span.SetStatus(tracer.StatusError, err.Error())
+			panic(recovered)
+		}
+	}()
+
+	h.next.ServeHTTP(rw, r)
+
+	if route := h.routeResolver(r); route != "" {
+		span.SetAttributes(attribute.HTTPRoute(route))
+	}
+	span.SetAttributes(
+		attribute.HTTPResponseStatus(rw.statusCode),
+		attribute.HTTPResponseBodySize(rw.written),
+	)
+	// Client errors are the caller's fault, only server errors mark the
+	// entry span as failed.
+	if rw.statusCode >= http.StatusInternalServerError {
+		span.SetStatus(tracer.StatusError, http.StatusText(rw.statusCode))
+	}
+}
+
+// responseWriter captures the status code and the number of bytes written
+// by the wrapped handler.
+type responseWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	written     int64
+	wroteHeader bool
+}
+
+// WriteHeader captures the status code before writing it.
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.statusCode = statusCode
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write counts the bytes written to the response body.
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.written += int64(n)
+	return n, err
+}
+
+// Flush flushes the underlying writer if it supports it.
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		flusher.Flush()
+	}
+}
+
+// Hijack hijacks the connection of the underlying writer if it supports it.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %T does not implement http.Hijacker",
+			http.ErrNotSupported, w.ResponseWriter)
+	}
+	w.wroteHeader = true
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
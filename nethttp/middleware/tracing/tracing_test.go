@@ -0,0 +1,283 @@
+package tracing
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"github.com/rmscoal/tengcorux/tracer/tracetest"
+)
+
+func TestNewHandler(t *testing.T) {
+	tr := tracetest.NewTracer()
+	tracer.SetGlobalTracer(tr)
+
+	var requestID string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		requestID = reqid.RetrieveFromContext(r.Context())
+		if tracer.SpanFromContext(r.Context()) == nil {
+			t.Error("expected the span to be in the request context")
+		}
+		_, _ = w.Write([]byte("hello"))
+	})
+	handler := NewHandler(mux)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1?expand=true", nil))
+
+	if requestID == "" {
+		t.Error("expected a request id to be generated")
+	} else if rec.Header().Get(reqid.HeaderKey) != requestID {
+		t.Errorf("expected X-Request-Id %s to be echoed, got %s", requestID,
+			rec.Header().Get(reqid.HeaderKey))
+	}
+
+	spans := tr.Recorder().EndedSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "HTTP GET /users/{id}" {
+		t.Errorf("unexpected span name %s", span.Name)
+	} else if span.Type != tracer.SpanTypeEntry {
+		t.Errorf("expected entry span, got %d", span.Type)
+	} else if span.Layer != tracer.SpanLayerHttp {
+		t.Errorf("expected http layer, got %d", span.Layer)
+	} else if span.Status != tracer.StatusUnset {
+		t.Errorf("expected unset status, got %s", span.Status)
+	}
+
+	tests := map[attribute.Key]attribute.Value{
+		attribute.HTTPRequestIDKey:        attribute.StringValue(requestID),
+		attribute.HTTPRequestMethodKey:    attribute.StringValue(http.MethodGet),
+		attribute.HTTPUrlPathKey:          attribute.StringValue("/users/1"),
+		attribute.HTTPUrlQueryKey:         attribute.StringValue("expand=true"),
+		attribute.HTTPRouteKey:            attribute.StringValue("/users/{id}"),
+		attribute.HTTPResponseStatusKey:   attribute.IntValue(http.StatusOK),
+		attribute.HTTPResponseBodySizeKey: attribute.Int64Value(5),
+	}
+	for key, want := range tests {
+		if got, _ := span.Attribute(key); got != want {
+			t.Errorf("%s: want %v, got %v", key, want, got)
+		}
+	}
+}
+
+func TestNewHandler_Route(t *testing.T) {
+	t.Run("Unknown Route", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+			WithTracer(tr))
+		handler.ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Name != "HTTP GET" {
+			t.Errorf("expected the span to be named after the method only, got %s",
+				span.Name)
+		}
+		if got, ok := span.Attribute(attribute.HTTPRouteKey); ok {
+			t.Errorf("expected no route to be recorded, got %v", got)
+		}
+	})
+
+	t.Run("Nested Router", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		mux := http.NewServeMux()
+		mux.Handle("example.com/orders/{id}", http.NotFoundHandler())
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mux.ServeHTTP(w, r)
+		})
+		NewHandler(handler, WithTracer(tr)).ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "http://example.com/orders/1", nil))
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Name != "HTTP GET" {
+			t.Errorf("expected the span to be named after the method only, got %s",
+				span.Name)
+		}
+		if got, _ := span.Attribute(attribute.HTTPRouteKey); got.AsString() != "/orders/{id}" {
+			t.Errorf("expected the pattern matched while serving, got %v", got)
+		}
+	})
+}
+
+func TestNewHandler_Propagation(t *testing.T) {
+	tr := tracetest.NewTracer()
+	handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reqid.RetrieveFromContext(r.Context()) != "upstream-request-id" {
+			t.Error("expected the upstream request id to be injected")
+		}
+	}), WithTracer(tr))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(tracetest.TraceIDKey, "42")
+	req.Header.Set(tracetest.SpanIDKey, "7")
+	req.Header.Set(reqid.HeaderKey, "upstream-request-id")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get(reqid.HeaderKey) != "upstream-request-id" {
+		t.Errorf("expected the request id to be echoed, got %s",
+			rec.Header().Get(reqid.HeaderKey))
+	}
+
+	span := tr.Recorder().EndedSpans()[0]
+	if strconv.FormatUint(span.TraceID, 10) != "42" || span.ParentSpanID != 7 {
+		t.Errorf("expected the upstream trace to be continued, got %d/%d",
+			span.TraceID, span.ParentSpanID)
+	}
+}
+
+func TestNewHandler_Status(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		want       tracer.StatusCode
+	}{
+		{"Client Error", http.StatusNotFound, tracer.StatusUnset},
+		{"Server Error", http.StatusInternalServerError, tracer.StatusError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := tracetest.NewTracer()
+			handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				w.WriteHeader(http.StatusOK) // superfluous, ignored
+			}), WithTracer(tr))
+			handler.ServeHTTP(httptest.NewRecorder(),
+				httptest.NewRequest(http.MethodGet, "/", nil))
+
+			span := tr.Recorder().EndedSpans()[0]
+			if span.Status != tc.want {
+				t.Errorf("expected status %s, got %s", tc.want, span.Status)
+			}
+			if got, _ := span.Attribute(attribute.HTTPResponseStatusKey); got.AsInt64() != int64(tc.statusCode) {
+				t.Errorf("expected status code %d, got %v", tc.statusCode, got)
+			}
+		})
+	}
+}
+
+func TestNewHandler_Panic(t *testing.T) {
+	tr := tracetest.NewTracer()
+	handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}), WithTracer(tr))
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to be propagated")
+			}
+		}()
+		handler.ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+
+	span := tr.Recorder().EndedSpans()[0]
+	if span.Error == nil {
+		t.Error("expected the panic to be recorded")
+	} else if span.Status != tracer.StatusError {
+		t.Errorf("expected error status, got %s", span.Status)
+	}
+}
+
+func TestNewMiddleware_Options(t *testing.T) {
+	t.Run("WithRouteResolver and WithSpanNameGenerator", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		middleware := NewMiddleware(WithTracer(tr),
+			WithRouteResolver(func(r *http.Request) string { return "/users/{id}" }),
+			WithSpanNameGenerator(func(r *http.Request) string { return "users" }),
+		)
+		middleware(http.NotFoundHandler()).ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Name != "users" {
+			t.Errorf("expected span name users, got %s", span.Name)
+		}
+		if got, _ := span.Attribute(attribute.HTTPRouteKey); got.AsString() != "/users/{id}" {
+			t.Errorf("expected the resolved route, got %v", got)
+		}
+	})
+
+	t.Run("WithFilter", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		served := false
+		handler := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			served = true
+		}), WithTracer(tr), WithFilter(func(r *http.Request) bool {
+			return r.URL.Path != "/healthz"
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		if !served {
+			t.Error("expected the request to be served")
+		}
+		if len(tr.Recorder().StartedSpans()) != 0 {
+			t.Error("expected the filtered request to not be traced")
+		}
+	})
+}
+
+func TestResponseWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rw := &responseWriter{ResponseWriter: rec, statusCode: http.StatusOK}
+
+	_, _ = rw.Write([]byte("abc"))
+	rw.WriteHeader(http.StatusTeapot) // after the body, ignored
+	rw.Flush()
+
+	if rw.statusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rw.statusCode)
+	} else if rw.written != 3 {
+		t.Errorf("expected 3 bytes written, got %d", rw.written)
+	} else if !rec.Flushed {
+		t.Error("expected the underlying writer to be flushed")
+	} else if rw.Unwrap() != rec {
+		t.Error("expected Unwrap to return the underlying writer")
+	}
+}
+
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	r.hijacked = true
+	return nil, nil, nil
+}
+
+func TestResponseWriter_Hijack(t *testing.T) {
+	t.Run("Supported", func(t *testing.T) {
+		rec := &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+		rw := &responseWriter{ResponseWriter: rec, statusCode: http.StatusOK}
+
+		if _, _, err := http.NewResponseController(rw).Hijack(); err != nil {
+			t.Fatalf("expected hijack to succeed, got %v", err)
+		} else if !rec.hijacked {
+			t.Error("expected the underlying writer to be hijacked")
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		rw := &responseWriter{ResponseWriter: httptest.NewRecorder(),
+			statusCode: http.StatusOK}
+
+		if _, _, err := rw.Hijack(); !errors.Is(err, http.ErrNotSupported) {
+			t.Errorf("expected %v, got %v", http.ErrNotSupported, err)
+		}
+	})
+}
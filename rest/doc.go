@@ -5,5 +5,9 @@
 // When opted, it will start a new span before requests and captures attributes.
 // Then, after request it will end the span marking the process has finished as
 // well as capturing attributes for the span.
+//
+// Likewise, when metrics are opted, it will record the request count, error
+// count and latency histogram per method, host and status class through the
+// metric package.
 
 package rest
@@ -4,8 +4,9 @@ go 1.21
 
 require (
 	github.com/go-resty/resty/v2 v2.16.2
+	github.com/rmscoal/tengcorux/metric v0.0.0-20261017174934-22fc87409d0a
 	github.com/rmscoal/tengcorux/reqid v0.1.0
-	github.com/rmscoal/tengcorux/tracer v0.1.4
+	github.com/rmscoal/tengcorux/tracer v0.1.5-0.20261017175312-3ec99204e181
 	github.com/stretchr/testify v1.10.0
 )
 
@@ -16,3 +17,7 @@ require (
 	golang.org/x/net v0.32.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/rmscoal/tengcorux/tracer => ../tracer
+
+replace github.com/rmscoal/tengcorux/metric => ../metric
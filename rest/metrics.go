@@ -0,0 +1,194 @@
+package rest
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rmscoal/tengcorux/metric"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+const (
+	// requestsMetricName counts the requests made by the client.
+	requestsMetricName = "http.client.requests"
+	// errorsMetricName counts the failed requests, either resty failed to
+	// make the request or the response status is 4xx or 5xx.
+	errorsMetricName = "http.client.errors"
+	// durationMetricName is the latency histogram of the requests.
+	durationMetricName = "http.client.request.duration"
+)
+
+// metricsContextKey is the context key holding the *requestMetrics of a
+// request attempt.
+type metricsContextKey struct{}
+
+// requestMetrics is the state of a request attempt shared between the
+// middleware.
+type requestMetrics struct {
+	start    time.Time
+	recorded bool
+	failed   bool
+}
+
+// instruments holds the RED instruments of the client.
+type instruments struct {
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func newInstruments(meter metric.Meter) (*instruments, error) {
+	requests, err := meter.Int64Counter(requestsMetricName,
+		metric.WithDescription("Number of HTTP requests made by the client."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	errors, err := meter.Int64Counter(errorsMetricName,
+		metric.WithDescription("Number of failed HTTP requests made by the client."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram(durationMetricName,
+		metric.WithDescription("Duration of HTTP requests made by the client."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instruments{requests: requests, errors: errors, duration: duration}, nil
+}
+
+// lazyInstruments creates the instruments on first use, from the given
+// meter, else from the global meter at that time. Hence, the client may be
+// built before the global meter is set.
+type lazyInstruments struct {
+	meter       metric.Meter
+	once        sync.Once
+	instruments *instruments
+}
+
+// get returns the instruments, nil if they could not be created.
+func (l *lazyInstruments) get() *instruments {
+	l.once.Do(func() {
+		meter := l.meter
+		if meter == nil {
+			meter = metric.GetGlobalMeter()
+		}
+		// Metrics are best effort, the client still works without them.
+		l.instruments, _ = newInstruments(meter)
+	})
+	return l.instruments
+}
+
+// registerMetricsMiddleware registers OnBeforeRequest, OnAfterResponse and
+// OnError middleware recording the request count, error count and latency
+// of the requests per method, host and status class.
+func (r *Rest) registerMetricsMiddleware() {
+	lazy := &lazyInstruments{meter: r.meter}
+
+	r.Client = r.Client.
+		OnBeforeRequest(
+			func(client *resty.Client, request *resty.Request) error {
+				if lazy.get() == nil {
+					return nil
+				}
+				// Each attempt, including retries, is measured on its own.
+				request.SetContext(context.WithValue(request.Context(),
+					metricsContextKey{}, &requestMetrics{start: time.Now()}))
+				return nil
+			},
+		).
+		OnError(
+			func(request *resty.Request, err error) {
+				// OnError is triggered once after the last attempt. The
+				// attempt may already be recorded by OnAfterResponse when a
+				// later response middleware failed.
+				state, ok := request.Context().Value(metricsContextKey{}).(*requestMetrics)
+				if !ok || state.failed {
+					return
+				}
+				instruments := lazy.get()
+
+				attrs := metricAttributes(request.Method, requestHost(request), "")
+				if !state.recorded {
+					instruments.requests.Add(request.Context(), 1,
+						metric.WithAttributes(attrs...))
+					instruments.duration.Record(request.Context(),
+						time.Since(state.start).Seconds(),
+						metric.WithAttributes(attrs...))
+				}
+				instruments.errors.Add(request.Context(), 1,
+					metric.WithAttributes(attrs...))
+				state.recorded, state.failed = true, true
+			},
+		).
+		OnAfterResponse(
+			func(client *resty.Client, response *resty.Response) error {
+				request := response.Request
+				state, ok := request.Context().Value(metricsContextKey{}).(*requestMetrics)
+				if !ok || state.recorded {
+					return nil
+				}
+				instruments := lazy.get()
+
+				attrs := metricAttributes(request.Method, requestHost(request),
+					statusClass(response.StatusCode()))
+				instruments.requests.Add(request.Context(), 1,
+					metric.WithAttributes(attrs...))
+				instruments.duration.Record(request.Context(),
+					time.Since(state.start).Seconds(),
+					metric.WithAttributes(attrs...))
+				if response.IsError() {
+					instruments.errors.Add(request.Context(), 1,
+						metric.WithAttributes(attrs...))
+					state.failed = true
+				}
+				state.recorded = true
+
+				return nil
+			},
+		)
+}
+
+// metricAttributes returns the attributes of the measurements, omitting the
+// status class when there was no response.
+func metricAttributes(method, host, class string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.HTTPRequestMethod(method),
+		attribute.HTTPServerAddress(host),
+	}
+	if class != "" {
+		attrs = append(attrs, attribute.HTTPResponseStatusClass(class))
+	}
+	return attrs
+}
+
+// requestHost returns the host of the request, without the port.
+func requestHost(request *resty.Request) string {
+	if request.RawRequest != nil && request.RawRequest.URL != nil {
+		return request.RawRequest.URL.Hostname()
+	}
+	if u, err := url.Parse(request.URL); err == nil {
+		return u.Hostname()
+	}
+	return ""
+}
+
+// statusClass returns the class of the status code such as "2xx".
+func statusClass(code int) string {
+	if code < 100 || code > 599 {
+		return ""
+	}
+	return strconv.Itoa(code/100) + "xx"
+}
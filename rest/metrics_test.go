@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rmscoal/tengcorux/metric"
+	"github.com/rmscoal/tengcorux/metric/metrictest"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRest_Metrics(t *testing.T) {
+	server := httptest.NewServer(testServer().Handler)
+	defer server.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		meter := metrictest.NewMeter()
+		rest := New(WithMetricsEnabled(), WithMeter(meter)).SetBaseURL(server.URL)
+
+		_, err := rest.R().SetContext(context.Background()).Get("/success")
+		assert.NoError(t, err, "error should be nil")
+		_, err = rest.R().SetContext(context.Background()).Post("/success")
+		assert.NoError(t, err, "error should be nil")
+
+		get := []attribute.KeyValue{
+			attribute.HTTPRequestMethod("GET"),
+			attribute.HTTPServerAddress("127.0.0.1"),
+			attribute.HTTPResponseStatusClass("2xx"),
+		}
+		assert.Equal(t, float64(2), meter.Sum(requestsMetricName),
+			"requests should be counted")
+		assert.Equal(t, float64(1), meter.Sum(requestsMetricName, get...),
+			"requests should be counted per method")
+		assert.Equal(t, 1, meter.Count(durationMetricName, get...),
+			"duration should be recorded")
+		assert.Zero(t, meter.Count(errorsMetricName),
+			"errors should not be counted")
+
+		instrument, ok := meter.Instrument(durationMetricName)
+		assert.True(t, ok, "duration histogram should be created")
+		assert.Equal(t, "s", instrument.Unit, "duration unit should be seconds")
+	})
+
+	t.Run("Error Status", func(t *testing.T) {
+		meter := metrictest.NewMeter()
+		rest := New(WithMetricsEnabled(), WithMeter(meter)).SetBaseURL(server.URL)
+
+		resp, err := rest.R().SetContext(context.Background()).Get("/error")
+		assert.NoError(t, err, "error should be nil")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode(),
+			"status code should be 400")
+
+		class := attribute.HTTPResponseStatusClass("4xx")
+		assert.Equal(t, float64(1), meter.Sum(requestsMetricName, class),
+			"requests should be counted")
+		assert.Equal(t, float64(1), meter.Sum(errorsMetricName, class),
+			"errors should be counted")
+		assert.Equal(t, 1, meter.Count(durationMetricName, class),
+			"duration should be recorded")
+	})
+
+	t.Run("Request Failed", func(t *testing.T) {
+		meter := metrictest.NewMeter()
+		rest := New(WithMetricsEnabled(), WithMeter(meter)).SetBaseURL(server.URL)
+		rest.SetTransport(&http.Transport{
+			DialContext: func(
+				ctx context.Context, network, addr string,
+			) (net.Conn, error) {
+				return nil, errors.New("dial failed")
+			},
+		})
+
+		_, err := rest.R().SetContext(context.Background()).Get("/success")
+		assert.Error(t, err)
+
+		method := attribute.HTTPRequestMethod("GET")
+		assert.Equal(t, float64(1), meter.Sum(requestsMetricName, method),
+			"requests should be counted")
+		assert.Equal(t, float64(1), meter.Sum(errorsMetricName, method),
+			"errors should be counted")
+		assert.Equal(t, 1, meter.Count(durationMetricName, method),
+			"duration should be recorded")
+		for _, m := range meter.Measurements(errorsMetricName) {
+			for _, attr := range m.Attributes {
+				assert.NotEqual(t, attribute.HTTPResponseStatusClassKey, attr.Key,
+					"status class should be omitted without a response")
+			}
+		}
+	})
+
+	t.Run("Response Middleware Failed", func(t *testing.T) {
+		meter := metrictest.NewMeter()
+		rest := New(WithMetricsEnabled(), WithMeter(meter)).SetBaseURL(server.URL)
+
+		// The body is not JSON, failing resty to parse the result after the
+		// response was recorded.
+		_, err := rest.R().SetContext(context.Background()).
+			SetResult(&map[string]any{}).
+			ForceContentType("application/json").
+			Get("/success")
+		assert.Error(t, err)
+
+		assert.Equal(t, float64(1), meter.Sum(requestsMetricName),
+			"request should be counted once")
+		assert.Equal(t, float64(1), meter.Sum(errorsMetricName),
+			"error should be counted once")
+	})
+
+	t.Run("Global Meter", func(t *testing.T) {
+		meter := metrictest.NewMeter()
+		metric.SetGlobalMeter(meter)
+		defer metric.SetGlobalMeter(&metric.NoopMeter{})
+
+		rest := New(WithMetricsEnabled()).SetBaseURL(server.URL)
+		_, err := rest.R().SetContext(context.Background()).Get("/success")
+		assert.NoError(t, err, "error should be nil")
+		assert.Equal(t, float64(1), meter.Sum(requestsMetricName),
+			"requests should be counted")
+	})
+
+	t.Run("Global Meter Set After New", func(t *testing.T) {
+		rest := New(WithMetricsEnabled()).SetBaseURL(server.URL)
+
+		meter := metrictest.NewMeter()
+		metric.SetGlobalMeter(meter)
+		defer metric.SetGlobalMeter(&metric.NoopMeter{})
+
+		_, err := rest.R().SetContext(context.Background()).Get("/success")
+		assert.NoError(t, err, "error should be nil")
+		assert.Equal(t, float64(1), meter.Sum(requestsMetricName),
+			"requests should be counted by the meter set after New")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		meter := metrictest.NewMeter()
+		rest := New(WithMeter(meter)).SetBaseURL(server.URL)
+		_, err := rest.R().SetContext(context.Background()).Get("/success")
+		assert.NoError(t, err, "error should be nil")
+		assert.Zero(t, meter.Count(requestsMetricName),
+			"requests should not be counted")
+	})
+}
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, "2xx", statusClass(http.StatusOK))
+	assert.Equal(t, "3xx", statusClass(http.StatusFound))
+	assert.Equal(t, "4xx", statusClass(http.StatusNotFound))
+	assert.Equal(t, "5xx", statusClass(http.StatusBadGateway))
+	assert.Equal(t, "", statusClass(0))
+}
@@ -1,5 +1,10 @@
 package rest
 
+import (
+	"github.com/rmscoal/tengcorux/metric"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
 type Option func(r *Rest)
 
 // WithTracerEnabled marks tracer flag as true and registers request and response
@@ -9,3 +14,29 @@ func WithTracerEnabled() Option {
 		r.tracerEnabled = true
 	}
 }
+
+// WithMetricsEnabled marks metrics flag as true and registers request and
+// response middleware to record the request count, error count and latency
+// per method, host and status class.
+func WithMetricsEnabled() Option {
+	return func(r *Rest) {
+		r.metricsEnabled = true
+	}
+}
+
+// WithMeter sets the meter creating the instruments when metrics are
+// enabled. Defaults to the global meter at the first request.
+func WithMeter(meter metric.Meter) Option {
+	return func(r *Rest) {
+		r.meter = meter
+	}
+}
+
+// WithRedactor replaces the default redactor applied to the recorded URL
+// queries, headers and bodies. By default, [attribute.DefaultRedactor] is used and
+// passing nil disables redaction.
+func WithRedactor(redactor *attribute.Redactor) Option {
+	return func(r *Rest) {
+		r.redactor = redactor
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/rmscoal/tengcorux/metric"
 	"github.com/rmscoal/tengcorux/reqid"
 	"github.com/rmscoal/tengcorux/tracer"
 	"github.com/rmscoal/tengcorux/tracer/attribute"
@@ -16,13 +20,17 @@ import (
 type Rest struct {
 	*resty.Client
 
-	tracerEnabled bool
+	tracerEnabled  bool
+	metricsEnabled bool
+	meter          metric.Meter
+	redactor       *attribute.Redactor
 }
 
 func New(opts ...Option) *Rest {
 	rest := &Rest{
 		Client:        resty.New(),
 		tracerEnabled: false,
+		redactor:      attribute.DefaultRedactor(),
 	}
 
 	for _, opt := range opts {
@@ -32,6 +40,9 @@ func New(opts ...Option) *Rest {
 	if rest.tracerEnabled {
 		rest.registerTracerMiddleware()
 	}
+	if rest.metricsEnabled {
+		rest.registerMetricsMiddleware()
+	}
 
 	return rest
 }
@@ -49,6 +60,8 @@ func (r *Rest) registerTracerMiddleware() {
 				// 2. URL Target, this should include path and query params,
 				// 3. Body of the payload if it is a POST/PUT/PATCH request, and
 				// 4. Headers of the request.
+				// The span context is injected to the request headers as well,
+				// hence the peer of the span must be set beforehand.
 				method := request.Method
 				ctx, span := tracer.StartSpan(
 					request.Context(), "HTTP "+method+" Request",
@@ -61,24 +74,37 @@ func (r *Rest) registerTracerMiddleware() {
 				request.SetHeader(reqid.HeaderKey, requestID)
 
 				// Add spans to the attribute
+				target := requestURL(client, request)
 				span.SetAttributes(
 					attribute.HTTPRequestID(requestID),
 					attribute.HTTPRequestMethod(method),
+					attribute.HTTPUrl(r.redactor.RedactURL(target)),
+				)
+				span.SetAttributes(peerAttributes(target)...)
+				tracer.Inject(ctx, tracer.HeaderCarrier(request.Header))
+
+				// Marshaling headers and body is expensive, skip it when the
+				// span is not sampled.
+				request.SetContext(ctx)
+				if !span.IsRecording() {
+					return nil
+				}
+
+				span.SetAttributes(r.redactor.Redact(
 					attribute.KeyValuePair(
 						"http.request.headers",
 						generateHeaderAttribute(request.Header),
 					),
-				)
+				)...)
 
 				if method == resty.MethodPost ||
 					method == resty.MethodPut ||
 					method == resty.MethodPatch {
-					span.SetAttributes(
+					span.SetAttributes(r.redactor.Redact(
 						attribute.HTTPRequestBody(generateBodyAttribute(request.Body)),
-					)
+					)...)
 				}
 
-				request.SetContext(ctx)
 				return nil
 			},
 		).
@@ -93,8 +119,9 @@ func (r *Rest) registerTracerMiddleware() {
 				}
 				defer span.End()
 
-				span.SetAttributes(attribute.HTTPUrl(request.URL))
+				span.SetAttributes(attribute.HTTPUrl(r.redactor.RedactURL(request.URL)))
 				span.RecordError(err)
+				span.SetStatus(tracer.StatusError, err.Error())
 			},
 		).
 		OnPanic(
@@ -108,8 +135,9 @@ func (r *Rest) registerTracerMiddleware() {
 				}
 				defer span.End()
 
-				span.SetAttributes(attribute.HTTPUrl(request.URL))
+				span.SetAttributes(attribute.HTTPUrl(r.redactor.RedactURL(request.URL)))
 				span.RecordError(err)
+				span.SetStatus(tracer.StatusError, err.Error())
 			},
 		).
 		OnAfterResponse(
@@ -128,19 +156,26 @@ func (r *Rest) registerTracerMiddleware() {
 
 				span.SetAttributes(
 					attribute.HTTPResponseStatus(response.StatusCode()),
-					attribute.HTTPUrl(response.Request.URL),
+					attribute.HTTPUrl(r.redactor.RedactURL(response.Request.URL)),
+				)
+
+				if !span.IsRecording() {
+					return nil
+				}
+
+				span.SetAttributes(r.redactor.Redact(
 					attribute.KeyValuePair(
 						"http.response.headers",
 						generateHeaderAttribute(response.Header()),
 					),
-				)
+				)...)
 
 				if response.Body() != nil {
-					span.SetAttributes(
+					span.SetAttributes(r.redactor.Redact(
 						attribute.HTTPResponseBody(
 							generateBodyAttribute(response.Body()),
 						),
-					)
+					)...)
 				}
 
 				return nil
@@ -148,6 +183,42 @@ func (r *Rest) registerTracerMiddleware() {
 		)
 }
 
+// requestURL returns the URL the request is going to hit. Resty only resolves
+// the URL against the base URL after the user defined middlewares, hence it
+// is resolved here the same way.
+func requestURL(client *resty.Client, request *resty.Request) string {
+	if u, err := url.Parse(request.URL); err == nil && u.IsAbs() {
+		return request.URL
+	}
+	if client.BaseURL == "" {
+		return request.URL
+	}
+	return client.BaseURL + "/" + strings.TrimLeft(request.URL, "/")
+}
+
+// peerAttributes returns the server address and port of the given URL.
+func peerAttributes(target string) []attribute.KeyValue {
+	u, err := url.Parse(target)
+	if err != nil || u.Hostname() == "" {
+		return nil
+	}
+
+	attrs := []attribute.KeyValue{attribute.HTTPServerAddress(u.Hostname())}
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		}
+	}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, attribute.HTTPServerPort(p))
+	}
+	return attrs
+}
+
 func generateBodyAttribute(body any) any {
 	if body == nil {
 		return nil
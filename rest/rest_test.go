@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -25,8 +26,14 @@ func TestRest_New(t *testing.T) {
 		_ = server.Shutdown(ctx)
 	}()
 
+	// Listens before serving such that the requests below never hit
+	// the server before it is ready.
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
 	go func() {
-		_ = server.ListenAndServe()
+		_ = server.Serve(listener)
 	}()
 
 	t.Run("NoOption", func(t *testing.T) {
@@ -77,22 +84,22 @@ func TestRest_New(t *testing.T) {
 			for _, attr := range span.Attributes {
 				switch attr.Key {
 				case attribute.HTTPRequestIDKey:
-					assert.NotEmpty(t, attr.Value,
+					assert.NotEmpty(t, attr.Value.Emit(),
 						"the request id span attribute should not be empty")
 				case attribute.HTTPRequestMethodKey:
-					assert.Equal(t, "GET", attr.Value,
+					assert.Equal(t, "GET", attr.Value.AsString(),
 						"http request method should be GET")
 				case "http.request.headers":
-					assert.NotNil(t, attr.Value,
+					assert.NotEmpty(t, attr.Value.Emit(),
 						"http request headers should not be nil")
 				case attribute.HTTPUrlKey:
-					assert.Equal(t, "http://localhost:8123/success", attr.Value,
+					assert.Equal(t, "http://localhost:8123/success", attr.Value.AsString(),
 						"http url should be correct")
 				case attribute.HTTPResponseStatusKey:
-					assert.Equal(t, 200, attr.Value,
+					assert.Equal(t, int64(200), attr.Value.AsInt64(),
 						"http response status should be 200")
 				case attribute.HTTPResponseBodyKey:
-					assert.Equal(t, "Success", attr.Value,
+					assert.Equal(t, "Success", attr.Value.AsString(),
 						"http response body should be correct")
 				}
 			}
@@ -123,22 +130,22 @@ func TestRest_New(t *testing.T) {
 			for _, attr := range span.Attributes {
 				switch attr.Key {
 				case attribute.HTTPRequestIDKey:
-					assert.NotEmpty(t, attr.Value,
+					assert.NotEmpty(t, attr.Value.Emit(),
 						"the request id span attribute should not be empty")
 				case attribute.HTTPRequestMethodKey:
-					assert.Equal(t, "GET", attr.Value,
+					assert.Equal(t, "GET", attr.Value.AsString(),
 						"http request method should be GET")
 				case "http.request.headers":
-					assert.NotNil(t, attr.Value,
+					assert.NotEmpty(t, attr.Value.Emit(),
 						"http request headers should not be nil")
 				case attribute.HTTPUrlKey:
-					assert.Equal(t, "http://localhost:8123/error", attr.Value,
+					assert.Equal(t, "http://localhost:8123/error", attr.Value.AsString(),
 						"http url should be correct")
 				case attribute.HTTPResponseStatusKey:
-					assert.Equal(t, 400, attr.Value,
+					assert.Equal(t, int64(400), attr.Value.AsInt64(),
 						"http response status should be 400")
 				case attribute.HTTPResponseBodyKey:
-					assert.Equal(t, "Bad Request", attr.Value,
+					assert.Equal(t, "Bad Request", attr.Value.AsString(),
 						"http response body should be correct")
 				}
 			}
@@ -172,25 +179,25 @@ func TestRest_New(t *testing.T) {
 			for _, attr := range span.Attributes {
 				switch attr.Key {
 				case attribute.HTTPRequestIDKey:
-					assert.NotEmpty(t, attr.Value,
+					assert.NotEmpty(t, attr.Value.Emit(),
 						"the request id span attribute should not be empty")
 				case attribute.HTTPRequestMethodKey:
-					assert.Equal(t, "POST", attr.Value,
+					assert.Equal(t, "POST", attr.Value.AsString(),
 						"http request method should be POST")
 				case "http.request.headers":
-					assert.NotNil(t, attr.Value,
+					assert.NotEmpty(t, attr.Value.Emit(),
 						"http request headers should not be nil")
 				case attribute.HTTPUrlKey:
-					assert.Equal(t, "http://localhost:8123/error", attr.Value,
+					assert.Equal(t, "http://localhost:8123/error", attr.Value.AsString(),
 						"http url should be correct")
 				case attribute.HTTPRequestBodyKey:
-					assert.NotNil(t, attr.Value,
+					assert.NotEmpty(t, attr.Value.Emit(),
 						"http request body should not be nil")
 				case attribute.HTTPResponseStatusKey:
-					assert.Equal(t, 400, attr.Value,
+					assert.Equal(t, int64(400), attr.Value.AsInt64(),
 						"http response status should be 400")
 				case attribute.HTTPResponseBodyKey:
-					assert.Equal(t, "Bad Request", attr.Value,
+					assert.Equal(t, "Bad Request", attr.Value.AsString(),
 						"http response body should be correct")
 				}
 			}
@@ -209,6 +216,131 @@ func TestRest_New(t *testing.T) {
 			})
 			_, err := rest.R().SetContext(context.TODO()).Get("/error")
 			assert.Error(t, err)
+
+			spans := tr.Recorder().EndedSpans()
+			assert.Len(t, spans, 1, "ended spans should be 1")
+			assert.Error(t, spans[0].Error, "span error should be recorded")
+			assert.Equal(t, tracer.StatusError, spans[0].Status,
+				"span status should be Error")
+		})
+
+		t.Run("Redacted", func(t *testing.T) {
+			tr := tracetest.NewTracer()
+			tracer.SetGlobalTracer(tr)
+			rest := New(WithTracerEnabled()).SetBaseURL("http://localhost:8123")
+
+			resp, err := rest.R().SetContext(context.TODO()).
+				SetHeader("Authorization", "Bearer some_token").
+				SetBody(map[string]string{
+					"email":    "john.doe@example.com",
+					"password": "some_password",
+				}).
+				Post("/success?access_token=some_query_token")
+			assert.NoError(t, err, "error should be nil")
+			assert.Equal(t, http.StatusOK, resp.StatusCode(),
+				"status code should be 200")
+
+			spans := tr.Recorder().EndedSpans()
+			assert.Len(t, spans, 1, "ended spans should be 1")
+			for _, attr := range spans[0].Attributes {
+				switch attr.Key {
+				case "http.request.headers", attribute.HTTPRequestBodyKey:
+					value := attr.Value.AsString()
+					assert.Contains(t, value, attribute.DefaultMask,
+						"%s should be redacted", attr.Key)
+					assert.NotContains(t, value, "some_token")
+					assert.NotContains(t, value, "some_password")
+					assert.NotContains(t, value, "john.doe@example.com")
+				case attribute.HTTPUrlKey:
+					assert.Equal(t,
+						"http://localhost:8123/success?access_token=%5BREDACTED%5D",
+						attr.Value.AsString(), "url query should be redacted")
+				}
+			}
+		})
+
+		t.Run("Redaction Disabled", func(t *testing.T) {
+			tr := tracetest.NewTracer()
+			tracer.SetGlobalTracer(tr)
+			rest := New(WithTracerEnabled(), WithRedactor(nil)).
+				SetBaseURL("http://localhost:8123")
+
+			_, err := rest.R().SetContext(context.TODO()).
+				SetHeader("Authorization", "Bearer some_token").
+				Get("/success")
+			assert.NoError(t, err, "error should be nil")
+
+			spans := tr.Recorder().EndedSpans()
+			assert.Len(t, spans, 1, "ended spans should be 1")
+			for _, attr := range spans[0].Attributes {
+				if attr.Key == "http.request.headers" {
+					assert.Contains(t, attr.Value.AsString(), "some_token",
+						"headers should not be redacted")
+				}
+			}
+		})
+
+		t.Run("Truncated Body", func(t *testing.T) {
+			tr := tracetest.NewTracer()
+			tracer.SetGlobalTracer(tr)
+			rest := New(WithTracerEnabled()).SetBaseURL("http://localhost:8123")
+
+			resp, err := rest.R().SetContext(context.TODO()).
+				SetBody(strings.Repeat("a", 1<<20)).
+				Post("/large")
+			assert.NoError(t, err, "error should be nil")
+			assert.Len(t, resp.Body(), 1<<20, "body should be whole")
+
+			spans := tr.Recorder().EndedSpans()
+			assert.Len(t, spans, 1, "ended spans should be 1")
+			want := strings.Repeat("a", tracer.DefaultAttributeValueLengthLimit) +
+				tracer.TruncationMarker
+			truncated := 0
+			for _, attr := range spans[0].Attributes {
+				switch attr.Key {
+				case attribute.HTTPRequestBodyKey, attribute.HTTPResponseBodyKey:
+					assert.Equal(t, want, attr.Value.AsString(),
+						"%s should be truncated", attr.Key)
+					truncated++
+				}
+			}
+			assert.Equal(t, 2, truncated, "both bodies should be recorded")
+		})
+
+		t.Run("Propagation", func(t *testing.T) {
+			tr := tracetest.NewTracer()
+			tracer.SetGlobalTracer(tr)
+			rest := New(WithTracerEnabled()).SetBaseURL("http://localhost:8123")
+
+			resp, err := rest.R().SetContext(context.TODO()).Get("/propagation")
+			assert.NoError(t, err, "error should be nil")
+
+			spans := tr.Recorder().EndedSpans()
+			assert.Len(t, spans, 1, "ended spans should be 1")
+			assert.Equal(t, strconv.FormatUint(spans[0].SpanID, 10), string(resp.Body()),
+				"the span should be propagated to the server")
+
+			address, _ := spans[0].Attribute(attribute.HTTPServerAddressKey)
+			assert.Equal(t, "localhost", address.AsString(),
+				"server address should be correct")
+			port, _ := spans[0].Attribute(attribute.HTTPServerPortKey)
+			assert.Equal(t, int64(8123), port.AsInt64(),
+				"server port should be correct")
+		})
+
+		t.Run("Unsampled", func(t *testing.T) {
+			tr := tracetest.NewTracer(tracetest.WithSampler(tracer.NeverSample()))
+			tracer.SetGlobalTracer(tr)
+			rest := New(WithTracerEnabled()).SetBaseURL("http://localhost:8123")
+
+			resp, err := rest.R().SetContext(context.TODO()).
+				SetBody(map[string]string{"hello": "world"}).
+				Post("/success")
+			assert.NoError(t, err, "error should be nil")
+			assert.Equal(t, http.StatusOK, resp.StatusCode(),
+				"status code should be 200")
+			assert.Empty(t, tr.Recorder().EndedSpans(),
+				"unsampled span should not be recorded")
 		})
 	})
 }
@@ -299,6 +431,14 @@ func testServer() *http.Server {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("Success"))
 	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(strings.Repeat("a", 1<<20)))
+	})
+	mux.HandleFunc("/propagation", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(r.Header.Get(tracetest.SpanIDKey)))
+	})
 	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte("Bad Request"))
@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"bytes"
+
+	"github.com/IBM/sarama"
+
+	"github.com/rmscoal/tengcorux/tracer"
+)
+
+// ProducerMessageCarrier adapts the headers of a sarama.ProducerMessage to
+// satisfy the tracer.Carrier interface.
+type ProducerMessageCarrier struct {
+	msg *sarama.ProducerMessage
+}
+
+// Make sure that ProducerMessageCarrier implements [tracer.Carrier] during
+// compile time.
+var _ tracer.Carrier = ProducerMessageCarrier{}
+
+// NewProducerMessageCarrier returns the carrier of the message headers.
+func NewProducerMessageCarrier(msg *sarama.ProducerMessage) ProducerMessageCarrier {
+	return ProducerMessageCarrier{msg: msg}
+}
+
+// Get returns the value associated with the given key.
+func (c ProducerMessageCarrier) Get(key string) string {
+	for _, h := range c.msg.Headers {
+		if string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set stores the key-value pair into the headers, replacing the existing
+// value of the key.
+func (c ProducerMessageCarrier) Set(key string, value string) {
+	for i := range c.msg.Headers {
+		if string(c.msg.Headers[i].Key) == key {
+			c.msg.Headers[i].Value = []byte(value)
+			return
+		}
+	}
+	c.msg.Headers = append(c.msg.Headers, sarama.RecordHeader{
+		Key:   []byte(key),
+		Value: []byte(value),
+	})
+}
+
+// Keys lists the keys stored in the headers.
+func (c ProducerMessageCarrier) Keys() []string {
+	keys := make([]string, len(c.msg.Headers))
+	for i, h := range c.msg.Headers {
+		keys[i] = string(h.Key)
+	}
+	return keys
+}
+
+// ConsumerMessageCarrier adapts the headers of a sarama.ConsumerMessage to
+// satisfy the tracer.Carrier interface.
+type ConsumerMessageCarrier struct {
+	msg *sarama.ConsumerMessage
+}
+
+// Make sure that ConsumerMessageCarrier implements [tracer.Carrier] during
+// compile time.
+var _ tracer.Carrier = ConsumerMessageCarrier{}
+
+// NewConsumerMessageCarrier returns the carrier of the message headers.
+func NewConsumerMessageCarrier(msg *sarama.ConsumerMessage) ConsumerMessageCarrier {
+	return ConsumerMessageCarrier{msg: msg}
+}
+
+// Get returns the value associated with the given key.
+func (c ConsumerMessageCarrier) Get(key string) string {
+	for _, h := range c.msg.Headers {
+		if h != nil && bytes.Equal(h.Key, []byte(key)) {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set stores the key-value pair into the headers, replacing the existing
+// value of the key.
+func (c ConsumerMessageCarrier) Set(key string, value string) {
+	for _, h := range c.msg.Headers {
+		if h != nil && bytes.Equal(h.Key, []byte(key)) {
+			h.Value = []byte(value)
+			return
+		}
+	}
+	c.msg.Headers = append(c.msg.Headers, &sarama.RecordHeader{
+		Key:   []byte(key),
+		Value: []byte(value),
+	})
+}
+
+// Keys lists the keys stored in the headers.
+func (c ConsumerMessageCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.msg.Headers))
+	for _, h := range c.msg.Headers {
+		if h != nil {
+			keys = append(keys, string(h.Key))
+		}
+	}
+	return keys
+}
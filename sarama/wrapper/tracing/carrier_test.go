@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestProducerMessageCarrier(t *testing.T) {
+	msg := &sarama.ProducerMessage{}
+	carrier := NewProducerMessageCarrier(msg)
+
+	carrier.Set("trace-id", "1")
+	carrier.Set("span-id", "2")
+	carrier.Set("span-id", "3")
+
+	if got := carrier.Get("span-id"); got != "3" {
+		t.Errorf("expected the value to be replaced, got %q", got)
+	}
+	if got := carrier.Get("missing"); got != "" {
+		t.Errorf("expected an empty value, got %q", got)
+	}
+	if got := carrier.Keys(); !reflect.DeepEqual(got, []string{"trace-id", "span-id"}) {
+		t.Errorf("unexpected keys %v", got)
+	}
+	if len(msg.Headers) != 2 {
+		t.Errorf("expected 2 headers, got %d", len(msg.Headers))
+	}
+}
+
+func TestConsumerMessageCarrier(t *testing.T) {
+	msg := &sarama.ConsumerMessage{Headers: []*sarama.RecordHeader{
+		nil,
+		{Key: []byte("trace-id"), Value: []byte("1")},
+	}}
+	carrier := NewConsumerMessageCarrier(msg)
+
+	carrier.Set("trace-id", "2")
+	carrier.Set("span-id", "3")
+
+	if got := carrier.Get("trace-id"); got != "2" {
+		t.Errorf("expected the value to be replaced, got %q", got)
+	}
+	if got := carrier.Get("span-id"); got != "3" {
+		t.Errorf("expected the value to be added, got %q", got)
+	}
+	if got := carrier.Keys(); !reflect.DeepEqual(got, []string{"trace-id", "span-id"}) {
+		t.Errorf("unexpected keys %v", got)
+	}
+}
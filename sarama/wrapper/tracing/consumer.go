@@ -0,0 +1,114 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/sarama"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// MessageHandler handles a consumed message. The context holds the entry
+// span of the message and its request id.
+type MessageHandler func(ctx context.Context, msg *sarama.ConsumerMessage) error
+
+// NewMessageHandler wraps the handler such that every message is handled
+// within an entry span. The span continues the trace propagated by the
+// message headers, the request id is taken from the X-Request-Id header or
+// generated and injected into the context. It suits the partition consumers,
+// for example:
+//
+//	handle := tracing.NewMessageHandler(handleOrder)
+//	for msg := range partitionConsumer.Messages() {
+//		_ = handle(ctx, msg)
+//	}
+func NewMessageHandler(handler MessageHandler, opts ...Option) MessageHandler {
+	c := newConfig(opts...)
+
+	return func(ctx context.Context, msg *sarama.ConsumerMessage) (err error) {
+		carrier := NewConsumerMessageCarrier(msg)
+		if propagator, ok := c.getTracer().(tracer.Propagator); ok {
+			ctx = propagator.Extract(ctx, carrier)
+		}
+		if requestID := carrier.Get(reqid.HeaderKey); requestID != "" {
+			ctx = reqid.InjectValue(ctx, requestID)
+		} else {
+			ctx = reqid.Inject(ctx)
+		}
+
+		ctx, span := c.startSpan(ctx, "Kafka/"+msg.Topic+"/Consumer", msg.Topic,
+			tracer.SpanTypeEntry)
+		span.SetAttributes(
+			attribute.MQPartition(msg.Partition),
+			attribute.MQOffset(msg.Offset),
+			attribute.RequestID(reqid.RetrieveFromContext(ctx)),
+		)
+		if c.consumerGroup != "" {
+			span.SetAttributes(attribute.MQConsumerGroup(c.consumerGroup))
+		}
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err := fmt.Errorf("panic: %v", recovered)
+				finishSpan(span, err)
+				panic(recovered)
+			}
+			finishSpan(span, err)
+		}()
+
+		return handler(ctx, msg)
+	}
+}
+
+// NewConsumerGroupHandler returns a sarama.ConsumerGroupHandler handling
+// every claimed message with NewMessageHandler. A handled message is marked
+// as consumed, while a failed one ends the claim, leaving it to be consumed
+// again after the rebalance. For example:
+//
+//	handler := tracing.NewConsumerGroupHandler(handleOrder,
+//		tracing.WithConsumerGroup("orders"))
+//	for ctx.Err() == nil {
+//		if err := group.Consume(ctx, topics, handler); err != nil {
+//			return err
+//		}
+//	}
+func NewConsumerGroupHandler(handler MessageHandler, opts ...Option) sarama.ConsumerGroupHandler {
+	return &consumerGroupHandler{handle: NewMessageHandler(handler, opts...)}
+}
+
+type consumerGroupHandler struct {
+	handle MessageHandler
+}
+
+// Setup is run at the beginning of a new session.
+func (h *consumerGroupHandler) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// Cleanup is run at the end of a session.
+func (h *consumerGroupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim handles the messages of the claim until the session ends.
+func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
+	claim sarama.ConsumerGroupClaim,
+) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			if err := h.handle(session.Context(), msg); err != nil {
+				return err
+			}
+			session.MarkMessage(msg, "")
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/IBM/sarama"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"github.com/rmscoal/tengcorux/tracer/tracetest"
+)
+
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []*sarama.ConsumerMessage
+}
+
+func (s *fakeSession) Context() context.Context { return s.ctx }
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg)
+}
+
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func newTestingClaim(msgs ...*sarama.ConsumerMessage) *fakeClaim {
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage, len(msgs))}
+	for _, msg := range msgs {
+		claim.messages <- msg
+	}
+	close(claim.messages)
+	return claim
+}
+
+func TestNewMessageHandler(t *testing.T) {
+	t.Run("Propagation", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		msg := &sarama.ConsumerMessage{Topic: "orders", Partition: 1, Offset: 42}
+		carrier := NewConsumerMessageCarrier(msg)
+		carrier.Set(tracetest.TraceIDKey, "7")
+		carrier.Set(tracetest.SpanIDKey, "3")
+		carrier.Set(reqid.HeaderKey, "request-id")
+
+		handle := NewMessageHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			if reqid.RetrieveFromContext(ctx) != "request-id" {
+				t.Error("expected the request id to be injected")
+			}
+			if tr.SpanFromContext(ctx) == nil {
+				t.Error("expected the span to be in the context")
+			}
+			return nil
+		}, WithTracer(tr), WithConsumerGroup("billing"))
+		if err := handle(context.Background(), msg); err != nil {
+			t.Fatal(err)
+		}
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Name != "Kafka/orders/Consumer" {
+			t.Errorf("unexpected span name %s", span.Name)
+		} else if span.Type != tracer.SpanTypeEntry || span.Layer != tracer.SpanLayerMQ {
+			t.Errorf("expected an entry mq span, got %d %d", span.Type, span.Layer)
+		} else if span.TraceID != 7 || span.ParentSpanID != 3 {
+			t.Errorf("expected the producer trace to be continued, got %d/%d",
+				span.TraceID, span.ParentSpanID)
+		}
+
+		tests := map[attribute.Key]attribute.Value{
+			attribute.MQSystemKey:        attribute.StringValue("kafka"),
+			attribute.MQTopicKey:         attribute.StringValue("orders"),
+			attribute.MQPartitionKey:     attribute.IntValue(1),
+			attribute.MQOffsetKey:        attribute.Int64Value(42),
+			attribute.MQConsumerGroupKey: attribute.StringValue("billing"),
+			attribute.RequestIDKey:       attribute.StringValue("request-id"),
+		}
+		for key, want := range tests {
+			if got, _ := span.Attribute(key); got != want {
+				t.Errorf("%s: want %v, got %v", key, want, got)
+			}
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		handle := NewMessageHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			return errors.New("some_error")
+		}, WithTracer(tr))
+		if err := handle(context.Background(), &sarama.ConsumerMessage{}); err == nil {
+			t.Fatal("expected the handler error")
+		}
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Error == nil || span.Status != tracer.StatusError {
+			t.Error("expected the error to fail the span")
+		}
+		if got, _ := span.Attribute(attribute.RequestIDKey); got.AsString() == "" {
+			t.Error("expected a request id to be generated")
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		handle := NewMessageHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			panic("boom")
+		}, WithTracer(tr))
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected the panic to be propagated")
+				}
+			}()
+			_ = handle(context.Background(), &sarama.ConsumerMessage{})
+		}()
+
+		if span := tr.Recorder().EndedSpans()[0]; span.Status != tracer.StatusError {
+			t.Errorf("expected error status, got %s", span.Status)
+		}
+	})
+}
+
+func TestNewConsumerGroupHandler(t *testing.T) {
+	t.Run("Marks Handled Messages", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		handler := NewConsumerGroupHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			return nil
+		}, WithTracer(tr))
+
+		session := &fakeSession{ctx: context.Background()}
+		claim := newTestingClaim(
+			&sarama.ConsumerMessage{Topic: "orders", Offset: 1},
+			&sarama.ConsumerMessage{Topic: "orders", Offset: 2},
+		)
+		if err := handler.ConsumeClaim(session, claim); err != nil {
+			t.Fatal(err)
+		}
+		if len(session.marked) != 2 {
+			t.Errorf("expected 2 marked messages, got %d", len(session.marked))
+		}
+		if len(tr.Recorder().EndedSpans()) != 2 {
+			t.Errorf("expected a span per message, got %d",
+				len(tr.Recorder().EndedSpans()))
+		}
+	})
+
+	t.Run("Stops On Error", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		handler := NewConsumerGroupHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			if msg.Offset == 2 {
+				return errors.New("some_error")
+			}
+			return nil
+		}, WithTracer(tr))
+
+		session := &fakeSession{ctx: context.Background()}
+		claim := newTestingClaim(
+			&sarama.ConsumerMessage{Topic: "orders", Offset: 1},
+			&sarama.ConsumerMessage{Topic: "orders", Offset: 2},
+			&sarama.ConsumerMessage{Topic: "orders", Offset: 3},
+		)
+		if err := handler.ConsumeClaim(session, claim); err == nil {
+			t.Fatal("expected the handler error")
+		}
+		if len(session.marked) != 1 || session.marked[0].Offset != 1 {
+			t.Errorf("expected only the first message to be marked, got %d",
+				len(session.marked))
+		}
+	})
+}
+
+func TestProduceConsume(t *testing.T) {
+	tr := tracetest.NewTracer()
+	producer, _ := newTestingProducer(t, tr, sarama.ErrNoError)
+
+	msg := &sarama.ProducerMessage{Topic: "orders", Value: sarama.StringEncoder("order")}
+	if _, _, err := producer.SendMessage(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	consumed := &sarama.ConsumerMessage{Topic: msg.Topic}
+	for i := range msg.Headers {
+		consumed.Headers = append(consumed.Headers, &msg.Headers[i])
+	}
+	handle := NewMessageHandler(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		return nil
+	}, WithTracer(tr))
+	if err := handle(context.Background(), consumed); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := tr.Recorder().EndedSpans()
+	producerSpan, consumerSpan := spans[0], spans[1]
+	if consumerSpan.TraceID != producerSpan.TraceID ||
+		consumerSpan.ParentSpanID != producerSpan.SpanID {
+		t.Errorf("expected the consumer to continue the producer trace, got %s/%s",
+			strconv.FormatUint(consumerSpan.TraceID, 10),
+			strconv.FormatUint(consumerSpan.ParentSpanID, 10))
+	}
+}
@@ -0,0 +1,29 @@
+package tracing
+
+import (
+	"github.com/rmscoal/tengcorux/tracer"
+)
+
+type Option func(*config)
+
+// WithTracer uses the given tracer instead of the global tracer.
+func WithTracer(t tracer.Tracer) Option {
+	return func(c *config) {
+		c.tracer = t
+	}
+}
+
+// WithBrokers records the given broker addresses as the instance of the
+// message queue and the server address of the spans.
+func WithBrokers(addrs ...string) Option {
+	return func(c *config) {
+		c.brokers = addrs
+	}
+}
+
+// WithConsumerGroup records the given consumer group on the consumer spans.
+func WithConsumerGroup(group string) Option {
+	return func(c *config) {
+		c.consumerGroup = group
+	}
+}
@@ -0,0 +1,119 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IBM/sarama"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// SyncProducer is a sarama.SyncProducer sending every message within an
+// exit span. The context variants continue the trace found in the given
+// context, while the sarama.SyncProducer methods start a new trace.
+type SyncProducer interface {
+	sarama.SyncProducer
+
+	// SendMessageContext produces the message within a span continuing the
+	// trace found in ctx.
+	SendMessageContext(ctx context.Context, msg *sarama.ProducerMessage) (partition int32, offset int64, err error)
+
+	// SendMessagesContext produces the messages within a span each,
+	// continuing the trace found in ctx.
+	SendMessagesContext(ctx context.Context, msgs []*sarama.ProducerMessage) error
+}
+
+// WrapSyncProducer returns a SyncProducer tracing the given producer. The
+// trace context and the request id found in the context are propagated
+// through the message headers. For example:
+//
+//	producer, err := sarama.NewSyncProducer(brokers, cfg)
+//	if err != nil {
+//		return err
+//	}
+//	producer = tracing.WrapSyncProducer(producer, tracing.WithBrokers(brokers...))
+func WrapSyncProducer(producer sarama.SyncProducer, opts ...Option) SyncProducer {
+	return &syncProducer{SyncProducer: producer, cfg: newConfig(opts...)}
+}
+
+type syncProducer struct {
+	sarama.SyncProducer
+	cfg *config
+}
+
+// SendMessage produces the message within a span starting a new trace.
+func (p *syncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	return p.SendMessageContext(context.Background(), msg)
+}
+
+// SendMessageContext produces the message within a span continuing the
+// trace found in ctx.
+func (p *syncProducer) SendMessageContext(ctx context.Context, msg *sarama.ProducerMessage) (int32, int64, error) {
+	span := p.startProducerSpan(ctx, msg)
+
+	partition, offset, err := p.SyncProducer.SendMessage(msg)
+	if err == nil {
+		span.SetAttributes(attribute.MQPartition(partition), attribute.MQOffset(offset))
+	}
+	finishSpan(span, err)
+	return partition, offset, err
+}
+
+// SendMessages produces the messages within a span each, starting a new
+// trace.
+func (p *syncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	return p.SendMessagesContext(context.Background(), msgs)
+}
+
+// SendMessagesContext produces the messages within a span each, continuing
+// the trace found in ctx.
+func (p *syncProducer) SendMessagesContext(ctx context.Context, msgs []*sarama.ProducerMessage) error {
+	spans := make([]tracer.Span, len(msgs))
+	for i, msg := range msgs {
+		spans[i] = p.startProducerSpan(ctx, msg)
+	}
+
+	err := p.SyncProducer.SendMessages(msgs)
+
+	// The errors are reported per message, the other messages are produced.
+	failed := make(map[*sarama.ProducerMessage]error)
+	var producerErrs sarama.ProducerErrors
+	if errors.As(err, &producerErrs) {
+		for _, producerErr := range producerErrs {
+			failed[producerErr.Msg] = producerErr.Err
+		}
+	}
+	for i, msg := range msgs {
+		msgErr, ok := failed[msg]
+		switch {
+		case ok:
+		case err != nil && len(failed) == 0:
+			msgErr = err
+		default:
+			spans[i].SetAttributes(attribute.MQPartition(msg.Partition),
+				attribute.MQOffset(msg.Offset))
+		}
+		finishSpan(spans[i], msgErr)
+	}
+	return err
+}
+
+// startProducerSpan starts the exit span of the message and propagates its
+// trace context and the request id through the message headers.
+func (p *syncProducer) startProducerSpan(ctx context.Context, msg *sarama.ProducerMessage) tracer.Span {
+	ctx, span := p.cfg.startSpan(ctx, "Kafka/"+msg.Topic+"/Producer", msg.Topic,
+		tracer.SpanTypeExit)
+
+	carrier := NewProducerMessageCarrier(msg)
+	if propagator, ok := p.cfg.getTracer().(tracer.Propagator); ok {
+		propagator.Inject(ctx, carrier)
+	}
+	if requestID := reqid.RetrieveFromContext(ctx); requestID != "" {
+		carrier.Set(reqid.HeaderKey, requestID)
+		span.SetAttributes(attribute.RequestID(requestID))
+	}
+	return span
+}
@@ -0,0 +1,153 @@
+package tracing
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/IBM/sarama"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"github.com/rmscoal/tengcorux/tracer/tracetest"
+)
+
+// newTestingProducer returns a producer of a mock broker leading the
+// partition 0 of the orders topic, which replies with the given error.
+func newTestingProducer(t *testing.T, tr tracer.Tracer, kerr sarama.KError) (SyncProducer, *sarama.MockBroker) {
+	t.Helper()
+
+	broker := sarama.NewMockBroker(t, 1)
+	t.Cleanup(broker.Close)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader("orders", 0, broker.BrokerID()),
+		"ProduceRequest": sarama.NewMockProduceResponse(t).
+			SetError("orders", 0, kerr),
+	})
+
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V2_0_0_0
+	cfg.Producer.Return.Successes = true
+	cfg.Producer.Retry.Max = 0
+	producer, err := sarama.NewSyncProducer([]string{broker.Addr()}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = producer.Close() })
+
+	return WrapSyncProducer(producer, WithTracer(tr), WithBrokers(broker.Addr())), broker
+}
+
+func TestSyncProducer_SendMessageContext(t *testing.T) {
+	tr := tracetest.NewTracer()
+	producer, broker := newTestingProducer(t, tr, sarama.ErrNoError)
+
+	ctx, parent := tr.StartSpan(context.Background(), "parent")
+	ctx = reqid.InjectValue(ctx, "request-id")
+	msg := &sarama.ProducerMessage{Topic: "orders", Value: sarama.StringEncoder("order")}
+	if _, _, err := producer.SendMessageContext(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	span := tr.Recorder().EndedSpans()[0]
+	if span.Name != "Kafka/orders/Producer" {
+		t.Errorf("unexpected span name %s", span.Name)
+	} else if span.Type != tracer.SpanTypeExit || span.Layer != tracer.SpanLayerMQ {
+		t.Errorf("expected an exit mq span, got %d %d", span.Type, span.Layer)
+	} else if strconv.FormatUint(span.ParentSpanID, 10) != parent.Context().SpanID() {
+		t.Error("expected the span to continue the trace of the context")
+	}
+
+	carrier := NewProducerMessageCarrier(msg)
+	if carrier.Get(tracetest.SpanIDKey) != strconv.FormatUint(span.SpanID, 10) {
+		t.Error("expected the span context to be injected into the headers")
+	}
+	if carrier.Get(reqid.HeaderKey) != "request-id" {
+		t.Error("expected the request id to be injected into the headers")
+	}
+
+	tests := map[attribute.Key]attribute.Value{
+		attribute.MQSystemKey:          attribute.StringValue("kafka"),
+		attribute.MQTopicKey:           attribute.StringValue("orders"),
+		attribute.MQInstanceIDKey:      attribute.StringValue(broker.Addr()),
+		attribute.HTTPServerAddressKey: attribute.StringValue(broker.Addr()),
+		attribute.MQPartitionKey:       attribute.IntValue(0),
+		attribute.MQOffsetKey:          attribute.Int64Value(msg.Offset),
+		attribute.RequestIDKey:         attribute.StringValue("request-id"),
+	}
+	for key, want := range tests {
+		if got, _ := span.Attribute(key); got != want {
+			t.Errorf("%s: want %v, got %v", key, want, got)
+		}
+	}
+}
+
+func TestSyncProducer_SendMessage(t *testing.T) {
+	t.Run("Error", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		producer, _ := newTestingProducer(t, tr, sarama.ErrInvalidMessage)
+
+		_, _, err := producer.SendMessage(&sarama.ProducerMessage{
+			Topic: "orders", Value: sarama.StringEncoder("order"),
+		})
+		if err == nil {
+			t.Fatal("expected the produce to fail")
+		}
+
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Error == nil || span.Status != tracer.StatusError {
+			t.Error("expected the error to fail the span")
+		}
+		if _, ok := span.Attribute(attribute.MQOffsetKey); ok {
+			t.Error("expected no offset for a failed message")
+		}
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		producer, _ := newTestingProducer(t, tr, sarama.ErrNoError)
+
+		msgs := []*sarama.ProducerMessage{
+			{Topic: "orders", Value: sarama.StringEncoder("first")},
+			{Topic: "orders", Value: sarama.StringEncoder("second")},
+		}
+		if err := producer.SendMessages(msgs); err != nil {
+			t.Fatal(err)
+		}
+
+		spans := tr.Recorder().EndedSpans()
+		if len(spans) != 2 {
+			t.Fatalf("expected a span per message, got %d", len(spans))
+		}
+		for i, span := range spans {
+			if span.Error != nil {
+				t.Errorf("unexpected error %v", span.Error)
+			}
+			if got, _ := span.Attribute(attribute.MQOffsetKey); got.AsInt64() != msgs[i].Offset {
+				t.Errorf("expected offset %d, got %v", msgs[i].Offset, got)
+			}
+		}
+	})
+	t.Run("Batch Error", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		producer, _ := newTestingProducer(t, tr, sarama.ErrInvalidMessage)
+
+		err := producer.SendMessages([]*sarama.ProducerMessage{
+			{Topic: "orders", Value: sarama.StringEncoder("first")},
+			{Topic: "orders", Value: sarama.StringEncoder("second")},
+		})
+		if err == nil {
+			t.Fatal("expected the produce to fail")
+		}
+
+		for _, span := range tr.Recorder().EndedSpans() {
+			if span.Error == nil || span.Status != tracer.StatusError {
+				t.Error("expected the error to fail the span of each message")
+			}
+		}
+	})
+}
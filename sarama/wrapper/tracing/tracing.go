@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// config holds the configuration shared by the wrappers.
+type config struct {
+	tracer        tracer.Tracer
+	brokers       []string
+	consumerGroup string
+}
+
+func newConfig(opts ...Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *config) getTracer() tracer.Tracer {
+	if c.tracer != nil {
+		return c.tracer
+	}
+	return tracer.GetGlobalTracer()
+}
+
+// startSpan starts the span of a message of the topic, recording the
+// attributes shared by the producer and consumer spans. The brokers are
+// recorded as the server address, the peer of the span, joined like the
+// instance id since a message is not bound to a single broker.
+func (c *config) startSpan(ctx context.Context, name, topic string,
+	spanType tracer.SpanType,
+) (context.Context, tracer.Span) {
+	ctx, span := c.getTracer().StartSpan(ctx, name,
+		tracer.WithSpanType(spanType),
+		tracer.WithSpanLayer(tracer.SpanLayerMQ),
+	)
+
+	span.SetAttributes(
+		attribute.MQSystem("kafka"),
+		attribute.MQTopic(topic),
+	)
+	if len(c.brokers) > 0 {
+		brokers := strings.Join(c.brokers, ",")
+		span.SetAttributes(
+			attribute.MQInstanceID(brokers),
+			attribute.HTTPServerAddress(brokers),
+		)
+	}
+	return ctx, span
+}
+
+// finishSpan records the error, if any, and ends the span.
+func finishSpan(span tracer.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(tracer.StatusError, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,182 @@
+// Package tracing provides a log/slog handler correlating the logs with the
+// traces of tengcorux's tracer package.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+const (
+	// TraceIDKey is the record attribute key of the active trace id.
+	TraceIDKey = "trace_id"
+	// SpanIDKey is the record attribute key of the active span id.
+	SpanIDKey = "span_id"
+	// RequestIDKey is the record attribute key of the request id.
+	RequestIDKey = "request_id"
+
+	// logSeverityKey is the event attribute key of the record level.
+	logSeverityKey = "log.severity"
+)
+
+type config struct {
+	tracer       tracer.Tracer
+	eventLevel   slog.Leveler
+	recordErrors bool
+}
+
+func (cfg *config) getTracer() tracer.Tracer {
+	if cfg.tracer != nil {
+		return cfg.tracer
+	}
+	return tracer.GetGlobalTracer()
+}
+
+// Handler is a slog.Handler adding the trace id, span id and request id found
+// in the context to every record before passing it to the wrapped handler.
+// Attributes are added to the top level of the record, unless the logger was
+// derived with WithGroup.
+type Handler struct {
+	next slog.Handler
+	cfg  *config
+}
+
+// Make sure that Handler implements slog.Handler during compile time.
+var _ slog.Handler = (*Handler)(nil)
+
+// NewHandler wraps the given handler. For example:
+//
+//	logger := slog.New(tracing.NewHandler(slog.NewJSONHandler(os.Stdout, nil),
+//		tracing.WithSpanEvents(slog.LevelWarn), tracing.WithRecordErrors()))
+//	logger.InfoContext(ctx, "user created")
+func NewHandler(next slog.Handler, opts ...Option) *Handler {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Handler{next: next, cfg: cfg}
+}
+
+// Enabled reports whether the wrapped handler handles records at the level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds the ids found in the context to the record, mirrors it onto the
+// active span if opted, and passes it to the wrapped handler.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx == nil {
+		return h.next.Handle(ctx, record)
+	}
+
+	var attrs []slog.Attr
+	span := h.cfg.getTracer().SpanFromContext(ctx)
+	if span != nil {
+		if traceID := span.Context().TraceID(); traceID != "" {
+			attrs = append(attrs, slog.String(TraceIDKey, traceID))
+		}
+		if spanID := span.Context().SpanID(); spanID != "" {
+			attrs = append(attrs, slog.String(SpanIDKey, spanID))
+		}
+		if span.IsRecording() {
+			h.mirror(span, record)
+		}
+	}
+	if requestID := reqid.RetrieveFromContext(ctx); requestID != "" {
+		attrs = append(attrs, slog.String(RequestIDKey, requestID))
+	}
+
+	if len(attrs) > 0 {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs returns a Handler wrapping the handler with the attributes.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{next: h.next.WithAttrs(attrs), cfg: h.cfg}
+}
+
+// WithGroup returns a Handler wrapping the handler with the group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), cfg: h.cfg}
+}
+
+// mirror adds the record as an event or records its error onto the span.
+func (h *Handler) mirror(span tracer.Span, record slog.Record) {
+	if h.cfg.eventLevel != nil && record.Level >= h.cfg.eventLevel.Level() {
+		attrs := []attribute.KeyValue{
+			attribute.String(logSeverityKey, record.Level.String()),
+		}
+		record.Attrs(func(attr slog.Attr) bool {
+			attrs = append(attrs, mapAttr("", attr)...)
+			return true
+		})
+
+		span.AddEvent(record.Message,
+			tracer.WithTimestamp(record.Time),
+			tracer.WithEventAttributes(attrs...))
+	}
+
+	if h.cfg.recordErrors && record.Level >= slog.LevelError {
+		var err error
+		record.Attrs(func(attr slog.Attr) bool {
+			if e, ok := attr.Value.Resolve().Any().(error); ok {
+				err = e
+				return false
+			}
+			return true
+		})
+		if err == nil {
+			err = errors.New(record.Message)
+		}
+
+		span.RecordError(err)
+	}
+}
+
+// mapAttr maps a slog.Attr into tracer attributes, flattening the groups
+// with a "." separator.
+func mapAttr(prefix string, attr slog.Attr) []attribute.KeyValue {
+	value := attr.Value.Resolve()
+	key := attr.Key
+	if prefix != "" {
+		key = prefix + "." + key
+	}
+
+	switch value.Kind() {
+	case slog.KindGroup:
+		var attrs []attribute.KeyValue
+		for _, groupAttr := range value.Group() {
+			attrs = append(attrs, mapAttr(key, groupAttr)...)
+		}
+		return attrs
+	case slog.KindString:
+		return []attribute.KeyValue{attribute.String(key, value.String())}
+	case slog.KindInt64:
+		return []attribute.KeyValue{attribute.Int64(key, value.Int64())}
+	case slog.KindUint64:
+		return []attribute.KeyValue{attribute.Int64(key, int64(value.Uint64()))}
+	case slog.KindFloat64:
+		return []attribute.KeyValue{attribute.Float64(key, value.Float64())}
+	case slog.KindBool:
+		return []attribute.KeyValue{attribute.Bool(key, value.Bool())}
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			return []attribute.KeyValue{attribute.String(key, err.Error())}
+		}
+		return []attribute.KeyValue{attribute.String(key, fmt.Sprint(value.Any()))}
+	default:
+		// Durations, times and log valuers are emitted as their string.
+		return []attribute.KeyValue{attribute.String(key, value.String())}
+	}
+}
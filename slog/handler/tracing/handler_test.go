@@ -0,0 +1,199 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rmscoal/tengcorux/reqid"
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"github.com/rmscoal/tengcorux/tracer/tracetest"
+)
+
+func newLogger(opts ...Option) (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	handler := NewHandler(slog.NewJSONHandler(&buf, nil), opts...)
+	return slog.New(handler), &buf
+}
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	record := make(map[string]any)
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+	buf.Reset()
+	return record
+}
+
+func TestHandler_Handle(t *testing.T) {
+	tr := tracetest.NewTracer()
+
+	t.Run("Span And Request ID", func(t *testing.T) {
+		logger, buf := newLogger(WithTracer(tr))
+		ctx, span := tr.StartSpan(reqid.InjectValue(context.Background(), "req-1"),
+			"operation")
+		defer span.End()
+
+		logger.InfoContext(ctx, "hello", slog.String("user", "john"))
+
+		record := decode(t, buf)
+		if record[TraceIDKey] != span.Context().TraceID() {
+			t.Errorf("expected trace id %s but got %v",
+				span.Context().TraceID(), record[TraceIDKey])
+		}
+		if record[SpanIDKey] != span.Context().SpanID() {
+			t.Errorf("expected span id %s but got %v",
+				span.Context().SpanID(), record[SpanIDKey])
+		}
+		if record[RequestIDKey] != "req-1" {
+			t.Errorf("expected request id req-1 but got %v", record[RequestIDKey])
+		}
+		if record["user"] != "john" {
+			t.Errorf("expected the record attributes to be kept but got %v", record)
+		}
+	})
+
+	t.Run("No Span", func(t *testing.T) {
+		logger, buf := newLogger(WithTracer(tr))
+		logger.InfoContext(context.Background(), "hello")
+
+		record := decode(t, buf)
+		for _, key := range []string{TraceIDKey, SpanIDKey, RequestIDKey} {
+			if _, ok := record[key]; ok {
+				t.Errorf("expected %s to be omitted", key)
+			}
+		}
+	})
+
+	t.Run("Global Tracer", func(t *testing.T) {
+		tracer.SetGlobalTracer(tr)
+		defer tracer.SetGlobalTracer(&tracer.NoopTracer{})
+
+		logger, buf := newLogger()
+		ctx, span := tr.StartSpan(context.Background(), "operation")
+		defer span.End()
+
+		logger.InfoContext(ctx, "hello")
+		if record := decode(t, buf); record[TraceIDKey] != span.Context().TraceID() {
+			t.Errorf("expected trace id %s but got %v",
+				span.Context().TraceID(), record[TraceIDKey])
+		}
+	})
+
+	t.Run("With Attrs", func(t *testing.T) {
+		logger, buf := newLogger(WithTracer(tr))
+		ctx := reqid.InjectValue(context.Background(), "req-2")
+
+		logger.With("component", "billing").InfoContext(ctx, "hello")
+		record := decode(t, buf)
+		if record["component"] != "billing" || record[RequestIDKey] != "req-2" {
+			t.Errorf("unexpected record %v", record)
+		}
+	})
+
+	t.Run("With Group", func(t *testing.T) {
+		logger, buf := newLogger(WithTracer(tr))
+		ctx := reqid.InjectValue(context.Background(), "req-3")
+
+		logger.WithGroup("billing").InfoContext(ctx, "hello")
+		record := decode(t, buf)
+		group, ok := record["billing"].(map[string]any)
+		if !ok || group[RequestIDKey] != "req-3" {
+			t.Errorf("expected the request id within the group but got %v", record)
+		}
+	})
+}
+
+func TestHandler_Mirror(t *testing.T) {
+	t.Run("Span Events", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		logger, _ := newLogger(WithTracer(tr), WithSpanEvents(slog.LevelWarn))
+		ctx, span := tr.StartSpan(context.Background(), "operation")
+
+		logger.InfoContext(ctx, "ignored")
+		logger.WarnContext(ctx, "slow query",
+			slog.Duration("elapsed", time.Second),
+			slog.Group("db", slog.String("table", "users"), slog.Int("rows", 3)))
+		span.End()
+
+		events := tr.Recorder().EndedSpans()[0].Events
+		if len(events) != 1 {
+			t.Fatalf("expected 1 event but got %d", len(events))
+		}
+		if events[0].Name != "slow query" {
+			t.Errorf("expected event slow query but got %s", events[0].Name)
+		}
+
+		wants := map[attribute.Key]attribute.Value{
+			logSeverityKey: attribute.StringValue("WARN"),
+			"elapsed":      attribute.StringValue("1s"),
+			"db.table":     attribute.StringValue("users"),
+			"db.rows":      attribute.Int64Value(3),
+		}
+		for key, want := range wants {
+			if got, _ := events[0].Attribute(key); got != want {
+				t.Errorf("expected %s %v but got %v", key, want, got)
+			}
+		}
+	})
+
+	t.Run("Record Errors", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		logger, _ := newLogger(WithTracer(tr), WithRecordErrors())
+		errFailed := errors.New("connection refused")
+
+		ctx, span := tr.StartSpan(context.Background(), "with error")
+		logger.WarnContext(ctx, "ignored", slog.Any("error", errors.New("warn")))
+		logger.ErrorContext(ctx, "failed to charge", slog.Any("error", errFailed))
+		span.End()
+
+		ctx, span = tr.StartSpan(context.Background(), "without error")
+		logger.ErrorContext(ctx, "failed to charge")
+		span.End()
+
+		spans := tr.Recorder().EndedSpans()
+		if !errors.Is(spans[0].Error, errFailed) {
+			t.Errorf("expected the error attribute to be recorded but got %v",
+				spans[0].Error)
+		}
+		if spans[0].Status != tracer.StatusUnset {
+			t.Errorf("expected status to be Unset but got %s", spans[0].Status)
+		}
+		if spans[1].Error == nil || spans[1].Error.Error() != "failed to charge" {
+			t.Errorf("expected the message to be recorded but got %v",
+				spans[1].Error)
+		}
+		if len(spans[0].Events) != 0 {
+			t.Errorf("expected no events but got %d", len(spans[0].Events))
+		}
+	})
+}
+
+func TestMapAttr(t *testing.T) {
+	tests := []struct {
+		attr slog.Attr
+		want attribute.Value
+	}{
+		{slog.String("k", "v"), attribute.StringValue("v")},
+		{slog.Int64("k", -1), attribute.Int64Value(-1)},
+		{slog.Uint64("k", 1), attribute.Int64Value(1)},
+		{slog.Float64("k", 1.5), attribute.Float64Value(1.5)},
+		{slog.Bool("k", true), attribute.BoolValue(true)},
+		{slog.Any("k", errors.New("boom")), attribute.StringValue("boom")},
+		{slog.Any("k", []int{1, 2}), attribute.StringValue("[1 2]")},
+	}
+
+	for _, test := range tests {
+		got := mapAttr("", test.attr)
+		if len(got) != 1 || got[0].Key != "k" || got[0].Value != test.want {
+			t.Errorf("expected %v but got %v", test.want, got)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package tracing
+
+import (
+	"log/slog"
+
+	"github.com/rmscoal/tengcorux/tracer"
+)
+
+type Option func(*config)
+
+// WithTracer uses the given tracer instead of the global tracer.
+func WithTracer(t tracer.Tracer) Option {
+	return func(cfg *config) {
+		cfg.tracer = t
+	}
+}
+
+// WithSpanEvents mirrors the records at or above the given level, such as
+// slog.LevelWarn, onto the active span as events named after the message.
+func WithSpanEvents(level slog.Leveler) Option {
+	return func(cfg *config) {
+		cfg.eventLevel = level
+	}
+}
+
+// WithRecordErrors records the records at or above slog.LevelError onto the
+// active span as errors. The error is the first error attribute of the
+// record, or the message otherwise. The span status is left untouched.
+func WithRecordErrors() Option {
+	return func(cfg *config) {
+		cfg.recordErrors = true
+	}
+}
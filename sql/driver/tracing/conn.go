@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+// conn wraps a driver.Conn. The optional interfaces of the underlying
+// connection that are not implemented fall back to the behavior database/sql
+// has without them.
+type conn struct {
+	driver.Conn
+	cfg *config
+}
+
+// Make sure that conn implements the optional interfaces during compile
+// time.
+var (
+	_ driver.ConnPrepareContext = (*conn)(nil)
+	_ driver.ConnBeginTx        = (*conn)(nil)
+	_ driver.ExecerContext      = (*conn)(nil)
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.Pinger             = (*conn)(nil)
+	_ driver.SessionResetter    = (*conn)(nil)
+	_ driver.Validator          = (*conn)(nil)
+	_ driver.NamedValueChecker  = (*conn)(nil)
+)
+
+// Prepare prepares a traced statement.
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext prepares a traced statement within a span.
+func (c *conn) PrepareContext(ctx context.Context, query string) (_ driver.Stmt, err error) {
+	_, span := c.cfg.startSpan(ctx, operationPrepare, query)
+	defer func() { finishSpan(span, err) }()
+
+	var s driver.Stmt
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		s, err = preparer.PrepareContext(ctx, query)
+	} else {
+		s, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{Stmt: s, cfg: c.cfg, query: query}, nil
+}
+
+// Begin starts a traced transaction.
+//
+// Deprecated: Drivers should implement ConnBeginTx instead (or additionally).
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx starts a traced transaction within a span.
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (_ driver.Tx, err error) {
+	_, span := c.cfg.startSpan(ctx, operationBegin, "")
+	defer func() { finishSpan(span, err) }()
+
+	var t driver.Tx
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		t, err = beginner.BeginTx(ctx, opts)
+	} else {
+		// Mirrors database/sql for drivers not supporting the options.
+		if opts.Isolation != driver.IsolationLevel(0) {
+			return nil, errors.New("sql: driver does not support non-default isolation level")
+		}
+		if opts.ReadOnly {
+			return nil, errors.New("sql: driver does not support read-only transactions")
+		}
+		t, err = c.Conn.Begin()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tx{Tx: t, cfg: c.cfg, ctx: ctx}, nil
+}
+
+// ExecContext executes the query within a span, through the legacy
+// driver.Execer when the underlying connection lacks driver.ExecerContext.
+// It returns driver.ErrSkip when the underlying connection does not support
+// executing without preparing, such that database/sql prepares a traced
+// statement instead. The span is then discarded, such that the query is
+// traced once.
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (_ driver.Result, err error) {
+	execerContext, ok := c.Conn.(driver.ExecerContext)
+	execer, legacy := c.Conn.(driver.Execer)
+	if !ok && !legacy {
+		return nil, driver.ErrSkip
+	}
+
+	_, span := c.cfg.startSpan(ctx, statementOperation(query, "EXEC"), query)
+	defer func() { finishSpan(span, err) }()
+
+	if ok {
+		return execerContext.ExecContext(ctx, query, args)
+	}
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return execer.Exec(query, values)
+}
+
+// QueryContext executes the query within a span and traces the iteration of
+// its rows, through the legacy driver.Queryer when the underlying connection
+// lacks driver.QueryerContext. It returns driver.ErrSkip when the underlying
+// connection does not support querying without preparing, such that
+// database/sql prepares a traced statement instead. The span is then
+// discarded, such that the query is traced once.
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (_ driver.Rows, err error) {
+	queryerContext, ok := c.Conn.(driver.QueryerContext)
+	queryer, legacy := c.Conn.(driver.Queryer)
+	if !ok && !legacy {
+		return nil, driver.ErrSkip
+	}
+
+	_, span := c.cfg.startSpan(ctx, statementOperation(query, "QUERY"), query)
+	defer func() { finishSpan(span, err) }()
+
+	var r driver.Rows
+	if ok {
+		r, err = queryerContext.QueryContext(ctx, query, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err != nil {
+			return nil, err
+		}
+		r, err = queryer.Query(query, values)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newRows(ctx, c.cfg, r), nil
+}
+
+// Ping verifies the connection if the underlying connection supports it.
+func (c *conn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession resets the connection if the underlying connection supports
+// it.
+func (c *conn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid reports whether the underlying connection is still valid.
+func (c *conn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue checks the argument with the underlying connection, or
+// returns driver.ErrSkip to use the default conversion.
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
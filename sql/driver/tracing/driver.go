@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+// Open opens a database like sql.Open, except that every operation of the
+// returned database is traced. The database system is derived from the
+// driver name unless WithDBSystem is given. For example:
+//
+//	db, err := tracing.Open("postgres", dsn, tracing.WithDBName("reports"))
+//
+// The returned database may be used as is or wrapped by sqlx.NewDb.
+func Open(driverName, dataSourceName string, opts ...Option) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	d := db.Driver()
+	_ = db.Close()
+
+	opts = append([]Option{WithDBSystem(mapDBSystem(driverName))}, opts...)
+	connector, err := Wrap(d, opts...).(driver.DriverContext).OpenConnector(dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// Wrap returns a driver tracing every operation of the given driver. The
+// returned driver may be registered through sql.Register.
+func Wrap(d driver.Driver, opts ...Option) driver.Driver {
+	return &tracingDriver{Driver: d, cfg: newConfig(opts...)}
+}
+
+// WrapConnector returns a connector tracing every operation of the given
+// connector, to be opened through sql.OpenDB.
+func WrapConnector(c driver.Connector, opts ...Option) driver.Connector {
+	return &connector{Connector: c, cfg: newConfig(opts...)}
+}
+
+// tracingDriver wraps a driver.Driver.
+type tracingDriver struct {
+	driver.Driver
+	cfg *config
+}
+
+// Make sure that tracingDriver implements [driver.DriverContext] during
+// compile time.
+var _ driver.DriverContext = (*tracingDriver)(nil)
+
+// Open returns a new traced connection to the database.
+func (d *tracingDriver) Open(name string) (driver.Conn, error) {
+	c, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: c, cfg: d.cfg}, nil
+}
+
+// OpenConnector returns a traced connector of the underlying driver, or one
+// calling Open when the underlying driver is not a driver.DriverContext.
+func (d *tracingDriver) OpenConnector(name string) (driver.Connector, error) {
+	if dc, ok := d.Driver.(driver.DriverContext); ok {
+		c, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err
+		}
+		return &connector{Connector: c, cfg: d.cfg}, nil
+	}
+	return &connector{Connector: dsnConnector{name: name, driver: d.Driver}, cfg: d.cfg}, nil
+}
+
+// connector wraps a driver.Connector.
+type connector struct {
+	driver.Connector
+	cfg *config
+}
+
+// Connect returns a new traced connection to the database.
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: cn, cfg: c.cfg}, nil
+}
+
+// Driver returns the traced underlying driver of the connector.
+func (c *connector) Driver() driver.Driver {
+	return &tracingDriver{Driver: c.Connector.Driver(), cfg: c.cfg}
+}
+
+// dsnConnector is the connector of a driver that is not a
+// driver.DriverContext, as done by database/sql.
+type dsnConnector struct {
+	name   string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
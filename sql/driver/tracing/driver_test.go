@@ -0,0 +1,194 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/rmscoal/tengcorux/tracer/tracetest"
+)
+
+// fakeDriver implements none of the optional interfaces, such that every
+// fallback of the wrappers is exercised.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+// skippingConn supports executing and querying without preparing, but skips
+// them like drivers that need to prepare the statement with arguments.
+type skippingConn struct{ fakeConn }
+
+func (skippingConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return nil, driver.ErrSkip
+}
+
+func (skippingConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return nil, driver.ErrSkip
+}
+
+// legacyConn executes and queries without preparing through the deprecated
+// driver.Execer and driver.Queryer.
+type legacyConn struct{ fakeConn }
+
+func (legacyConn) Exec(query string, args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(len(args)), nil
+}
+
+func (legacyConn) Query(query string, args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{}, nil
+}
+
+// connDriver opens the given connection.
+type connDriver struct{ conn driver.Conn }
+
+func (d connDriver) Open(string) (driver.Conn, error) { return d.conn, nil }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(len(args)), nil
+}
+
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return errors.New("rollback failed") }
+
+type fakeRows struct{ next int }
+
+func (*fakeRows) Columns() []string { return []string{"n"} }
+func (*fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == 3 {
+		return io.EOF
+	}
+	r.next++
+	dest[0] = int64(r.next)
+	return nil
+}
+
+func TestWrap(t *testing.T) {
+	tr := tracetest.NewTracer()
+	driverName := "fake-tracing"
+	sql.Register(driverName, Wrap(fakeDriver{}, WithTracer(tr)))
+
+	db, err := sql.Open(driverName, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		t.Fatalf("expected the missing pinger to be ignored, got %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO t VALUES (?)", 1); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	if err := db.QueryRow("SELECT n FROM t").Scan(&n); err != nil {
+		t.Fatal(err)
+	} else if n != 1 {
+		t.Errorf("expected 1, got %d", n)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err == nil {
+		t.Error("expected the rollback error")
+	}
+
+	spans := tr.Recorder().EndedSpans()
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name
+	}
+	want := []string{"SQL PREPARE", "SQL INSERT", "SQL PREPARE", "SQL SELECT",
+		"SQL ROWS", "SQL BEGIN", "SQL ROLLBACK"}
+	if fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Errorf("want spans %v, got %v", want, names)
+	}
+	if rollback := spans[len(spans)-1]; rollback.Error == nil {
+		t.Error("expected the rollback error to be recorded")
+	}
+
+	t.Run("Unsupported Transaction Options", func(t *testing.T) {
+		_, err := db.BeginTx(context.Background(), &sql.TxOptions{ReadOnly: true})
+		if err == nil {
+			t.Error("expected read-only transactions to be unsupported")
+		}
+	})
+}
+
+func TestWrap_WithoutPreparing(t *testing.T) {
+	tests := []struct {
+		name string
+		conn driver.Conn
+		want []string
+	}{
+		{"Skipped", skippingConn{}, []string{"SQL PREPARE", "SQL INSERT",
+			"SQL PREPARE", "SQL SELECT", "SQL ROWS"}},
+		{"Legacy", legacyConn{}, []string{"SQL INSERT", "SQL SELECT", "SQL ROWS"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := tracetest.NewTracer()
+			db := sql.OpenDB(WrapConnector(dsnConnector{driver: connDriver{tc.conn}},
+				WithTracer(tr)))
+			defer db.Close()
+
+			if _, err := db.Exec("INSERT INTO t VALUES (?)", 1); err != nil {
+				t.Fatal(err)
+			}
+			var n int
+			if err := db.QueryRow("SELECT n FROM t").Scan(&n); err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, span := range tr.Recorder().EndedSpans() {
+				names = append(names, span.Name)
+			}
+			if fmt.Sprint(names) != fmt.Sprint(tc.want) {
+				t.Errorf("want spans %v, got %v", tc.want, names)
+			}
+		})
+	}
+}
+
+func TestWrapConnector(t *testing.T) {
+	tr := tracetest.NewTracer()
+	db := sql.OpenDB(WrapConnector(dsnConnector{driver: fakeDriver{}},
+		WithTracer(tr)))
+	defer db.Close()
+
+	if _, ok := db.Driver().(*tracingDriver); !ok {
+		t.Errorf("expected the traced driver, got %T", db.Driver())
+	}
+	if _, err := db.Exec("DELETE FROM t"); err != nil {
+		t.Fatal(err)
+	}
+	if len(tr.Recorder().EndedSpans()) != 2 {
+		t.Errorf("expected 2 spans, got %d", len(tr.Recorder().EndedSpans()))
+	}
+}
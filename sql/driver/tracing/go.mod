@@ -0,0 +1,10 @@
+module github.com/rmscoal/tengcorux/sql/driver/tracing
+
+go 1.21
+
+require (
+	github.com/mattn/go-sqlite3 v1.14.22
+	github.com/rmscoal/tengcorux/tracer v0.1.5-0.20261017175312-3ec99204e181
+)
+
+replace github.com/rmscoal/tengcorux/tracer => ../../../tracer
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+type Option func(*config)
+
+// WithTracer uses the given tracer instead of the global tracer.
+func WithTracer(t tracer.Tracer) Option {
+	return func(c *config) {
+		c.tracer = t
+	}
+}
+
+// WithSpanNameGenerator replaces the default span name generator, which
+// returns "SQL <operation>", for example "SQL SELECT" or "SQL COMMIT".
+func WithSpanNameGenerator(gen SpanNameGenerator) Option {
+	return func(c *config) {
+		if gen != nil {
+			c.spanNameGenerator = gen
+		}
+	}
+}
+
+// WithDBSystem records the given database system, such as [PostgreSQL].
+// Open derives it from the driver name when it is not given.
+func WithDBSystem(system string) Option {
+	return func(c *config) {
+		c.dbSystem = system
+	}
+}
+
+// WithDBName records the given database name.
+func WithDBName(name string) Option {
+	return func(c *config) {
+		c.dbName = name
+	}
+}
+
+// WithRedactor replaces the default redactor applied to the span
+// attributes. By default, [attribute.DefaultRedactor] is used and passing
+// nil disables redaction.
+func WithRedactor(redactor *attribute.Redactor) Option {
+	return func(c *config) {
+		c.redactor = redactor
+	}
+}
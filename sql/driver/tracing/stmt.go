@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+// stmt wraps a driver.Stmt prepared from the query.
+type stmt struct {
+	driver.Stmt
+	cfg   *config
+	query string
+}
+
+// Make sure that stmt implements the optional interfaces during compile
+// time.
+var (
+	_ driver.StmtExecContext   = (*stmt)(nil)
+	_ driver.StmtQueryContext  = (*stmt)(nil)
+	_ driver.NamedValueChecker = (*stmt)(nil)
+)
+
+// Exec executes the statement.
+//
+// Deprecated: Drivers should implement StmtExecContext instead (or additionally).
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), valuesToNamedValues(args))
+}
+
+// ExecContext executes the statement within a span.
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (_ driver.Result, err error) {
+	_, span := s.cfg.startSpan(ctx, statementOperation(s.query, "EXEC"), s.query)
+	defer func() { finishSpan(span, err) }()
+
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.Exec(values)
+}
+
+// Query executes the statement.
+//
+// Deprecated: Drivers should implement StmtQueryContext instead (or additionally).
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), valuesToNamedValues(args))
+}
+
+// QueryContext executes the statement within a span and traces the
+// iteration of its rows.
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (_ driver.Rows, err error) {
+	_, span := s.cfg.startSpan(ctx, statementOperation(s.query, "QUERY"), s.query)
+	defer func() { finishSpan(span, err) }()
+
+	var r driver.Rows
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		r, err = queryer.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			r, err = s.Stmt.Query(values)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newRows(ctx, s.cfg, r), nil
+}
+
+// CheckNamedValue checks the argument with the underlying statement, or
+// returns driver.ErrSkip to use the default conversion.
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+func valuesToNamedValues(values []driver.Value) []driver.NamedValue {
+	args := make([]driver.NamedValue, len(values))
+	for i, value := range values {
+		args[i] = driver.NamedValue{Ordinal: i + 1, Value: value}
+	}
+	return args
+}
+
+// namedValuesToValues mirrors database/sql for the drivers not supporting
+// named arguments.
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("sql: driver does not support the use of Named Parameters")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
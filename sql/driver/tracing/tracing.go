@@ -0,0 +1,164 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// SpanNameGenerator returns the name of the span of an operation given the
+// operation, such as "SELECT" or "COMMIT", and its statement if any.
+type SpanNameGenerator func(operation, query string) string
+
+var _defaultSpanNameGenerator SpanNameGenerator = func(operation, query string) string {
+	return "SQL " + operation
+}
+
+// Operations of the spans that are not a statement.
+const (
+	operationPrepare  = "PREPARE"
+	operationBegin    = "BEGIN"
+	operationCommit   = "COMMIT"
+	operationRollback = "ROLLBACK"
+	operationRows     = "ROWS"
+)
+
+// config holds the configuration shared by the wrapped driver, its
+// connections, statements, transactions and rows.
+type config struct {
+	tracer            tracer.Tracer
+	spanNameGenerator SpanNameGenerator
+	dbSystem          string
+	dbName            string
+	redactor          *attribute.Redactor
+}
+
+func newConfig(opts ...Option) *config {
+	c := &config{
+		spanNameGenerator: _defaultSpanNameGenerator,
+		redactor:          attribute.DefaultRedactor(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *config) getTracer() tracer.Tracer {
+	if c.tracer != nil {
+		return c.tracer
+	}
+	return tracer.GetGlobalTracer()
+}
+
+// startSpan starts the span of an operation, along its statement if any.
+func (c *config) startSpan(ctx context.Context, operation, query string) (context.Context, tracer.Span) {
+	ctx, span := c.getTracer().StartSpan(ctx,
+		c.spanNameGenerator(operation, query),
+		tracer.WithSpanType(tracer.SpanTypeLocal),
+		tracer.WithSpanLayer(tracer.SpanLayerDatabase),
+	)
+
+	attrs := []attribute.KeyValue{attribute.DBOperation(operation)}
+	if query != "" {
+		attrs = append(attrs, attribute.DBStatement(query))
+	}
+	if c.dbSystem != "" {
+		attrs = append(attrs, attribute.DBSystem(c.dbSystem))
+	}
+	if c.dbName != "" {
+		attrs = append(attrs, attribute.DBName(c.dbName))
+	}
+	span.SetAttributes(c.redactor.Redact(attrs...)...)
+	return ctx, span
+}
+
+// finishSpan records the error of the operation, if any, and ends the span.
+// The span is discarded on driver.ErrSkip, since database/sql then falls
+// back to another operation which is traced on its own.
+func finishSpan(span tracer.Span, err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		if discardable, ok := span.(tracer.DiscardableSpan); ok {
+			discardable.Discard()
+			return
+		}
+	}
+	defer span.End()
+
+	switch {
+	case err == nil,
+		errors.Is(err, io.EOF),
+		errors.Is(err, driver.ErrSkip):
+		// We ignore these errors
+	default:
+		span.RecordError(err)
+		span.SetStatus(tracer.StatusError, err.Error())
+	}
+}
+
+// statementOperation returns the uppercased leading keyword of the query,
+// such as "SELECT", skipping the leading comments. It returns the fallback
+// when the query has no keyword.
+func statementOperation(query, fallback string) string {
+	for {
+		query = strings.TrimSpace(query)
+		switch {
+		case strings.HasPrefix(query, "--"):
+			end := strings.IndexByte(query, '\n')
+			if end < 0 {
+				return fallback
+			}
+			query = query[end+1:]
+		case strings.HasPrefix(query, "/*"):
+			end := strings.Index(query, "*/")
+			if end < 0 {
+				return fallback
+			}
+			query = query[end+2:]
+		default:
+			end := strings.IndexFunc(query, func(r rune) bool {
+				return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z')
+			})
+			if end < 0 {
+				end = len(query)
+			}
+			if end == 0 {
+				return fallback
+			}
+			return strings.ToUpper(query[:end])
+		}
+	}
+}
+
+const (
+	MySQL      = "MySQL"
+	MsSQL      = "Microsoft SQL Server"
+	PostgreSQL = "PostgreSQL"
+	SQLite     = "SQLite"
+	SQLServer  = "SQL Server"
+)
+
+// mapDBSystem maps the name of a known driver registered to database/sql.
+// The known drivers are "mysql", "mssql", "postgres", "pgx", "sqlite",
+// "sqlite3" and "sqlserver". Unknown drivers will return an empty string.
+func mapDBSystem(driverName string) string {
+	switch driverName {
+	case "mysql":
+		return MySQL
+	case "mssql":
+		return MsSQL
+	case "postgres", "postgresql", "pgx":
+		return PostgreSQL
+	case "sqlite", "sqlite3":
+		return SQLite
+	case "sqlserver":
+		return SQLServer
+	default:
+		return ""
+	}
+}
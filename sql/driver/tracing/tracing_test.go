@@ -0,0 +1,336 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+	"github.com/rmscoal/tengcorux/tracer/tracetest"
+)
+
+// openTestingDB opens an in-memory SQLite database holding a users table.
+// The table is created through a plain connection sharing the database,
+// such that only the operations of the test are traced.
+func openTestingDB(t *testing.T, opts ...Option) (*sql.DB, *tracetest.Tracer) {
+	t.Helper()
+
+	dsn := "file:" + url.PathEscape(t.Name()) + "?mode=memory&cache=shared"
+	plain, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = plain.Close() })
+	_, err = plain.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT);" +
+		"INSERT INTO users (name) VALUES ('alice'), ('bob')")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tracetest.NewTracer()
+	db, err := Open("sqlite3", dsn, append([]Option{WithTracer(tr)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db, tr
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("Exec", func(t *testing.T) {
+		db, tr := openTestingDB(t, WithDBName("main"))
+
+		_, err := db.ExecContext(context.Background(),
+			"UPDATE users SET name = ? WHERE id = ?", "carol", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		spans := tr.Recorder().EndedSpans()
+		if len(spans) != 1 {
+			t.Fatalf("expected 1 span, got %d", len(spans))
+		}
+		span := spans[0]
+		if span.Name != "SQL UPDATE" {
+			t.Errorf("unexpected span name %s", span.Name)
+		} else if span.Layer != tracer.SpanLayerDatabase {
+			t.Errorf("expected database layer, got %d", span.Layer)
+		}
+
+		tests := map[attribute.Key]attribute.Value{
+			attribute.DBSystemKey:    attribute.StringValue(SQLite),
+			attribute.DBNameKey:      attribute.StringValue("main"),
+			attribute.DBOperationKey: attribute.StringValue("UPDATE"),
+			attribute.DBStatementKey: attribute.StringValue("UPDATE users SET name = ? WHERE id = ?"),
+		}
+		for key, want := range tests {
+			if got, _ := span.Attribute(key); got != want {
+				t.Errorf("%s: want %v, got %v", key, want, got)
+			}
+		}
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		db, tr := openTestingDB(t)
+
+		rows, err := db.Query("SELECT id, name FROM users ORDER BY id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for rows.Next() {
+			var id int
+			var name string
+			if err := rows.Scan(&id, &name); err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, name)
+		}
+		if err := rows.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if len(names) != 2 {
+			t.Errorf("expected 2 users, got %v", names)
+		}
+
+		spans := tr.Recorder().EndedSpans()
+		if len(spans) != 2 {
+			t.Fatalf("expected 2 spans, got %d", len(spans))
+		}
+		if spans[0].Name != "SQL SELECT" {
+			t.Errorf("expected the query span first, got %s", spans[0].Name)
+		}
+		if spans[1].Name != "SQL ROWS" {
+			t.Errorf("expected the rows span last, got %s", spans[1].Name)
+		} else if got, _ := spans[1].Attribute(DBRowsKey); got.AsInt64() != 2 {
+			t.Errorf("expected 2 iterated rows, got %v", got)
+		}
+		if spans[1].Error != nil {
+			t.Errorf("expected io.EOF to be ignored, got %v", spans[1].Error)
+		}
+	})
+
+	t.Run("No Rows", func(t *testing.T) {
+		db, tr := openTestingDB(t)
+
+		var name string
+		err := db.QueryRow("SELECT name FROM users WHERE id = ?", 42).Scan(&name)
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Fatalf("expected sql.ErrNoRows, got %v", err)
+		}
+		for _, span := range tr.Recorder().EndedSpans() {
+			if span.Status == tracer.StatusError {
+				t.Errorf("expected %s to not fail", span.Name)
+			}
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		db, tr := openTestingDB(t)
+
+		if _, err := db.Exec("SELECT * FROM missing"); err == nil {
+			t.Fatal("expected the query to fail")
+		}
+		span := tr.Recorder().EndedSpans()[0]
+		if span.Error == nil || span.Status != tracer.StatusError {
+			t.Error("expected the error to fail the span")
+		}
+	})
+
+	t.Run("Prepare", func(t *testing.T) {
+		db, tr := openTestingDB(t)
+
+		stmt, err := db.Prepare("SELECT name FROM users WHERE id = ?")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer stmt.Close()
+		var name string
+		if err := stmt.QueryRow(2).Scan(&name); err != nil {
+			t.Fatal(err)
+		} else if name != "bob" {
+			t.Errorf("expected bob, got %s", name)
+		}
+
+		spans := tr.Recorder().EndedSpans()
+		names := make([]string, len(spans))
+		for i, span := range spans {
+			names[i] = span.Name
+		}
+		want := []string{"SQL PREPARE", "SQL SELECT", "SQL ROWS"}
+		if fmt.Sprint(names) != fmt.Sprint(want) {
+			t.Errorf("want spans %v, got %v", want, names)
+		}
+		if got, _ := spans[0].Attribute(attribute.DBStatementKey); got.AsString() != "SELECT name FROM users WHERE id = ?" {
+			t.Errorf("expected the prepared statement, got %v", got)
+		}
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		db, tr := openTestingDB(t)
+
+		for _, commit := range []bool{true, false} {
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tx.Exec("DELETE FROM users"); err != nil {
+				t.Fatal(err)
+			}
+			if commit {
+				err = tx.Commit()
+			} else {
+				err = tx.Rollback()
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		spans := tr.Recorder().EndedSpans()
+		names := make([]string, len(spans))
+		for i, span := range spans {
+			names[i] = span.Name
+		}
+		want := []string{"SQL BEGIN", "SQL DELETE", "SQL COMMIT",
+			"SQL BEGIN", "SQL DELETE", "SQL ROLLBACK"}
+		if fmt.Sprint(names) != fmt.Sprint(want) {
+			t.Errorf("want spans %v, got %v", want, names)
+		}
+	})
+}
+
+func TestOptions(t *testing.T) {
+	t.Run("WithSpanNameGenerator", func(t *testing.T) {
+		db, tr := openTestingDB(t, WithSpanNameGenerator(func(operation, query string) string {
+			return "db." + operation
+		}))
+		if _, err := db.Exec("DELETE FROM users"); err != nil {
+			t.Fatal(err)
+		}
+		if name := tr.Recorder().EndedSpans()[0].Name; name != "db.DELETE" {
+			t.Errorf("expected span name db.DELETE, got %s", name)
+		}
+	})
+
+	t.Run("WithDBSystem", func(t *testing.T) {
+		db, tr := openTestingDB(t, WithDBSystem("libsql"))
+		if _, err := db.Exec("DELETE FROM users"); err != nil {
+			t.Fatal(err)
+		}
+		span := tr.Recorder().EndedSpans()[0]
+		if got, _ := span.Attribute(attribute.DBSystemKey); got.AsString() != "libsql" {
+			t.Errorf("expected the given system, got %v", got)
+		}
+	})
+
+	t.Run("WithRedactor", func(t *testing.T) {
+		query := "UPDATE users SET name = 'alice@example.com'"
+		tests := []struct {
+			name     string
+			redactor *attribute.Redactor
+			want     string
+		}{
+			{"Default", attribute.DefaultRedactor(), "UPDATE users SET name = '" + attribute.DefaultMask + "'"},
+			{"Disabled", nil, query},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				db, tr := openTestingDB(t, WithRedactor(tc.redactor))
+				if _, err := db.Exec(query); err != nil {
+					t.Fatal(err)
+				}
+				span := tr.Recorder().EndedSpans()[0]
+				if got, _ := span.Attribute(attribute.DBStatementKey); got.AsString() != tc.want {
+					t.Errorf("want %q, got %q", tc.want, got.AsString())
+				}
+			})
+		}
+	})
+}
+
+func TestFinishSpan(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantError  bool
+		wantStatus tracer.StatusCode
+	}{
+		{"Nil", nil, false, tracer.StatusUnset},
+		{"EOF", io.EOF, false, tracer.StatusUnset},
+		{"Error", errors.New("some_error"), true, tracer.StatusError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := tracetest.NewTracer()
+			_, span := tr.StartSpan(context.Background(), "span")
+			finishSpan(span, tc.err)
+
+			got := tr.Recorder().EndedSpans()[0]
+			if (got.Error != nil) != tc.wantError {
+				t.Errorf("expected recorded error %v, got %v", tc.wantError, got.Error)
+			}
+			if got.Status != tc.wantStatus {
+				t.Errorf("expected status %s, got %s", tc.wantStatus, got.Status)
+			}
+		})
+	}
+
+	t.Run("Skip", func(t *testing.T) {
+		tr := tracetest.NewTracer()
+		_, span := tr.StartSpan(context.Background(), "span")
+		finishSpan(span, driver.ErrSkip)
+
+		if len(tr.Recorder().EndedSpans()) != 0 {
+			t.Error("expected the skipped span to be discarded")
+		}
+	})
+}
+
+func TestStatementOperation(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"SELECT 1", "SELECT"},
+		{"  insert INTO users VALUES (1)", "INSERT"},
+		{"-- comment\nUPDATE users SET name = ''", "UPDATE"},
+		{"/* comment */ DELETE FROM users", "DELETE"},
+		{"WITH t AS (SELECT 1) SELECT * FROM t", "WITH"},
+		{"-- unterminated", "EXEC"},
+		{"(SELECT 1)", "EXEC"},
+		{"", "EXEC"},
+	}
+
+	for _, tc := range tests {
+		if got := statementOperation(tc.query, "EXEC"); got != tc.want {
+			t.Errorf("%q: want %s, got %s", tc.query, tc.want, got)
+		}
+	}
+}
+
+func TestMapDBSystem(t *testing.T) {
+	tests := map[string]string{
+		"mysql":     MySQL,
+		"mssql":     MsSQL,
+		"postgres":  PostgreSQL,
+		"pgx":       PostgreSQL,
+		"sqlite3":   SQLite,
+		"sqlserver": SQLServer,
+		"unknown":   "",
+	}
+
+	for name, want := range tests {
+		if got := mapDBSystem(name); got != want {
+			t.Errorf("%s: want %q, got %q", name, want, got)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// tx wraps a driver.Tx, remembering the context it began with such that
+// its commit or rollback belongs to the same trace.
+type tx struct {
+	driver.Tx
+	cfg *config
+	ctx context.Context
+}
+
+// Commit commits the transaction within a span.
+func (t *tx) Commit() (err error) {
+	_, span := t.cfg.startSpan(t.ctx, operationCommit, "")
+	defer func() { finishSpan(span, err) }()
+
+	return t.Tx.Commit()
+}
+
+// Rollback aborts the transaction within a span.
+func (t *tx) Rollback() (err error) {
+	_, span := t.cfg.startSpan(t.ctx, operationRollback, "")
+	defer func() { finishSpan(span, err) }()
+
+	return t.Tx.Rollback()
+}
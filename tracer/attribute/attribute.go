@@ -4,20 +4,123 @@ type Key string
 
 type KeyValue struct {
 	Key   Key
-	Value any
+	Value Value
 }
 
+// KeyValuePair converts val with ValueOf. Prefer the typed constructors,
+// such as String or Int, when the type is known.
 func KeyValuePair(key string, val any) KeyValue {
 	return Key(key).Val(val)
 }
 
+// Val returns a KeyValue of the key and val converted with ValueOf.
 func (k Key) Val(val any) KeyValue {
 	return KeyValue{
 		Key:   k,
-		Value: val,
+		Value: ValueOf(val),
 	}
 }
 
+// Bool returns a KeyValue of the key and a bool value.
+func (k Key) Bool(val bool) KeyValue {
+	return KeyValue{Key: k, Value: BoolValue(val)}
+}
+
+// Int returns a KeyValue of the key and an int value.
+func (k Key) Int(val int) KeyValue {
+	return KeyValue{Key: k, Value: IntValue(val)}
+}
+
+// Int64 returns a KeyValue of the key and an int64 value.
+func (k Key) Int64(val int64) KeyValue {
+	return KeyValue{Key: k, Value: Int64Value(val)}
+}
+
+// Float64 returns a KeyValue of the key and a float64 value.
+func (k Key) Float64(val float64) KeyValue {
+	return KeyValue{Key: k, Value: Float64Value(val)}
+}
+
+// String returns a KeyValue of the key and a string value.
+func (k Key) String(val string) KeyValue {
+	return KeyValue{Key: k, Value: StringValue(val)}
+}
+
+// BoolSlice returns a KeyValue of the key and a []bool value.
+func (k Key) BoolSlice(val []bool) KeyValue {
+	return KeyValue{Key: k, Value: BoolSliceValue(val)}
+}
+
+// IntSlice returns a KeyValue of the key and a []int value.
+func (k Key) IntSlice(val []int) KeyValue {
+	return KeyValue{Key: k, Value: IntSliceValue(val)}
+}
+
+// Int64Slice returns a KeyValue of the key and a []int64 value.
+func (k Key) Int64Slice(val []int64) KeyValue {
+	return KeyValue{Key: k, Value: Int64SliceValue(val)}
+}
+
+// Float64Slice returns a KeyValue of the key and a []float64 value.
+func (k Key) Float64Slice(val []float64) KeyValue {
+	return KeyValue{Key: k, Value: Float64SliceValue(val)}
+}
+
+// StringSlice returns a KeyValue of the key and a []string value.
+func (k Key) StringSlice(val []string) KeyValue {
+	return KeyValue{Key: k, Value: StringSliceValue(val)}
+}
+
+// Bool returns a KeyValue of the key and a bool value.
+func Bool(key string, val bool) KeyValue {
+	return Key(key).Bool(val)
+}
+
+// Int returns a KeyValue of the key and an int value.
+func Int(key string, val int) KeyValue {
+	return Key(key).Int(val)
+}
+
+// Int64 returns a KeyValue of the key and an int64 value.
+func Int64(key string, val int64) KeyValue {
+	return Key(key).Int64(val)
+}
+
+// Float64 returns a KeyValue of the key and a float64 value.
+func Float64(key string, val float64) KeyValue {
+	return Key(key).Float64(val)
+}
+
+// String returns a KeyValue of the key and a string value.
+func String(key string, val string) KeyValue {
+	return Key(key).String(val)
+}
+
+// BoolSlice returns a KeyValue of the key and a []bool value.
+func BoolSlice(key string, val []bool) KeyValue {
+	return Key(key).BoolSlice(val)
+}
+
+// IntSlice returns a KeyValue of the key and a []int value.
+func IntSlice(key string, val []int) KeyValue {
+	return Key(key).IntSlice(val)
+}
+
+// Int64Slice returns a KeyValue of the key and a []int64 value.
+func Int64Slice(key string, val []int64) KeyValue {
+	return Key(key).Int64Slice(val)
+}
+
+// Float64Slice returns a KeyValue of the key and a []float64 value.
+func Float64Slice(key string, val []float64) KeyValue {
+	return Key(key).Float64Slice(val)
+}
+
+// StringSlice returns a KeyValue of the key and a []string value.
+func StringSlice(key string, val []string) KeyValue {
+	return Key(key).StringSlice(val)
+}
+
 const (
 	// HTTPUrlKey is the Key conforming to the "url.full" semantics.
 	HTTPUrlKey = Key("url.full")
@@ -65,6 +168,16 @@ const (
 	HTTPResponseStatusKey = Key("http.response.status")
 	// HTTPResponseBodyKey is the Key conforming to the "http.response.body" semantics.
 	HTTPResponseBodyKey = Key("http.response.body")
+	// HTTPResponseBodySizeKey is the Key conforming to the "http.response.body.size" semantics.
+	HTTPResponseBodySizeKey = Key("http.response.body.size")
+	// HTTPRouteKey is the Key conforming to the "http.route" semantics.
+	HTTPRouteKey = Key("http.route")
+	// HTTPResponseStatusClassKey is the Key conforming to the "http.response.status_class" semantics.
+	HTTPResponseStatusClassKey = Key("http.response.status_class")
+	// HTTPServerAddressKey is the Key conforming to the "server.address" semantics.
+	HTTPServerAddressKey = Key("server.address")
+	// HTTPServerPortKey is the Key conforming to the "server.port" semantics.
+	HTTPServerPortKey = Key("server.port")
 )
 
 func HTTPResponseStatus(val any) KeyValue {
@@ -75,6 +188,64 @@ func HTTPResponseBody(val any) KeyValue {
 	return HTTPResponseBodyKey.Val(val)
 }
 
+func HTTPResponseBodySize(val any) KeyValue {
+	return HTTPResponseBodySizeKey.Val(val)
+}
+
+func HTTPRoute(val any) KeyValue {
+	return HTTPRouteKey.Val(val)
+}
+
+func HTTPResponseStatusClass(val any) KeyValue {
+	return HTTPResponseStatusClassKey.Val(val)
+}
+
+func HTTPServerAddress(val any) KeyValue {
+	return HTTPServerAddressKey.Val(val)
+}
+
+func HTTPServerPort(val any) KeyValue {
+	return HTTPServerPortKey.Val(val)
+}
+
+const (
+	// RequestIDKey is the Key conforming to the "request.id" semantics. It is
+	// the protocol neutral counterpart of HTTPRequestIDKey, used by the RPC
+	// and messaging spans.
+	RequestIDKey = Key("request.id")
+)
+
+func RequestID(val any) KeyValue {
+	return RequestIDKey.Val(val)
+}
+
+const (
+	// RPCSystemKey is the Key conforming to the "rpc.system" semantics.
+	RPCSystemKey = Key("rpc.system")
+	// RPCServiceKey is the Key conforming to the "rpc.service" semantics.
+	RPCServiceKey = Key("rpc.service")
+	// RPCMethodKey is the Key conforming to the "rpc.method" semantics.
+	RPCMethodKey = Key("rpc.method")
+	// RPCGRPCStatusCodeKey is the Key conforming to the "rpc.grpc.status_code" semantics.
+	RPCGRPCStatusCodeKey = Key("rpc.grpc.status_code")
+)
+
+func RPCSystem(val any) KeyValue {
+	return RPCSystemKey.Val(val)
+}
+
+func RPCService(val any) KeyValue {
+	return RPCServiceKey.Val(val)
+}
+
+func RPCMethod(val any) KeyValue {
+	return RPCMethodKey.Val(val)
+}
+
+func RPCGRPCStatusCode(val any) KeyValue {
+	return RPCGRPCStatusCodeKey.Val(val)
+}
+
 const (
 	// DBSystemKey is the Key conforming to the "db.system" semantics.
 	DBSystemKey = Key("db.system")
@@ -130,6 +301,16 @@ const (
 	MQMessageBodyKey = Key("mq.message.body")
 	// MQMessageIDKey is the Key conforming to the "mq.message.id" semantics.
 	MQMessageIDKey = Key("mq.message.id")
+	// MQPartitionKey is the Key conforming to the "mq.partition" semantics.
+	MQPartitionKey = Key("mq.partition")
+	// MQOffsetKey is the Key conforming to the "mq.offset" semantics.
+	MQOffsetKey = Key("mq.offset")
+	// MQExchangeKey is the Key conforming to the "mq.exchange" semantics.
+	MQExchangeKey = Key("mq.exchange")
+	// MQRoutingKeyKey is the Key conforming to the "mq.routing_key" semantics.
+	MQRoutingKeyKey = Key("mq.routing_key")
+	// MQDeliveryTagKey is the Key conforming to the "mq.delivery_tag" semantics.
+	MQDeliveryTagKey = Key("mq.delivery_tag")
 	// NOTE: Perhaps extends to each system like otel's semconv
 )
 
@@ -160,3 +341,23 @@ func MQMessageBody(val any) KeyValue {
 func MQMessageID(val any) KeyValue {
 	return MQMessageIDKey.Val(val)
 }
+
+func MQPartition(val any) KeyValue {
+	return MQPartitionKey.Val(val)
+}
+
+func MQOffset(val any) KeyValue {
+	return MQOffsetKey.Val(val)
+}
+
+func MQExchange(val any) KeyValue {
+	return MQExchangeKey.Val(val)
+}
+
+func MQRoutingKey(val any) KeyValue {
+	return MQRoutingKeyKey.Val(val)
+}
+
+func MQDeliveryTag(val any) KeyValue {
+	return MQDeliveryTagKey.Val(val)
+}
@@ -7,13 +7,13 @@ import (
 
 func TestAttribute_KeyValuePair(t *testing.T) {
 	got := KeyValuePair("some_key", "some_value")
-	want := KeyValue{Key: Key("some_key"), Value: any("some_value")}
+	want := KeyValue{Key: Key("some_key"), Value: ValueOf("some_value")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
 
 	got = KeyValuePair("some_other_key", 1)
-	want = KeyValue{Key: Key("some_other_key"), Value: any(1)}
+	want = KeyValue{Key: Key("some_other_key"), Value: ValueOf(1)}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -21,7 +21,7 @@ func TestAttribute_KeyValuePair(t *testing.T) {
 
 func TestAttribute_HTTPUrl(t *testing.T) {
 	got := HTTPUrl("some_url")
-	want := KeyValue{Key: HTTPUrlKey, Value: any("some_url")}
+	want := KeyValue{Key: HTTPUrlKey, Value: ValueOf("some_url")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -29,7 +29,7 @@ func TestAttribute_HTTPUrl(t *testing.T) {
 
 func TestAttribute_HTTPUrlQuery(t *testing.T) {
 	got := HTTPUrlQuery("{id: 1}")
-	want := KeyValue{Key: HTTPUrlQueryKey, Value: any("{id: 1}")}
+	want := KeyValue{Key: HTTPUrlQueryKey, Value: ValueOf("{id: 1}")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -37,7 +37,7 @@ func TestAttribute_HTTPUrlQuery(t *testing.T) {
 
 func TestAttribute_HTTPUrlPath(t *testing.T) {
 	got := HTTPUrlPath("/path")
-	want := KeyValue{Key: HTTPUrlPathKey, Value: any("/path")}
+	want := KeyValue{Key: HTTPUrlPathKey, Value: ValueOf("/path")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -46,7 +46,7 @@ func TestAttribute_HTTPUrlPath(t *testing.T) {
 func TestAttribute_HTTPRequestBody(t *testing.T) {
 	got := HTTPRequestBody("{\"loanId\": \"some_loan_id\"")
 	want := KeyValue{
-		Key: HTTPRequestBodyKey, Value: any("{\"loanId\": \"some_loan_id\""),
+		Key: HTTPRequestBodyKey, Value: ValueOf("{\"loanId\": \"some_loan_id\""),
 	}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
@@ -55,7 +55,7 @@ func TestAttribute_HTTPRequestBody(t *testing.T) {
 
 func TestAttribute_HTTPRequestMethod(t *testing.T) {
 	got := HTTPRequestMethod("POST")
-	want := KeyValue{Key: HTTPRequestMethodKey, Value: any("POST")}
+	want := KeyValue{Key: HTTPRequestMethodKey, Value: ValueOf("POST")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -63,7 +63,7 @@ func TestAttribute_HTTPRequestMethod(t *testing.T) {
 
 func TestAttribute_HTTPRequestID(t *testing.T) {
 	got := HTTPRequestID("some_uuid")
-	want := KeyValue{Key: HTTPRequestIDKey, Value: any("some_uuid")}
+	want := KeyValue{Key: HTTPRequestIDKey, Value: ValueOf("some_uuid")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -71,7 +71,7 @@ func TestAttribute_HTTPRequestID(t *testing.T) {
 
 func TestAttribute_HTTPResponseStatus(t *testing.T) {
 	got := HTTPResponseStatus(200)
-	want := KeyValue{Key: HTTPResponseStatusKey, Value: any(200)}
+	want := KeyValue{Key: HTTPResponseStatusKey, Value: ValueOf(200)}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -80,16 +80,96 @@ func TestAttribute_HTTPResponseStatus(t *testing.T) {
 func TestAttribute_HTTPResponseBody(t *testing.T) {
 	got := HTTPResponseBody("{\"message\": \"Success\"}")
 	want := KeyValue{
-		Key: HTTPResponseBodyKey, Value: any("{\"message\": \"Success\"}"),
+		Key: HTTPResponseBodyKey, Value: ValueOf("{\"message\": \"Success\"}"),
 	}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
 }
 
+func TestAttribute_HTTPResponseBodySize(t *testing.T) {
+	got := HTTPResponseBodySize(128)
+	want := KeyValue{Key: HTTPResponseBodySizeKey, Value: ValueOf(128)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_HTTPRoute(t *testing.T) {
+	got := HTTPRoute("/users/{id}")
+	want := KeyValue{Key: HTTPRouteKey, Value: ValueOf("/users/{id}")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_HTTPResponseStatusClass(t *testing.T) {
+	got := HTTPResponseStatusClass("2xx")
+	want := KeyValue{Key: HTTPResponseStatusClassKey, Value: ValueOf("2xx")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_HTTPServerAddress(t *testing.T) {
+	got := HTTPServerAddress("localhost")
+	want := KeyValue{Key: HTTPServerAddressKey, Value: ValueOf("localhost")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_HTTPServerPort(t *testing.T) {
+	got := HTTPServerPort(8080)
+	want := KeyValue{Key: HTTPServerPortKey, Value: ValueOf(8080)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_RequestID(t *testing.T) {
+	got := RequestID("some_uuid")
+	want := KeyValue{Key: RequestIDKey, Value: ValueOf("some_uuid")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_RPCSystem(t *testing.T) {
+	got := RPCSystem("grpc")
+	want := KeyValue{Key: RPCSystemKey, Value: ValueOf("grpc")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_RPCService(t *testing.T) {
+	got := RPCService("helloworld.Greeter")
+	want := KeyValue{Key: RPCServiceKey, Value: ValueOf("helloworld.Greeter")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_RPCMethod(t *testing.T) {
+	got := RPCMethod("SayHello")
+	want := KeyValue{Key: RPCMethodKey, Value: ValueOf("SayHello")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_RPCGRPCStatusCode(t *testing.T) {
+	got := RPCGRPCStatusCode(13)
+	want := KeyValue{Key: RPCGRPCStatusCodeKey, Value: ValueOf(13)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
 func TestAttribute_DBSystem(t *testing.T) {
 	got := DBSystem("mysql")
-	want := KeyValue{Key: DBSystemKey, Value: any("mysql")}
+	want := KeyValue{Key: DBSystemKey, Value: ValueOf("mysql")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -97,7 +177,7 @@ func TestAttribute_DBSystem(t *testing.T) {
 
 func TestAttribute_DBName(t *testing.T) {
 	got := DBName("some_db_name")
-	want := KeyValue{Key: DBNameKey, Value: any("some_db_name")}
+	want := KeyValue{Key: DBNameKey, Value: ValueOf("some_db_name")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -105,7 +185,7 @@ func TestAttribute_DBName(t *testing.T) {
 
 func TestAttribute_DBInstanceID(t *testing.T) {
 	got := DBInstanceID("some_value")
-	want := KeyValue{Key: DBInstanceIDKey, Value: any("some_value")}
+	want := KeyValue{Key: DBInstanceIDKey, Value: ValueOf("some_value")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -115,7 +195,7 @@ func TestAttribute_DBStatement(t *testing.T) {
 	got := DBStatement("SELECT * FROM test WHERE id = ? AND status = ?")
 	want := KeyValue{
 		Key:   DBStatementKey,
-		Value: any("SELECT * FROM test WHERE id = ? AND status = ?"),
+		Value: ValueOf("SELECT * FROM test WHERE id = ? AND status = ?"),
 	}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
@@ -124,7 +204,7 @@ func TestAttribute_DBStatement(t *testing.T) {
 
 func TestAttribute_DBOperation(t *testing.T) {
 	got := DBOperation("BEGIN")
-	want := KeyValue{Key: DBOperationKey, Value: any("BEGIN")}
+	want := KeyValue{Key: DBOperationKey, Value: ValueOf("BEGIN")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -132,7 +212,7 @@ func TestAttribute_DBOperation(t *testing.T) {
 
 func TestAttribute_DBTable(t *testing.T) {
 	got := DBTable("some_table")
-	want := KeyValue{Key: DBTableKey, Value: any("some_table")}
+	want := KeyValue{Key: DBTableKey, Value: ValueOf("some_table")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -140,7 +220,7 @@ func TestAttribute_DBTable(t *testing.T) {
 
 func TestAttribute_MQSystem(t *testing.T) {
 	got := MQSystem("Kafka")
-	want := KeyValue{Key: MQSystemKey, Value: any("Kafka")}
+	want := KeyValue{Key: MQSystemKey, Value: ValueOf("Kafka")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -148,7 +228,7 @@ func TestAttribute_MQSystem(t *testing.T) {
 
 func TestAttribute_MQInstanceID(t *testing.T) {
 	got := MQInstanceID("localhost:6379")
-	want := KeyValue{Key: MQInstanceIDKey, Value: any("localhost:6379")}
+	want := KeyValue{Key: MQInstanceIDKey, Value: ValueOf("localhost:6379")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -156,7 +236,7 @@ func TestAttribute_MQInstanceID(t *testing.T) {
 
 func TestAttribute_MQTopic(t *testing.T) {
 	got := MQTopic("some_topic")
-	want := KeyValue{Key: MQTopicKey, Value: any("some_topic")}
+	want := KeyValue{Key: MQTopicKey, Value: ValueOf("some_topic")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -164,7 +244,7 @@ func TestAttribute_MQTopic(t *testing.T) {
 
 func TestAttribute_MQSubscriber(t *testing.T) {
 	got := MQSubscriber("some_subscriber")
-	want := KeyValue{Key: MQSubscriberKey, Value: any("some_subscriber")}
+	want := KeyValue{Key: MQSubscriberKey, Value: ValueOf("some_subscriber")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -172,7 +252,7 @@ func TestAttribute_MQSubscriber(t *testing.T) {
 
 func TestAttribute_MQConsumerGroup(t *testing.T) {
 	got := MQConsumerGroup("group:hello_world")
-	want := KeyValue{Key: MQConsumerGroupKey, Value: any("group:hello_world")}
+	want := KeyValue{Key: MQConsumerGroupKey, Value: ValueOf("group:hello_world")}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -181,7 +261,7 @@ func TestAttribute_MQConsumerGroup(t *testing.T) {
 func TestAttribute_MQMessageBody(t *testing.T) {
 	got := MQMessageBody("{\"content\":\"hello world\"}")
 	want := KeyValue{
-		Key: MQMessageBodyKey, Value: any("{\"content\":\"hello world\"}"),
+		Key: MQMessageBodyKey, Value: ValueOf("{\"content\":\"hello world\"}"),
 	}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
@@ -190,7 +270,47 @@ func TestAttribute_MQMessageBody(t *testing.T) {
 
 func TestAttribute_MQMessageID(t *testing.T) {
 	got := MQMessageID(3432804932)
-	want := KeyValue{Key: MQMessageIDKey, Value: any(3432804932)}
+	want := KeyValue{Key: MQMessageIDKey, Value: ValueOf(3432804932)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_MQPartition(t *testing.T) {
+	got := MQPartition(3)
+	want := KeyValue{Key: MQPartitionKey, Value: ValueOf(3)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_MQOffset(t *testing.T) {
+	got := MQOffset(int64(42))
+	want := KeyValue{Key: MQOffsetKey, Value: ValueOf(int64(42))}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_MQExchange(t *testing.T) {
+	got := MQExchange("orders")
+	want := KeyValue{Key: MQExchangeKey, Value: ValueOf("orders")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_MQRoutingKey(t *testing.T) {
+	got := MQRoutingKey("orders.created")
+	want := KeyValue{Key: MQRoutingKeyKey, Value: ValueOf("orders.created")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", got, want)
+	}
+}
+
+func TestAttribute_MQDeliveryTag(t *testing.T) {
+	got := MQDeliveryTag(uint64(7))
+	want := KeyValue{Key: MQDeliveryTagKey, Value: ValueOf(uint64(7))}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("want %v, got %v", got, want)
 	}
@@ -0,0 +1,459 @@
+package attribute
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DefaultMask is the value written in place of redacted data.
+const DefaultMask = "[REDACTED]"
+
+// Redactor scrubs sensitive data out of attributes before they are set to a
+// span. Values are redacted by key with a deny-list, by regular expression
+// matches inside string values, and by JSON path for string values holding
+// a JSON document such as request bodies or headers.
+//
+// A nil *Redactor is valid and leaves attributes untouched.
+type Redactor struct {
+	mask       string
+	deniedKeys map[Key]struct{}
+	maskers    []func(mask, s string) string
+	jsonPaths  [][]pathSegment
+}
+
+// RedactorOption configures a Redactor.
+type RedactorOption func(*Redactor)
+
+// NewRedactor returns a Redactor configured with the given options.
+func NewRedactor(opts ...RedactorOption) *Redactor {
+	r := &Redactor{
+		mask:       DefaultMask,
+		deniedKeys: make(map[Key]struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// DefaultRedactor returns a Redactor masking emails, card numbers, bearer
+// and JWT tokens, and the common credential fields of JSON documents,
+// including the Authorization and Cookie headers.
+func DefaultRedactor() *Redactor {
+	return NewRedactor(
+		WithEmailMasking(),
+		WithCardMasking(),
+		WithTokenMasking(),
+		WithJSONFields(
+			"$..password",
+			"$..passwd",
+			"$..secret",
+			"$..token",
+			"$..access_token",
+			"$..refresh_token",
+			"$..api_key",
+			"$..Authorization",
+			"$..Proxy-Authorization",
+			"$..Cookie",
+			"$..Set-Cookie",
+			"$..X-Api-Key",
+		),
+	)
+}
+
+// WithMask replaces DefaultMask by the given mask.
+func WithMask(mask string) RedactorOption {
+	return func(r *Redactor) {
+		r.mask = mask
+	}
+}
+
+// WithDeniedKeys masks the whole value of attributes with the given keys.
+func WithDeniedKeys(keys ...Key) RedactorOption {
+	return func(r *Redactor) {
+		for _, key := range keys {
+			r.deniedKeys[key] = struct{}{}
+		}
+	}
+}
+
+// WithPatterns masks every match of the given patterns inside string values.
+func WithPatterns(patterns ...*regexp.Regexp) RedactorOption {
+	return func(r *Redactor) {
+		for _, pattern := range patterns {
+			if pattern == nil {
+				continue
+			}
+			pattern := pattern
+			r.maskers = append(r.maskers, func(mask, s string) string {
+				return pattern.ReplaceAllLiteralString(s, mask)
+			})
+		}
+	}
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	cardPattern  = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+	tokenPattern = regexp.MustCompile(
+		`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*|\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`)
+)
+
+// WithEmailMasking masks email addresses inside string values.
+func WithEmailMasking() RedactorOption {
+	return WithPatterns(emailPattern)
+}
+
+// WithCardMasking masks payment card numbers inside string values. Only
+// numbers passing the Luhn checksum are masked, such that ids and
+// timestamps are kept.
+func WithCardMasking() RedactorOption {
+	return func(r *Redactor) {
+		r.maskers = append(r.maskers, func(mask, s string) string {
+			return cardPattern.ReplaceAllStringFunc(s, func(match string) string {
+				if luhn(match) {
+					return mask
+				}
+				return match
+			})
+		})
+	}
+}
+
+// WithTokenMasking masks bearer tokens and JSON web tokens inside string
+// values.
+func WithTokenMasking() RedactorOption {
+	return WithPatterns(tokenPattern)
+}
+
+// WithJSONFields masks the fields matching the given paths of string values
+// holding a JSON document. A path starts with "$" followed by field
+// selectors: ".name" selects a field, ".*" or "[*]" selects every field or
+// element and "..name" selects the field at any depth. Field names are
+// matched case-insensitively, and a field selector applied to an array is
+// applied to each of its elements. Invalid paths are ignored.
+func WithJSONFields(paths ...string) RedactorOption {
+	return func(r *Redactor) {
+		for _, path := range paths {
+			if segments, ok := parsePath(path); ok {
+				r.jsonPaths = append(r.jsonPaths, segments)
+			}
+		}
+	}
+}
+
+// Redact returns a copy of the attributes with their sensitive values
+// masked. The given attributes are not modified.
+func (r *Redactor) Redact(attrs ...KeyValue) []KeyValue {
+	if r == nil || len(attrs) == 0 {
+		return attrs
+	}
+
+	redacted := make([]KeyValue, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = KeyValue{Key: attr.Key, Value: r.redactValue(attr)}
+	}
+	return redacted
+}
+
+func (r *Redactor) redactValue(attr KeyValue) Value {
+	if _, ok := r.deniedKeys[attr.Key]; ok {
+		return StringValue(r.mask)
+	}
+
+	switch attr.Value.Type() {
+	case TypeString:
+		return StringValue(r.RedactString(attr.Value.AsString()))
+	case TypeStringSlice:
+		strs := attr.Value.AsStringSlice()
+		for i, s := range strs {
+			strs[i] = r.RedactString(s)
+		}
+		return StringSliceValue(strs)
+	default:
+		return attr.Value
+	}
+}
+
+// RedactString masks the JSON fields and the pattern matches of s.
+func (r *Redactor) RedactString(s string) string {
+	if r == nil {
+		return s
+	}
+
+	s = r.redactJSON(s)
+	for _, masker := range r.maskers {
+		s = masker(r.mask, s)
+	}
+	return s
+}
+
+// RedactURL masks the query values of the raw URL whose parameter name is
+// selected by a JSON path of a single field, such as "$..token", and the
+// pattern matches of the other query values. The parameters keep their
+// order and encoding unless masked.
+func (r *Redactor) RedactURL(raw string) string {
+	if r == nil {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.RawQuery == "" {
+		return raw
+	}
+
+	params := strings.Split(u.RawQuery, "&")
+	for i, param := range params {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		unescapedName, err := url.QueryUnescape(name)
+		if err != nil {
+			continue
+		}
+		unescaped, err := url.QueryUnescape(value)
+		if err != nil {
+			continue
+		}
+
+		redacted := r.mask
+		if !r.selectsField(unescapedName) {
+			redacted = unescaped
+			for _, masker := range r.maskers {
+				redacted = masker(r.mask, redacted)
+			}
+		}
+		if redacted != unescaped {
+			params[i] = name + "=" + url.QueryEscape(redacted)
+		}
+	}
+	u.RawQuery = strings.Join(params, "&")
+	return u.String()
+}
+
+// selectsField reports whether a JSON path selects the field name of a flat
+// document.
+func (r *Redactor) selectsField(name string) bool {
+	for _, segments := range r.jsonPaths {
+		if len(segments) == 1 &&
+			(segments[0].wildcard || strings.EqualFold(segments[0].name, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSON masks the JSON fields of s in place, such that the rest of the
+// document is kept byte for byte, including its key order, spacing and
+// number formatting. Only the first JSON value of s is redacted.
+func (r *Redactor) redactJSON(s string) string {
+	if len(r.jsonPaths) == 0 {
+		return s
+	}
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return s
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+	scanner := &jsonScanner{decoder: decoder, input: s, paths: r.jsonPaths}
+	if err := scanner.value(nil); err != nil || len(scanner.masked) == 0 {
+		return s
+	}
+
+	mask, err := json.Marshal(r.mask)
+	if err != nil {
+		return s
+	}
+
+	var b strings.Builder
+	offset := int64(0)
+	for _, span := range scanner.masked {
+		b.WriteString(s[offset:span[0]])
+		b.Write(mask)
+		offset = span[1]
+	}
+	b.WriteString(s[offset:])
+	return b.String()
+}
+
+// jsonStep is the field name or, within an array, the element leading to a
+// value of a JSON document.
+type jsonStep struct {
+	name    string
+	element bool
+}
+
+// jsonScanner walks the tokens of a JSON document and collects the offsets
+// of the values selected by the paths.
+type jsonScanner struct {
+	decoder *json.Decoder
+	input   string
+	paths   [][]pathSegment
+	masked  [][2]int64
+}
+
+// value scans the next value of the document found at the given steps.
+func (s *jsonScanner) value(steps []jsonStep) error {
+	// The offset follows the previous token, which is trailed by the
+	// separators of the value.
+	start := s.decoder.InputOffset()
+	for start < int64(len(s.input)) &&
+		strings.IndexByte(" \t\r\n:,", s.input[start]) >= 0 {
+		start++
+	}
+
+	selected := false
+	for _, segments := range s.paths {
+		if matchPath(segments, steps) {
+			selected = true
+			break
+		}
+	}
+
+	token, err := s.decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch {
+	case selected:
+		if err := s.skip(token); err != nil {
+			return err
+		}
+		s.masked = append(s.masked, [2]int64{start, s.decoder.InputOffset()})
+	case token == json.Delim('{'):
+		for s.decoder.More() {
+			key, err := s.decoder.Token()
+			if err != nil {
+				return err
+			}
+			name, _ := key.(string)
+			if err := s.value(append(steps, jsonStep{name: name})); err != nil {
+				return err
+			}
+		}
+		_, err = s.decoder.Token()
+	case token == json.Delim('['):
+		for s.decoder.More() {
+			if err := s.value(append(steps, jsonStep{element: true})); err != nil {
+				return err
+			}
+		}
+		_, err = s.decoder.Token()
+	}
+	return err
+}
+
+// skip consumes the rest of the value started by token.
+func (s *jsonScanner) skip(token json.Token) error {
+	depth := 0
+	for {
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+
+		var err error
+		if token, err = s.decoder.Token(); err != nil {
+			return err
+		}
+	}
+}
+
+// pathSegment is a single selector of a JSON path.
+type pathSegment struct {
+	name      string
+	wildcard  bool
+	recursive bool
+}
+
+func parsePath(path string) ([]pathSegment, bool) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, false
+	}
+	path = strings.ReplaceAll(path[1:], "[*]", ".*")
+
+	var segments []pathSegment
+	for path != "" {
+		if !strings.HasPrefix(path, ".") {
+			return nil, false
+		}
+		segment := pathSegment{}
+		if strings.HasPrefix(path, "..") {
+			segment.recursive = true
+			path = path[2:]
+		} else {
+			path = path[1:]
+		}
+
+		end := strings.IndexByte(path, '.')
+		if end < 0 {
+			end = len(path)
+		}
+		name := path[:end]
+		path = path[end:]
+
+		if name == "" {
+			return nil, false
+		} else if name == "*" {
+			segment.wildcard = true
+		} else {
+			segment.name = name
+		}
+		segments = append(segments, segment)
+	}
+	return segments, len(segments) > 0
+}
+
+// matchPath reports whether the segments select the value found at the
+// given steps. A field selector applied to an array is applied to each of
+// its elements.
+func matchPath(segments []pathSegment, steps []jsonStep) bool {
+	if len(steps) == 0 {
+		return false
+	}
+	segment, step := segments[0], steps[0]
+
+	if step.element && !segment.wildcard {
+		return matchPath(segments, steps[1:])
+	}
+	if segment.wildcard || (!step.element && strings.EqualFold(step.name, segment.name)) {
+		if len(segments) == 1 {
+			return len(steps) == 1
+		}
+		return matchPath(segments[1:], steps[1:])
+	}
+	if segment.recursive {
+		return matchPath(segments, steps[1:])
+	}
+	return false
+}
+
+// luhn reports whether the digits of s pass the Luhn checksum.
+func luhn(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		digit := int(c - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,363 @@
+package attribute
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Type is the type of the data held by a Value.
+type Type int
+
+const (
+	// TypeInvalid is the type of the zero Value, holding no data.
+	TypeInvalid Type = iota
+	// TypeBool is the type of a Value holding a bool.
+	TypeBool
+	// TypeInt64 is the type of a Value holding an int64.
+	TypeInt64
+	// TypeFloat64 is the type of a Value holding a float64.
+	TypeFloat64
+	// TypeString is the type of a Value holding a string.
+	TypeString
+	// TypeBoolSlice is the type of a Value holding a []bool.
+	TypeBoolSlice
+	// TypeInt64Slice is the type of a Value holding a []int64.
+	TypeInt64Slice
+	// TypeFloat64Slice is the type of a Value holding a []float64.
+	TypeFloat64Slice
+	// TypeStringSlice is the type of a Value holding a []string.
+	TypeStringSlice
+)
+
+// String returns the name of the type.
+func (t Type) String() string {
+	switch t {
+	case TypeBool:
+		return "bool"
+	case TypeInt64:
+		return "int64"
+	case TypeFloat64:
+		return "float64"
+	case TypeString:
+		return "string"
+	case TypeBoolSlice:
+		return "[]bool"
+	case TypeInt64Slice:
+		return "[]int64"
+	case TypeFloat64Slice:
+		return "[]float64"
+	case TypeStringSlice:
+		return "[]string"
+	default:
+		return "invalid"
+	}
+}
+
+// Value is the typed value of an attribute. It is immutable and comparable,
+// slices are copied into arrays when the Value is created.
+type Value struct {
+	vtype    Type
+	numeric  uint64
+	stringly string
+	slice    any
+}
+
+// BoolValue returns a Value holding a bool.
+func BoolValue(v bool) Value {
+	var numeric uint64
+	if v {
+		numeric = 1
+	}
+	return Value{vtype: TypeBool, numeric: numeric}
+}
+
+// IntValue returns a Value holding an int as an int64.
+func IntValue(v int) Value {
+	return Int64Value(int64(v))
+}
+
+// Int64Value returns a Value holding an int64.
+func Int64Value(v int64) Value {
+	return Value{vtype: TypeInt64, numeric: uint64(v)}
+}
+
+// Float64Value returns a Value holding a float64.
+func Float64Value(v float64) Value {
+	return Value{vtype: TypeFloat64, numeric: math.Float64bits(v)}
+}
+
+// StringValue returns a Value holding a string.
+func StringValue(v string) Value {
+	return Value{vtype: TypeString, stringly: v}
+}
+
+// BoolSliceValue returns a Value holding a copy of a []bool.
+func BoolSliceValue(v []bool) Value {
+	return Value{vtype: TypeBoolSlice, slice: sliceToArray(v)}
+}
+
+// IntSliceValue returns a Value holding a []int as a []int64.
+func IntSliceValue(v []int) Value {
+	ints := make([]int64, len(v))
+	for i, n := range v {
+		ints[i] = int64(n)
+	}
+	return Int64SliceValue(ints)
+}
+
+// Int64SliceValue returns a Value holding a copy of a []int64.
+func Int64SliceValue(v []int64) Value {
+	return Value{vtype: TypeInt64Slice, slice: sliceToArray(v)}
+}
+
+// Float64SliceValue returns a Value holding a copy of a []float64.
+func Float64SliceValue(v []float64) Value {
+	return Value{vtype: TypeFloat64Slice, slice: sliceToArray(v)}
+}
+
+// StringSliceValue returns a Value holding a copy of a []string.
+func StringSliceValue(v []string) Value {
+	return Value{vtype: TypeStringSlice, slice: sliceToArray(v)}
+}
+
+// ValueOf converts an arbitrary value into a Value. Booleans, integers,
+// floats, strings and slices of them keep their type, integers being
+// widened to int64 and floats to float64. Unsigned integers overflowing an
+// int64, errors, fmt.Stringer and any other value are formatted to a
+// string, and nil results into an invalid Value.
+func ValueOf(v any) Value {
+	switch val := v.(type) {
+	case nil:
+		return Value{}
+	case Value:
+		return val
+	case bool:
+		return BoolValue(val)
+	case int:
+		return IntValue(val)
+	case int64:
+		return Int64Value(val)
+	case float64:
+		return Float64Value(val)
+	case string:
+		return StringValue(val)
+	case []byte:
+		return StringValue(string(val))
+	case []bool:
+		return BoolSliceValue(val)
+	case []int:
+		return IntSliceValue(val)
+	case []int64:
+		return Int64SliceValue(val)
+	case []float64:
+		return Float64SliceValue(val)
+	case []string:
+		return StringSliceValue(val)
+	case error:
+		return StringValue(val.Error())
+	case fmt.Stringer:
+		return StringValue(val.String())
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return BoolValue(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int64Value(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		if n := rv.Uint(); n <= math.MaxInt64 {
+			return Int64Value(int64(n))
+		}
+		return StringValue(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		return Float64Value(rv.Float())
+	case reflect.String:
+		return StringValue(rv.String())
+	case reflect.Slice, reflect.Array:
+		if value, ok := sliceValueOf(rv); ok {
+			return value
+		}
+	}
+
+	return StringValue(fmt.Sprintf("%v", v))
+}
+
+// sliceValueOf converts a slice or an array of booleans, integers, floats
+// or strings into a Value.
+func sliceValueOf(rv reflect.Value) (Value, bool) {
+	switch rv.Type().Elem().Kind() {
+	case reflect.Bool:
+		bools := make([]bool, rv.Len())
+		for i := range bools {
+			bools[i] = rv.Index(i).Bool()
+		}
+		return BoolSliceValue(bools), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ints := make([]int64, rv.Len())
+		for i := range ints {
+			ints[i] = rv.Index(i).Int()
+		}
+		return Int64SliceValue(ints), true
+	case reflect.Uint16, reflect.Uint32:
+		ints := make([]int64, rv.Len())
+		for i := range ints {
+			ints[i] = int64(rv.Index(i).Uint())
+		}
+		return Int64SliceValue(ints), true
+	case reflect.Float32, reflect.Float64:
+		floats := make([]float64, rv.Len())
+		for i := range floats {
+			floats[i] = rv.Index(i).Float()
+		}
+		return Float64SliceValue(floats), true
+	case reflect.String:
+		strs := make([]string, rv.Len())
+		for i := range strs {
+			strs[i] = rv.Index(i).String()
+		}
+		return StringSliceValue(strs), true
+	default:
+		return Value{}, false
+	}
+}
+
+// Type returns the type of the data held by the Value.
+func (v Value) Type() Type {
+	return v.vtype
+}
+
+// AsBool returns the bool held by the Value, false for other types.
+func (v Value) AsBool() bool {
+	return v.vtype == TypeBool && v.numeric != 0
+}
+
+// AsInt64 returns the int64 held by the Value, 0 for other types.
+func (v Value) AsInt64() int64 {
+	if v.vtype != TypeInt64 {
+		return 0
+	}
+	return int64(v.numeric)
+}
+
+// AsFloat64 returns the float64 held by the Value, 0 for other types.
+func (v Value) AsFloat64() float64 {
+	if v.vtype != TypeFloat64 {
+		return 0
+	}
+	return math.Float64frombits(v.numeric)
+}
+
+// AsString returns the string held by the Value, "" for other types.
+func (v Value) AsString() string {
+	return v.stringly
+}
+
+// AsBoolSlice returns a copy of the []bool held by the Value, nil for
+// other types.
+func (v Value) AsBoolSlice() []bool {
+	if v.vtype != TypeBoolSlice {
+		return nil
+	}
+	return arrayToSlice[bool](v.slice)
+}
+
+// AsInt64Slice returns a copy of the []int64 held by the Value, nil for
+// other types.
+func (v Value) AsInt64Slice() []int64 {
+	if v.vtype != TypeInt64Slice {
+		return nil
+	}
+	return arrayToSlice[int64](v.slice)
+}
+
+// AsFloat64Slice returns a copy of the []float64 held by the Value, nil for
+// other types.
+func (v Value) AsFloat64Slice() []float64 {
+	if v.vtype != TypeFloat64Slice {
+		return nil
+	}
+	return arrayToSlice[float64](v.slice)
+}
+
+// AsStringSlice returns a copy of the []string held by the Value, nil for
+// other types.
+func (v Value) AsStringSlice() []string {
+	if v.vtype != TypeStringSlice {
+		return nil
+	}
+	return arrayToSlice[string](v.slice)
+}
+
+// AsInterface returns the data held by the Value, nil when it is invalid.
+func (v Value) AsInterface() any {
+	switch v.vtype {
+	case TypeBool:
+		return v.AsBool()
+	case TypeInt64:
+		return v.AsInt64()
+	case TypeFloat64:
+		return v.AsFloat64()
+	case TypeString:
+		return v.AsString()
+	case TypeBoolSlice:
+		return v.AsBoolSlice()
+	case TypeInt64Slice:
+		return v.AsInt64Slice()
+	case TypeFloat64Slice:
+		return v.AsFloat64Slice()
+	case TypeStringSlice:
+		return v.AsStringSlice()
+	default:
+		return nil
+	}
+}
+
+// Emit returns the string representation of the Value. Scalars are
+// formatted without loss and slices are encoded as a JSON array.
+func (v Value) Emit() string {
+	switch v.vtype {
+	case TypeBool:
+		return strconv.FormatBool(v.AsBool())
+	case TypeInt64:
+		return strconv.FormatInt(v.AsInt64(), 10)
+	case TypeFloat64:
+		return strconv.FormatFloat(v.AsFloat64(), 'g', -1, 64)
+	case TypeString:
+		return v.stringly
+	case TypeBoolSlice, TypeInt64Slice, TypeFloat64Slice, TypeStringSlice:
+		b, err := json.Marshal(v.slice)
+		if err != nil {
+			return fmt.Sprintf("%v", v.slice)
+		}
+		return string(b)
+	default:
+		return ""
+	}
+}
+
+// String implements fmt.Stringer by returning Emit.
+func (v Value) String() string {
+	return v.Emit()
+}
+
+// sliceToArray copies a slice into an array of the same length, making the
+// Value holding it comparable.
+func sliceToArray[T any](s []T) any {
+	var zero T
+	array := reflect.New(reflect.ArrayOf(len(s), reflect.TypeOf(zero))).Elem()
+	reflect.Copy(array, reflect.ValueOf(s))
+	return array.Interface()
+}
+
+// arrayToSlice copies an array created by sliceToArray back into a slice.
+func arrayToSlice[T any](array any) []T {
+	rv := reflect.ValueOf(array)
+	s := make([]T, rv.Len())
+	reflect.Copy(reflect.ValueOf(s), rv)
+	return s
+}
@@ -0,0 +1,160 @@
+package attribute
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+func TestValueOf(t *testing.T) {
+	type named int
+	tests := []struct {
+		name  string
+		input any
+		want  Value
+	}{
+		{"Nil", nil, Value{}},
+		{"Value", StringValue("value"), StringValue("value")},
+		{"Bool", true, BoolValue(true)},
+		{"Int", 10, Int64Value(10)},
+		{"Int32", int32(10), Int64Value(10)},
+		{"Named Int", named(10), Int64Value(10)},
+		{"Uint", uint(10), Int64Value(10)},
+		{"Overflowing Uint", uint64(math.MaxUint64), StringValue("18446744073709551615")},
+		{"Float32", float32(4.5), Float64Value(4.5)},
+		{"Float64", 4.5, Float64Value(4.5)},
+		{"String", "hello", StringValue("hello")},
+		{"Bytes", []byte("hello"), StringValue("hello")},
+		{"Bool Slice", []bool{true, false}, BoolSliceValue([]bool{true, false})},
+		{"Int Slice", []int{1, 2}, Int64SliceValue([]int64{1, 2})},
+		{"Int32 Slice", []int32{1, 2}, Int64SliceValue([]int64{1, 2})},
+		{"Float32 Slice", []float32{1.5}, Float64SliceValue([]float64{1.5})},
+		{"String Array", [2]string{"a", "b"}, StringSliceValue([]string{"a", "b"})},
+		{"Error", errors.New("some_error"), StringValue("some_error")},
+		{"Stringer", stringer{}, StringValue("stringer")},
+		{"Duration", time.Second, StringValue("1s")},
+		{"Map", map[string]int{"a": 1}, StringValue("map[a:1]")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ValueOf(tc.input); got != tc.want {
+				t.Errorf("want %v (%s), got %v (%s)", tc.want, tc.want.Type(),
+					got, got.Type())
+			}
+		})
+	}
+}
+
+func TestValue_As(t *testing.T) {
+	t.Run("Scalars", func(t *testing.T) {
+		if !BoolValue(true).AsBool() || BoolValue(false).AsBool() {
+			t.Error("unexpected bool")
+		}
+		if IntValue(-3).AsInt64() != -3 {
+			t.Error("unexpected int64")
+		}
+		if Float64Value(-1.25).AsFloat64() != -1.25 {
+			t.Error("unexpected float64")
+		}
+		if StringValue("a").AsString() != "a" {
+			t.Error("unexpected string")
+		}
+		if StringValue("1").AsInt64() != 0 || IntValue(1).AsString() != "" {
+			t.Error("expected zero values for mismatched types")
+		}
+	})
+
+	t.Run("Slices Are Copied", func(t *testing.T) {
+		strs := []string{"a", "b"}
+		value := StringSliceValue(strs)
+		strs[0] = "c"
+
+		got := value.AsStringSlice()
+		if !reflect.DeepEqual(got, []string{"a", "b"}) {
+			t.Errorf("unexpected slice %v", got)
+		}
+		got[1] = "c"
+		if value.AsStringSlice()[1] != "b" {
+			t.Error("expected the value to be immutable")
+		}
+	})
+
+	t.Run("AsInterface", func(t *testing.T) {
+		tests := []struct {
+			value Value
+			want  any
+		}{
+			{Value{}, nil},
+			{BoolValue(true), true},
+			{IntValue(1), int64(1)},
+			{Float64Value(1.5), 1.5},
+			{StringValue("a"), "a"},
+			{BoolSliceValue([]bool{true}), []bool{true}},
+			{IntSliceValue([]int{1}), []int64{1}},
+			{Float64SliceValue([]float64{1.5}), []float64{1.5}},
+			{StringSliceValue([]string{"a"}), []string{"a"}},
+		}
+		for _, tc := range tests {
+			if got := tc.value.AsInterface(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		}
+	})
+}
+
+func TestValue_Emit(t *testing.T) {
+	tests := []struct {
+		value Value
+		want  string
+	}{
+		{Value{}, ""},
+		{BoolValue(true), "true"},
+		{IntValue(-10), "-10"},
+		{Float64Value(4.5), "4.5"},
+		{Float64Value(1e21), "1e+21"},
+		{StringValue("hello"), "hello"},
+		{BoolSliceValue([]bool{true, false}), "[true,false]"},
+		{IntSliceValue([]int{1, 2}), "[1,2]"},
+		{Float64SliceValue([]float64{1.5, 2}), "[1.5,2]"},
+		{StringSliceValue([]string{"a", "b"}), `["a","b"]`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value.Type().String(), func(t *testing.T) {
+			if got := tc.value.Emit(); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAttribute_TypedConstructors(t *testing.T) {
+	tests := []struct {
+		got  KeyValue
+		want Type
+	}{
+		{Bool("k", true), TypeBool},
+		{Int("k", 1), TypeInt64},
+		{Int64("k", 1), TypeInt64},
+		{Float64("k", 1), TypeFloat64},
+		{String("k", "v"), TypeString},
+		{BoolSlice("k", nil), TypeBoolSlice},
+		{IntSlice("k", nil), TypeInt64Slice},
+		{Int64Slice("k", nil), TypeInt64Slice},
+		{Float64Slice("k", nil), TypeFloat64Slice},
+		{StringSlice("k", nil), TypeStringSlice},
+	}
+
+	for _, tc := range tests {
+		if tc.got.Key != "k" || tc.got.Value.Type() != tc.want {
+			t.Errorf("want %s, got %s", tc.want, tc.got.Value.Type())
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package tracer
+
+import (
+	"time"
+
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// EventConfig holds the configuration of an event added to a span.
+type EventConfig struct {
+	// Attributes describes the event.
+	Attributes []attribute.KeyValue
+
+	// Timestamp is the time the event occurred. Defaults to the time the
+	// event is added.
+	Timestamp time.Time
+
+	// StackTrace determines whether the stack trace of the caller should be
+	// captured along with the event.
+	StackTrace bool
+}
+
+// EventOption provides options to configure an event when adding it
+// to a span.
+type EventOption func(*EventConfig)
+
+// WithEventAttributes appends the attributes describing the event.
+func WithEventAttributes(attributes ...attribute.KeyValue) EventOption {
+	return func(cfg *EventConfig) {
+		cfg.Attributes = append(cfg.Attributes, attributes...)
+	}
+}
+
+// WithTimestamp sets the time the event occurred.
+func WithTimestamp(timestamp time.Time) EventOption {
+	return func(cfg *EventConfig) {
+		cfg.Timestamp = timestamp
+	}
+}
+
+// WithStackTrace captures the stack trace of the caller along with the event.
+func WithStackTrace() EventOption {
+	return func(cfg *EventConfig) {
+		cfg.StackTrace = true
+	}
+}
+
+// NewEventConfig applies the options and returns the resulting EventConfig.
+// The Timestamp is set to now when none was given.
+func NewEventConfig(opts ...EventOption) *EventConfig {
+	cfg := &EventConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.Timestamp.IsZero() {
+		cfg.Timestamp = time.Now()
+	}
+
+	return cfg
+}
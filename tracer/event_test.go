@@ -0,0 +1,46 @@
+package tracer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+func TestNewEventConfig(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := NewEventConfig()
+		if cfg.Timestamp.IsZero() {
+			t.Error("default timestamp should not be zero")
+		} else if len(cfg.Attributes) != 0 {
+			t.Error("default attributes should be empty")
+		} else if cfg.StackTrace {
+			t.Error("default stack trace should be disabled")
+		}
+	})
+
+	t.Run("WithEventAttributes", func(t *testing.T) {
+		cfg := NewEventConfig(
+			WithEventAttributes(attribute.KeyValuePair("key1", "value1")),
+			WithEventAttributes(attribute.KeyValuePair("key2", "value2")),
+		)
+		if len(cfg.Attributes) != 2 {
+			t.Errorf("expected 2 attributes but got %d", len(cfg.Attributes))
+		}
+	})
+
+	t.Run("WithTimestamp", func(t *testing.T) {
+		timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		cfg := NewEventConfig(WithTimestamp(timestamp))
+		if !cfg.Timestamp.Equal(timestamp) {
+			t.Errorf("expected timestamp %s but got %s", timestamp, cfg.Timestamp)
+		}
+	})
+
+	t.Run("WithStackTrace", func(t *testing.T) {
+		cfg := NewEventConfig(WithStackTrace())
+		if !cfg.StackTrace {
+			t.Error("stack trace should be enabled")
+		}
+	})
+}
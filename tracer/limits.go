@@ -0,0 +1,216 @@
+package tracer
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+const (
+	// DefaultAttributeCountLimit is the default maximum number of distinct
+	// attribute keys of a span.
+	DefaultAttributeCountLimit = 128
+	// DefaultAttributeValueLengthLimit is the default maximum length of
+	// string attribute values. It bounds the recorded request and response
+	// bodies while keeping most messages whole.
+	DefaultAttributeValueLengthLimit = 4096
+	// DefaultEventCountLimit is the default maximum number of events of a
+	// span.
+	DefaultEventCountLimit = 128
+
+	// TruncationMarker is appended to string values cut down to the
+	// attribute value length limit.
+	TruncationMarker = "...[truncated]"
+)
+
+// SpanLimits bounds the data recorded by a span. A negative limit means
+// unlimited and a zero limit falls back to its default.
+type SpanLimits struct {
+	// AttributeCountLimit is the maximum number of distinct attribute keys
+	// of a span. Attributes with a new key beyond it are dropped, while
+	// attributes overwriting an existing key are kept.
+	AttributeCountLimit int
+
+	// AttributeValueLengthLimit is the maximum number of characters of a
+	// string value, including each string of a string slice. Longer values
+	// are cut down and suffixed by TruncationMarker. The db.statement values
+	// are kept whole, since a cut statement can neither be read nor run.
+	AttributeValueLengthLimit int
+
+	// EventCountLimit is the maximum number of events of a span. Events
+	// beyond it are dropped.
+	EventCountLimit int
+}
+
+// DefaultSpanLimits returns the SpanLimits with the default limits.
+func DefaultSpanLimits() SpanLimits {
+	return SpanLimits{
+		AttributeCountLimit:       DefaultAttributeCountLimit,
+		AttributeValueLengthLimit: DefaultAttributeValueLengthLimit,
+		EventCountLimit:           DefaultEventCountLimit,
+	}
+}
+
+// withDefaults replaces the zero limits with their default.
+func (l SpanLimits) withDefaults() SpanLimits {
+	if l.AttributeCountLimit == 0 {
+		l.AttributeCountLimit = DefaultAttributeCountLimit
+	}
+	if l.AttributeValueLengthLimit == 0 {
+		l.AttributeValueLengthLimit = DefaultAttributeValueLengthLimit
+	}
+	if l.EventCountLimit == 0 {
+		l.EventCountLimit = DefaultEventCountLimit
+	}
+	return l
+}
+
+var (
+	limitsMu     sync.RWMutex
+	globalLimits = DefaultSpanLimits()
+)
+
+// SetSpanLimits replaces the SpanLimits used by the tracers that are not
+// given their own limits.
+func SetSpanLimits(limits SpanLimits) {
+	limitsMu.Lock()
+	defer limitsMu.Unlock()
+	globalLimits = limits.withDefaults()
+}
+
+// GetSpanLimits retrieves the global SpanLimits.
+func GetSpanLimits() SpanLimits {
+	limitsMu.RLock()
+	defer limitsMu.RUnlock()
+	return globalLimits
+}
+
+// SpanLimiter enforces SpanLimits on a single span. Tracers keep one per
+// span and filter the attributes and events through it before recording
+// them, such that every backend applies the limits the same way. It is safe
+// for concurrent use, and a nil *SpanLimiter enforces no limit.
+type SpanLimiter struct {
+	mu                sync.Mutex
+	limits            SpanLimits
+	keys              map[attribute.Key]struct{}
+	events            int
+	droppedAttributes int
+	droppedEvents     int
+}
+
+// NewSpanLimiter returns a SpanLimiter enforcing the given limits.
+func NewSpanLimiter(limits SpanLimits) *SpanLimiter {
+	return &SpanLimiter{
+		limits: limits.withDefaults(),
+		keys:   make(map[attribute.Key]struct{}),
+	}
+}
+
+// LimitAttributes returns the attributes to record, dropping the ones
+// beyond the attribute count limit and truncating the long string values.
+func (l *SpanLimiter) LimitAttributes(attrs ...attribute.KeyValue) []attribute.KeyValue {
+	if l == nil {
+		return attrs
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limited := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		if _, ok := l.keys[attr.Key]; !ok {
+			if l.limits.AttributeCountLimit >= 0 &&
+				len(l.keys) >= l.limits.AttributeCountLimit {
+				l.droppedAttributes++
+				continue
+			}
+			l.keys[attr.Key] = struct{}{}
+		}
+		limited = append(limited, l.truncate(attr))
+	}
+	return limited
+}
+
+// LimitEvent reports whether an event may still be recorded and returns
+// its attributes, capped to the attribute count limit and with the long
+// string values truncated. The attributes cut off the event are counted
+// within DroppedAttributes.
+func (l *SpanLimiter) LimitEvent(attrs []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	if l == nil {
+		return attrs, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limits.EventCountLimit >= 0 && l.events >= l.limits.EventCountLimit {
+		l.droppedEvents++
+		return nil, false
+	}
+	l.events++
+
+	if l.limits.AttributeCountLimit >= 0 && len(attrs) > l.limits.AttributeCountLimit {
+		l.droppedAttributes += len(attrs) - l.limits.AttributeCountLimit
+		attrs = attrs[:l.limits.AttributeCountLimit]
+	}
+	limited := make([]attribute.KeyValue, len(attrs))
+	for i, attr := range attrs {
+		limited[i] = l.truncate(attr)
+	}
+	return limited, true
+}
+
+// DroppedAttributes returns the number of attributes dropped so far, from
+// both the span and its events.
+func (l *SpanLimiter) DroppedAttributes() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.droppedAttributes
+}
+
+// DroppedEvents returns the number of events dropped so far.
+func (l *SpanLimiter) DroppedEvents() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.droppedEvents
+}
+
+func (l *SpanLimiter) truncate(attr attribute.KeyValue) attribute.KeyValue {
+	limit := l.limits.AttributeValueLengthLimit
+	if limit < 0 || attr.Key == attribute.DBStatementKey {
+		return attr
+	}
+
+	switch attr.Value.Type() {
+	case attribute.TypeString:
+		return attr.Key.String(truncateString(attr.Value.AsString(), limit))
+	case attribute.TypeStringSlice:
+		strs := attr.Value.AsStringSlice()
+		for i, s := range strs {
+			strs[i] = truncateString(s, limit)
+		}
+		return attr.Key.StringSlice(strs)
+	default:
+		return attr
+	}
+}
+
+// truncateString keeps the first limit characters of s followed by
+// TruncationMarker when s is longer than limit.
+func truncateString(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	end := 0
+	for i := 0; i < limit; i++ {
+		_, size := utf8.DecodeRuneInString(s[end:])
+		end += size
+	}
+	return s[:end] + TruncationMarker
+}
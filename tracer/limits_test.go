@@ -0,0 +1,165 @@
+package tracer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+func TestSpanLimits(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		if got := (SpanLimits{}).withDefaults(); got != DefaultSpanLimits() {
+			t.Errorf("expected zero limits to fall back to defaults, got %+v", got)
+		}
+	})
+
+	t.Run("Global", func(t *testing.T) {
+		defer SetSpanLimits(DefaultSpanLimits())
+
+		SetSpanLimits(SpanLimits{AttributeValueLengthLimit: 10})
+		got := GetSpanLimits()
+		if got.AttributeValueLengthLimit != 10 {
+			t.Errorf("expected value length limit 10, got %d",
+				got.AttributeValueLengthLimit)
+		} else if got.AttributeCountLimit != DefaultAttributeCountLimit {
+			t.Errorf("expected default count limit, got %d",
+				got.AttributeCountLimit)
+		}
+	})
+}
+
+func TestSpanLimiter_LimitAttributes(t *testing.T) {
+	t.Run("Count", func(t *testing.T) {
+		limiter := NewSpanLimiter(SpanLimits{AttributeCountLimit: 2})
+
+		got := limiter.LimitAttributes(
+			attribute.String("a", "1"),
+			attribute.String("b", "2"),
+			attribute.String("c", "3"),
+		)
+		if len(got) != 2 {
+			t.Fatalf("expected 2 attributes, got %v", got)
+		}
+
+		// Overwriting an existing key is still allowed.
+		got = limiter.LimitAttributes(attribute.String("a", "4"),
+			attribute.String("d", "5"))
+		if len(got) != 1 || got[0].Key != "a" {
+			t.Errorf("expected only the existing key to be kept, got %v", got)
+		}
+		if limiter.DroppedAttributes() != 2 {
+			t.Errorf("expected 2 dropped attributes, got %d",
+				limiter.DroppedAttributes())
+		}
+	})
+
+	t.Run("Value Length", func(t *testing.T) {
+		limiter := NewSpanLimiter(SpanLimits{AttributeValueLengthLimit: 3})
+
+		got := limiter.LimitAttributes(
+			attribute.String("short", "abc"),
+			attribute.String("long", "abcdef"),
+			attribute.String("unicode", "héllo"),
+			attribute.StringSlice("slice", []string{"a", "abcd"}),
+			attribute.Int("int", 123456),
+		)
+		want := []attribute.KeyValue{
+			attribute.String("short", "abc"),
+			attribute.String("long", "abc"+TruncationMarker),
+			attribute.String("unicode", "hél"+TruncationMarker),
+			attribute.StringSlice("slice", []string{"a", "abc" + TruncationMarker}),
+			attribute.Int("int", 123456),
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("Default Value Length", func(t *testing.T) {
+		limiter := NewSpanLimiter(DefaultSpanLimits())
+
+		body := strings.Repeat("a", 1<<20)
+		got := limiter.LimitAttributes(attribute.HTTPResponseBody(body))
+		want := body[:DefaultAttributeValueLengthLimit] + TruncationMarker
+		if len(got) != 1 || got[0].Value.AsString() != want {
+			t.Errorf("expected the body to be truncated to %d characters",
+				DefaultAttributeValueLengthLimit)
+		}
+	})
+
+	t.Run("Statement", func(t *testing.T) {
+		limiter := NewSpanLimiter(SpanLimits{AttributeValueLengthLimit: 3})
+
+		statement := "SELECT * FROM users"
+		got := limiter.LimitAttributes(attribute.DBStatement(statement))
+		if len(got) != 1 || got[0].Value.AsString() != statement {
+			t.Errorf("expected the statement to be kept whole, got %v", got)
+		}
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		limiter := NewSpanLimiter(SpanLimits{
+			AttributeCountLimit:       -1,
+			AttributeValueLengthLimit: -1,
+		})
+		if got := limiter.LimitAttributes(attribute.String("long",
+			strings.Repeat("a", 1<<20))); len(got[0].Value.AsString()) != 1<<20 {
+			t.Error("expected the value to be kept whole")
+		}
+
+		attrs := make([]attribute.KeyValue, 500)
+		for i := range attrs {
+			attrs[i] = attribute.Int(string(rune('a'+i%26))+string(rune(i)), i)
+		}
+		if got := limiter.LimitAttributes(attrs...); len(got) != len(attrs) {
+			t.Errorf("expected every attribute to be kept, got %d", len(got))
+		}
+	})
+}
+
+func TestSpanLimiter_Nil(t *testing.T) {
+	var limiter *SpanLimiter
+	attrs := []attribute.KeyValue{attribute.String("a", "abc")}
+	if got := limiter.LimitAttributes(attrs...); !reflect.DeepEqual(got, attrs) {
+		t.Errorf("want %v, got %v", attrs, got)
+	}
+	if _, ok := limiter.LimitEvent(attrs); !ok {
+		t.Error("expected the event to be recorded")
+	}
+	if limiter.DroppedAttributes() != 0 || limiter.DroppedEvents() != 0 {
+		t.Error("expected nothing to be dropped")
+	}
+}
+
+func TestSpanLimiter_LimitEvent(t *testing.T) {
+	limiter := NewSpanLimiter(SpanLimits{
+		AttributeCountLimit:       1,
+		AttributeValueLengthLimit: 1,
+		EventCountLimit:           1,
+	})
+
+	attrs, ok := limiter.LimitEvent([]attribute.KeyValue{
+		attribute.String("a", "abc"),
+		attribute.String("b", "abc"),
+	})
+	if !ok {
+		t.Fatal("expected the first event to be recorded")
+	}
+	want := []attribute.KeyValue{attribute.String("a", "a"+TruncationMarker)}
+	if !reflect.DeepEqual(attrs, want) {
+		t.Errorf("want %v, got %v", want, attrs)
+	}
+
+	if _, ok := limiter.LimitEvent(nil); ok {
+		t.Error("expected the second event to be dropped")
+	}
+	if limiter.DroppedEvents() != 1 {
+		t.Errorf("expected 1 dropped event, got %d", limiter.DroppedEvents())
+	}
+	if limiter.DroppedAttributes() != 1 {
+		t.Errorf("expected the attribute cut off the event to be dropped, got %d",
+			limiter.DroppedAttributes())
+	}
+}
@@ -0,0 +1,210 @@
+package tracer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// MultiTracer fans out every operation to multiple tracers, allowing a
+// service to emit to several backends at once. The first tracer is the
+// primary, it provides the trace id and span id of the composite spans.
+type MultiTracer struct {
+	tracers []Tracer
+}
+
+// Make sure that MultiTracer implements [Tracer] and [Propagator] during
+// compile time.
+var (
+	_ Tracer     = (*MultiTracer)(nil)
+	_ Propagator = (*MultiTracer)(nil)
+)
+
+// NewMultiTracer returns a MultiTracer fanning out to the given tracers in
+// order. Nil tracers are ignored.
+func NewMultiTracer(tracers ...Tracer) *MultiTracer {
+	mt := &MultiTracer{}
+	for _, t := range tracers {
+		if t != nil {
+			mt.tracers = append(mt.tracers, t)
+		}
+	}
+	return mt
+}
+
+// StartSpan starts a span on every tracer. The context is passed along
+// from one tracer to the next, such that the returned context holds the
+// spans of all tracers.
+func (mt *MultiTracer) StartSpan(ctx context.Context, name string, opts ...StartSpanOption) (context.Context, Span) {
+	if len(mt.tracers) == 0 {
+		return ctx, &NoopSpan{}
+	}
+
+	spans := make([]Span, 0, len(mt.tracers))
+	for _, t := range mt.tracers {
+		var span Span
+		ctx, span = t.StartSpan(ctx, name, opts...)
+		spans = append(spans, span)
+	}
+
+	return ctx, newMultiSpan(ctx, spans)
+}
+
+// Shutdown shuts every tracer down and returns the joined errors.
+func (mt *MultiTracer) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, t := range mt.tracers {
+		if err := t.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SpanFromContext retrieves the span of every tracer from the context. It
+// returns nil when none of the tracers found a span.
+func (mt *MultiTracer) SpanFromContext(ctx context.Context) Span {
+	var spans []Span
+	for _, t := range mt.tracers {
+		if span := t.SpanFromContext(ctx); span != nil {
+			spans = append(spans, span)
+		}
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	return newMultiSpan(ctx, spans)
+}
+
+// Inject lets every tracer implementing [Propagator] write its trace
+// context into the carrier.
+func (mt *MultiTracer) Inject(ctx context.Context, carrier Carrier) {
+	for _, t := range mt.tracers {
+		if propagator, ok := t.(Propagator); ok {
+			propagator.Inject(ctx, carrier)
+		}
+	}
+}
+
+// Extract lets every tracer implementing [Propagator] read its trace
+// context from the carrier into the returned context.
+func (mt *MultiTracer) Extract(ctx context.Context, carrier Carrier) context.Context {
+	for _, t := range mt.tracers {
+		if propagator, ok := t.(Propagator); ok {
+			ctx = propagator.Extract(ctx, carrier)
+		}
+	}
+	return ctx
+}
+
+// multiSpan propagates every operation to the spans of all tracers.
+type multiSpan struct {
+	spans       []Span
+	spanContext *wrappedSpanContext
+}
+
+// Make sure that multiSpan implements [Span] and [DiscardableSpan] during
+// compile time.
+var (
+	_ Span            = (*multiSpan)(nil)
+	_ DiscardableSpan = (*multiSpan)(nil)
+)
+
+func newMultiSpan(ctx context.Context, spans []Span) *multiSpan {
+	return &multiSpan{
+		spans: spans,
+		spanContext: &wrappedSpanContext{
+			spanContext: spans[0].Context(),
+			ctx:         ctx,
+		},
+	}
+}
+
+// End ends every span.
+func (s *multiSpan) End() {
+	for _, span := range s.spans {
+		span.End()
+	}
+}
+
+// Discard discards every span able to, and ends the others.
+func (s *multiSpan) Discard() {
+	for _, span := range s.spans {
+		if discardable, ok := span.(DiscardableSpan); ok {
+			discardable.Discard()
+		} else {
+			span.End()
+		}
+	}
+}
+
+// SetAttributes sets the attributes to every span.
+func (s *multiSpan) SetAttributes(attributes ...attribute.KeyValue) {
+	for _, span := range s.spans {
+		span.SetAttributes(attributes...)
+	}
+}
+
+// RecordError records the error to every span.
+func (s *multiSpan) RecordError(err error) {
+	for _, span := range s.spans {
+		span.RecordError(err)
+	}
+}
+
+// SetStatus sets the status of every span.
+func (s *multiSpan) SetStatus(code StatusCode, description string) {
+	for _, span := range s.spans {
+		span.SetStatus(code, description)
+	}
+}
+
+// AddEvent adds the event to every span. The timestamp is resolved once,
+// such that every span records the event at the same time.
+func (s *multiSpan) AddEvent(name string, opts ...EventOption) {
+	cfg := NewEventConfig(opts...)
+	eventOpts := make([]EventOption, 0, len(opts)+1)
+	eventOpts = append(eventOpts, opts...)
+	eventOpts = append(eventOpts, WithTimestamp(cfg.Timestamp))
+
+	for _, span := range s.spans {
+		span.AddEvent(name, eventOpts...)
+	}
+}
+
+// IsRecording returns true if any of the spans is recording.
+func (s *multiSpan) IsRecording() bool {
+	for _, span := range s.spans {
+		if span.IsRecording() {
+			return true
+		}
+	}
+	return false
+}
+
+// Context returns the SpanContext of the primary span, holding the
+// context of all spans.
+func (s *multiSpan) Context() SpanContext {
+	return s.spanContext
+}
+
+// wrappedSpanContext reports the ids of a span while holding a context
+// derived from the one of the span, for example holding additional spans.
+type wrappedSpanContext struct {
+	spanContext SpanContext
+	ctx         context.Context
+}
+
+// Make sure that wrappedSpanContext implements [SpanContext] during compile time.
+var _ SpanContext = (*wrappedSpanContext)(nil)
+
+// TraceID returns the trace id of the wrapped span.
+func (sc *wrappedSpanContext) TraceID() string { return sc.spanContext.TraceID() }
+
+// SpanID returns the span id of the wrapped span.
+func (sc *wrappedSpanContext) SpanID() string { return sc.spanContext.SpanID() }
+
+// Context returns the derived context.
+func (sc *wrappedSpanContext) Context() context.Context { return sc.ctx }
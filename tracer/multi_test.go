@@ -0,0 +1,217 @@
+package tracer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+type fakeSpanKey string
+
+// fakeTracer stores its spans in the context under its own key, mimicking
+// a backend, and records every span it starts.
+type fakeTracer struct {
+	id          string
+	shutdownErr error
+	spans       []*fakeSpan
+}
+
+func (t *fakeTracer) StartSpan(ctx context.Context, name string, _ ...StartSpanOption) (context.Context, Span) {
+	span := &fakeSpan{id: t.id + "-" + name, recording: true}
+	t.spans = append(t.spans, span)
+	ctx = context.WithValue(ctx, fakeSpanKey(t.id), span)
+	span.ctx = ctx
+	return ctx, span
+}
+
+func (t *fakeTracer) Shutdown(_ context.Context) error { return t.shutdownErr }
+
+func (t *fakeTracer) SpanFromContext(ctx context.Context) Span {
+	span, ok := ctx.Value(fakeSpanKey(t.id)).(*fakeSpan)
+	if !ok {
+		return nil
+	}
+	return span
+}
+
+func (t *fakeTracer) Inject(_ context.Context, carrier Carrier) {
+	carrier.Set(t.id, "injected")
+}
+
+func (t *fakeTracer) Extract(ctx context.Context, carrier Carrier) context.Context {
+	return context.WithValue(ctx, fakeSpanKey(t.id+"-extracted"), carrier.Get(t.id))
+}
+
+type fakeSpan struct {
+	id         string
+	ctx        context.Context
+	ended      bool
+	discarded  bool
+	recording  bool
+	attributes []attribute.KeyValue
+	err        error
+	status     StatusCode
+	events     []time.Time
+}
+
+func (s *fakeSpan) End() { s.ended = true }
+
+func (s *fakeSpan) Discard() { s.discarded = true }
+
+func (s *fakeSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attributes = append(s.attributes, kv...)
+}
+
+func (s *fakeSpan) RecordError(err error) { s.err = err }
+
+func (s *fakeSpan) SetStatus(code StatusCode, _ string) { s.status = code }
+
+func (s *fakeSpan) AddEvent(_ string, opts ...EventOption) {
+	s.events = append(s.events, NewEventConfig(opts...).Timestamp)
+}
+
+func (s *fakeSpan) IsRecording() bool { return s.recording }
+
+func (s *fakeSpan) Context() SpanContext { return &fakeSpanContext{s} }
+
+type fakeSpanContext struct{ span *fakeSpan }
+
+func (sc *fakeSpanContext) TraceID() string          { return "trace-" + sc.span.id }
+func (sc *fakeSpanContext) SpanID() string           { return sc.span.id }
+func (sc *fakeSpanContext) Context() context.Context { return sc.span.ctx }
+
+func TestMultiTracer_StartSpan(t *testing.T) {
+	first, second := &fakeTracer{id: "first"}, &fakeTracer{id: "second"}
+	mt := NewMultiTracer(first, nil, second)
+
+	ctx, span := mt.StartSpan(context.Background(), "span")
+	if len(first.spans) != 1 || len(second.spans) != 1 {
+		t.Fatal("expected every tracer to start a span")
+	}
+	if first.SpanFromContext(ctx) == nil || second.SpanFromContext(ctx) == nil {
+		t.Error("expected the context to hold the spans of every tracer")
+	}
+
+	t.Run("Primary", func(t *testing.T) {
+		if span.Context().TraceID() != "trace-first-span" {
+			t.Errorf("expected primary trace id but got %s",
+				span.Context().TraceID())
+		} else if span.Context().SpanID() != "first-span" {
+			t.Errorf("expected primary span id but got %s",
+				span.Context().SpanID())
+		} else if span.Context().Context() != ctx {
+			t.Error("expected span context to hold the returned context")
+		}
+	})
+
+	t.Run("FanOut", func(t *testing.T) {
+		timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		span.SetAttributes(attribute.KeyValuePair("key", "value"))
+		span.RecordError(errors.New("some_error"))
+		span.SetStatus(StatusError, "some_error")
+		span.AddEvent("event", WithTimestamp(timestamp))
+		span.AddEvent("event")
+		span.End()
+
+		for _, fake := range []*fakeSpan{first.spans[0], second.spans[0]} {
+			if len(fake.attributes) != 1 {
+				t.Errorf("%s: expected 1 attribute", fake.id)
+			} else if fake.err == nil {
+				t.Errorf("%s: expected error to be recorded", fake.id)
+			} else if fake.status != StatusError {
+				t.Errorf("%s: expected status to be Error", fake.id)
+			} else if len(fake.events) != 2 || !fake.events[0].Equal(timestamp) {
+				t.Errorf("%s: expected 2 events, got %v", fake.id, fake.events)
+			} else if !fake.ended {
+				t.Errorf("%s: expected span to be ended", fake.id)
+			}
+		}
+		if !first.spans[0].events[1].Equal(second.spans[0].events[1]) {
+			t.Error("expected every span to share the event timestamp")
+		}
+	})
+
+	t.Run("IsRecording", func(t *testing.T) {
+		first.spans[0].recording = false
+		if !span.IsRecording() {
+			t.Error("expected span to be recording while any span is")
+		}
+		second.spans[0].recording = false
+		if span.IsRecording() {
+			t.Error("expected span to not be recording")
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, span := NewMultiTracer().StartSpan(context.Background(), "span")
+		if _, ok := span.(*NoopSpan); !ok {
+			t.Error("expected a NoopSpan without tracers")
+		}
+	})
+}
+
+func TestMultiTracer_Discard(t *testing.T) {
+	fake := &fakeTracer{id: "fake"}
+	mt := NewMultiTracer(fake, new(NoopTracer))
+
+	_, span := mt.StartSpan(context.Background(), "span")
+	span.(DiscardableSpan).Discard()
+	if !fake.spans[0].discarded || fake.spans[0].ended {
+		t.Error("expected the discardable span to be discarded")
+	}
+}
+
+func TestMultiTracer_SpanFromContext(t *testing.T) {
+	first, second := &fakeTracer{id: "first"}, &fakeTracer{id: "second"}
+	mt := NewMultiTracer(first, second)
+
+	if span := mt.SpanFromContext(context.Background()); span != nil {
+		t.Error("expected nil span from an empty context")
+	}
+
+	// Only the second tracer has a span, it becomes the primary.
+	ctx, _ := second.StartSpan(context.Background(), "span")
+	span := mt.SpanFromContext(ctx)
+	if span == nil {
+		t.Fatal("expected a span")
+	} else if span.Context().SpanID() != "second-span" {
+		t.Errorf("expected second-span but got %s", span.Context().SpanID())
+	}
+}
+
+func TestMultiTracer_Shutdown(t *testing.T) {
+	errFirst, errSecond := errors.New("first"), errors.New("second")
+	mt := NewMultiTracer(
+		&fakeTracer{id: "first", shutdownErr: errFirst},
+		&fakeTracer{id: "second"},
+		&fakeTracer{id: "third", shutdownErr: errSecond},
+	)
+
+	err := mt.Shutdown(context.Background())
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Errorf("expected joined errors but got %v", err)
+	}
+	if err := NewMultiTracer(&fakeTracer{}).Shutdown(context.Background()); err != nil {
+		t.Errorf("expected nil error but got %v", err)
+	}
+}
+
+func TestMultiTracer_Propagation(t *testing.T) {
+	mt := NewMultiTracer(&fakeTracer{id: "first"}, new(NoopTracer),
+		&fakeTracer{id: "second"})
+
+	carrier := MapCarrier{}
+	mt.Inject(context.Background(), carrier)
+	if carrier.Get("first") != "injected" || carrier.Get("second") != "injected" {
+		t.Errorf("expected every propagator to inject but got %v", carrier)
+	}
+
+	ctx := mt.Extract(context.Background(), carrier)
+	if ctx.Value(fakeSpanKey("first-extracted")) != "injected" ||
+		ctx.Value(fakeSpanKey("second-extracted")) != "injected" {
+		t.Error("expected every propagator to extract")
+	}
+}
@@ -9,8 +9,12 @@ import (
 // NoopTracer a no operation tracer that implements [Tracer].
 type NoopTracer struct{}
 
-// Make sure that NoopTracer implements [Tracer] during compile time.
-var _ Tracer = (*NoopTracer)(nil)
+// Make sure that NoopTracer implements [Tracer] and [Propagator] during
+// compile time.
+var (
+	_ Tracer     = (*NoopTracer)(nil)
+	_ Propagator = (*NoopTracer)(nil)
+)
 
 // StartSpan does returns the context itself and a [NoopSpan].
 func (t *NoopTracer) StartSpan(ctx context.Context, _ string, _ ...StartSpanOption) (context.Context, Span) {
@@ -25,6 +29,14 @@ func (t *NoopTracer) SpanFromContext(ctx context.Context) Span {
 	return &NoopSpan{}
 }
 
+// Inject does nothing.
+func (t *NoopTracer) Inject(_ context.Context, _ Carrier) {}
+
+// Extract returns the context itself.
+func (t *NoopTracer) Extract(ctx context.Context, _ Carrier) context.Context {
+	return ctx
+}
+
 // NoopSpan n no operation span that implements [Span].
 type NoopSpan struct{}
 
@@ -40,8 +52,14 @@ func (s *NoopSpan) SetAttributes(_ ...attribute.KeyValue) {}
 // RecordError does nothing.
 func (s *NoopSpan) RecordError(_ error) {}
 
+// SetStatus does nothing.
+func (s *NoopSpan) SetStatus(_ StatusCode, _ string) {}
+
 // AddEvent does nothing.
-func (s *NoopSpan) AddEvent(_ ...string) {}
+func (s *NoopSpan) AddEvent(_ string, _ ...EventOption) {}
+
+// IsRecording always returns false.
+func (s *NoopSpan) IsRecording() bool { return false }
 
 // Context returns an empty context.
 func (s *NoopSpan) Context() SpanContext {
@@ -45,6 +45,25 @@ func TestNoopTracer_SpanFromContext(t *testing.T) {
 	}
 }
 
+func TestNoopTracer_Inject(t *testing.T) {
+	noop := &NoopTracer{}
+
+	carrier := MapCarrier{}
+	noop.Inject(context.Background(), carrier)
+	if len(carrier) != 0 {
+		t.Error("carrier should be empty")
+	}
+}
+
+func TestNoopTracer_Extract(t *testing.T) {
+	noop := &NoopTracer{}
+
+	ctx := context.Background()
+	if noop.Extract(ctx, MapCarrier{}) != ctx {
+		t.Error("ctx should be returned as is")
+	}
+}
+
 func TestNoopSpan_End(t *testing.T) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -77,7 +96,7 @@ func TestNoopSpan_AddEvent(t *testing.T) {
 
 	span := &NoopSpan{}
 	span.AddEvent("event_1")
-	span.AddEvent("event_2")
+	span.AddEvent("event_2", WithStackTrace())
 }
 
 func TestNoopSpan_RecordError(t *testing.T) {
@@ -92,6 +111,17 @@ func TestNoopSpan_RecordError(t *testing.T) {
 	span.RecordError(errors.New("some_error"))
 }
 
+func TestNoopSpan_SetStatus(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Error("should not panic")
+		}
+	}()
+
+	span := &NoopSpan{}
+	span.SetStatus(StatusError, "some_error")
+}
+
 func TestNoopSpan_Context(t *testing.T) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -106,6 +136,13 @@ func TestNoopSpan_Context(t *testing.T) {
 	}
 }
 
+func TestNoopSpan_IsRecording(t *testing.T) {
+	span := &NoopSpan{}
+	if span.IsRecording() {
+		t.Error("noop span should not be recording")
+	}
+}
+
 func TestNoopSpanContext_TraceID(t *testing.T) {
 	defer func() {
 		if r := recover(); r != nil {
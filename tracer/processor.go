@@ -0,0 +1,412 @@
+package tracer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// SpanProcessor is a hook run by a ProcessingTracer when spans start and
+// end, before the span is handed over to the underlying tracer for export.
+// Since it only relies on the tengcorux API, a processor behaves the same
+// whatever the backend is.
+type SpanProcessor interface {
+	// OnStart is called when a span is started. Processors may enrich the
+	// span with attributes or drop it.
+	OnStart(ctx context.Context, span ReadWriteSpan)
+
+	// OnEnd is called when a span is ended, before the underlying span is
+	// ended. Processors may drop the span, for example to filter it.
+	OnEnd(span ReadWriteSpan)
+
+	// ForceFlush exports any pending data of the processor.
+	ForceFlush(ctx context.Context) error
+
+	// Shutdown stops the processor, it is called once on tracer shutdown.
+	Shutdown(ctx context.Context) error
+}
+
+// AttributeProcessor is optionally implemented by a SpanProcessor to process
+// the attributes of a span as they are set, before they are forwarded to the
+// underlying span, for example to redact or filter them.
+type AttributeProcessor interface {
+	// OnSetAttributes returns the attributes to set on the span.
+	OnSetAttributes(span ReadOnlySpan, attributes []attribute.KeyValue) []attribute.KeyValue
+}
+
+// DiscardableSpan is implemented by spans able to end without being
+// exported, such as the spans of the OpenTelemetry and SkyWalking tracers.
+// A span dropped by a SpanProcessor is discarded when the underlying span
+// implements it, else it is ended as usual.
+type DiscardableSpan interface {
+	Span
+
+	// Discard ends the span without exporting it.
+	Discard()
+}
+
+// ReadOnlySpan exposes the information of a span to a SpanProcessor.
+type ReadOnlySpan interface {
+	// Name returns the name of the span.
+	Name() string
+
+	// SpanType returns the type of the span.
+	SpanType() SpanType
+
+	// SpanLayer returns the layer of the span.
+	SpanLayer() SpanLayer
+
+	// Attributes returns a copy of the attributes of the span.
+	Attributes() []attribute.KeyValue
+
+	// Status returns the status code and description of the span.
+	Status() (StatusCode, string)
+
+	// StartTime returns the time the span started.
+	StartTime() time.Time
+
+	// EndTime returns the time the span ended, zero while still running.
+	EndTime() time.Time
+
+	// SpanContext returns the SpanContext of the span.
+	SpanContext() SpanContext
+
+	// Dropped reports whether a processor dropped the span.
+	Dropped() bool
+}
+
+// ReadWriteSpan allows a SpanProcessor to modify the attributes of a span
+// and to drop it.
+type ReadWriteSpan interface {
+	ReadOnlySpan
+
+	// SetAttributes sets the attributes, overwriting existing ones with
+	// the same key.
+	SetAttributes(attributes ...attribute.KeyValue)
+
+	// Drop drops the span. The span stops recording, the remaining
+	// processors are skipped and the underlying span is discarded on end.
+	Drop()
+}
+
+// ProcessingTracer wraps a Tracer and runs the registered SpanProcessors
+// on every span it starts. Attributes are forwarded to the underlying span
+// as they are set, once processed by the processors implementing
+// [AttributeProcessor], such that the backend sees them while the span is
+// live.
+type ProcessingTracer struct {
+	tracer     Tracer
+	processors []SpanProcessor
+}
+
+// Make sure that ProcessingTracer implements [Tracer] and [Propagator]
+// during compile time.
+var (
+	_ Tracer     = (*ProcessingTracer)(nil)
+	_ Propagator = (*ProcessingTracer)(nil)
+)
+
+// NewProcessingTracer returns a ProcessingTracer running the processors in
+// the given order on the spans of t. Nil processors are ignored.
+func NewProcessingTracer(t Tracer, processors ...SpanProcessor) *ProcessingTracer {
+	pt := &ProcessingTracer{tracer: t}
+	for _, processor := range processors {
+		if processor != nil {
+			pt.processors = append(pt.processors, processor)
+		}
+	}
+	return pt
+}
+
+type processingSpanContextKey struct{}
+
+// processingSpanKey is the key that holds the *processingSpan value inside
+// a context.
+var processingSpanKey processingSpanContextKey
+
+// StartSpan starts a span on the underlying tracer and calls OnStart of
+// every processor.
+func (pt *ProcessingTracer) StartSpan(ctx context.Context, name string, opts ...StartSpanOption) (context.Context, Span) {
+	cfg := DefaultStartSpanConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	ctx, span := pt.tracer.StartSpan(ctx, name, opts...)
+	ps := &processingSpan{
+		span:      span,
+		tracer:    pt,
+		name:      name,
+		spanType:  cfg.SpanType,
+		spanLayer: cfg.SpanLayer,
+		startTime: time.Now(),
+	}
+
+	ctx = context.WithValue(ctx, processingSpanKey, ps)
+	ps.spanContext = &wrappedSpanContext{spanContext: span.Context(), ctx: ctx}
+
+	for _, processor := range pt.processors {
+		processor.OnStart(ctx, ps)
+		if ps.Dropped() {
+			break
+		}
+	}
+
+	return ctx, ps
+}
+
+// Shutdown shuts the processors down, then the underlying tracer. It
+// returns the joined errors.
+func (pt *ProcessingTracer) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, processor := range pt.processors {
+		if err := processor.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := pt.tracer.Shutdown(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// ForceFlush flushes every processor and returns the joined errors.
+func (pt *ProcessingTracer) ForceFlush(ctx context.Context) error {
+	var errs []error
+	for _, processor := range pt.processors {
+		if err := processor.ForceFlush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SpanFromContext returns the span started by this tracer if it is the
+// active span of the underlying tracer, else the underlying span as is.
+func (pt *ProcessingTracer) SpanFromContext(ctx context.Context) Span {
+	span := pt.tracer.SpanFromContext(ctx)
+	if span == nil {
+		return nil
+	}
+
+	ps, ok := ctx.Value(processingSpanKey).(*processingSpan)
+	if ok && ps.span.Context().SpanID() == span.Context().SpanID() {
+		return ps
+	}
+	return span
+}
+
+// Inject delegates to the underlying tracer if it is a [Propagator].
+func (pt *ProcessingTracer) Inject(ctx context.Context, carrier Carrier) {
+	if propagator, ok := pt.tracer.(Propagator); ok {
+		propagator.Inject(ctx, carrier)
+	}
+}
+
+// Extract delegates to the underlying tracer if it is a [Propagator].
+func (pt *ProcessingTracer) Extract(ctx context.Context, carrier Carrier) context.Context {
+	if propagator, ok := pt.tracer.(Propagator); ok {
+		return propagator.Extract(ctx, carrier)
+	}
+	return ctx
+}
+
+// processingSpan forwards the processed attributes to the underlying span
+// and keeps a copy of them for the processors.
+type processingSpan struct {
+	span        Span
+	spanContext *wrappedSpanContext
+	tracer      *ProcessingTracer
+
+	mu                sync.Mutex
+	name              string
+	spanType          SpanType
+	spanLayer         SpanLayer
+	attributes        []attribute.KeyValue
+	status            StatusCode
+	statusDescription string
+	startTime         time.Time
+	endTime           time.Time
+	dropped           bool
+}
+
+// Make sure that processingSpan implements [DiscardableSpan] and
+// [ReadWriteSpan] during compile time.
+var (
+	_ DiscardableSpan = (*processingSpan)(nil)
+	_ ReadWriteSpan   = (*processingSpan)(nil)
+)
+
+// End calls OnEnd of every processor until one drops the span, then ends
+// the underlying span, or discards it if dropped.
+func (s *processingSpan) End() {
+	s.mu.Lock()
+	if !s.endTime.IsZero() {
+		s.mu.Unlock()
+		return
+	}
+	s.endTime = time.Now()
+	s.mu.Unlock()
+
+	if !s.Dropped() {
+		for _, processor := range s.tracer.processors {
+			processor.OnEnd(s)
+			if s.Dropped() {
+				break
+			}
+		}
+	}
+
+	if discardable, ok := s.span.(DiscardableSpan); ok && s.Dropped() {
+		discardable.Discard()
+		return
+	}
+	s.span.End()
+}
+
+// Discard drops the span and ends it, skipping OnEnd of the processors.
+func (s *processingSpan) Discard() {
+	s.Drop()
+	s.End()
+}
+
+// SetAttributes runs the attribute processors on the attributes, keeps a
+// copy of them, overwriting existing ones with the same key, and forwards
+// them to the underlying span. It does nothing once the span is dropped.
+func (s *processingSpan) SetAttributes(attributes ...attribute.KeyValue) {
+	if s.Dropped() {
+		return
+	}
+
+	for _, processor := range s.tracer.processors {
+		if attributeProcessor, ok := processor.(AttributeProcessor); ok {
+			attributes = attributeProcessor.OnSetAttributes(s, attributes)
+		}
+	}
+	if len(attributes) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	for _, attr := range attributes {
+		replaced := false
+		for i := range s.attributes {
+			if s.attributes[i].Key == attr.Key {
+				s.attributes[i] = attr
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			s.attributes = append(s.attributes, attr)
+		}
+	}
+	s.mu.Unlock()
+
+	s.span.SetAttributes(attributes...)
+}
+
+// Drop marks the span as dropped.
+func (s *processingSpan) Drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropped = true
+}
+
+// Dropped reports whether the span is dropped.
+func (s *processingSpan) Dropped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// RecordError records the error to the underlying span unless dropped.
+func (s *processingSpan) RecordError(err error) {
+	if s.Dropped() {
+		return
+	}
+	s.span.RecordError(err)
+}
+
+// SetStatus sets the status of the underlying span unless dropped.
+func (s *processingSpan) SetStatus(code StatusCode, description string) {
+	s.mu.Lock()
+	if s.dropped {
+		s.mu.Unlock()
+		return
+	}
+	if code != StatusUnset && s.status != StatusOk {
+		s.status = code
+		s.statusDescription = ""
+		if code == StatusError {
+			s.statusDescription = description
+		}
+	}
+	s.mu.Unlock()
+
+	s.span.SetStatus(code, description)
+}
+
+// AddEvent adds the event to the underlying span unless dropped.
+func (s *processingSpan) AddEvent(name string, opts ...EventOption) {
+	if s.Dropped() {
+		return
+	}
+	s.span.AddEvent(name, opts...)
+}
+
+// IsRecording returns whether the underlying span is recording and the
+// span is not dropped.
+func (s *processingSpan) IsRecording() bool {
+	return !s.Dropped() && s.span.IsRecording()
+}
+
+// Context returns the SpanContext of the underlying span, holding the
+// context returned by StartSpan.
+func (s *processingSpan) Context() SpanContext {
+	return s.spanContext
+}
+
+// Name returns the name of the span.
+func (s *processingSpan) Name() string { return s.name }
+
+// SpanType returns the type of the span.
+func (s *processingSpan) SpanType() SpanType { return s.spanType }
+
+// SpanLayer returns the layer of the span.
+func (s *processingSpan) SpanLayer() SpanLayer { return s.spanLayer }
+
+// Attributes returns a copy of the attributes kept by the span.
+func (s *processingSpan) Attributes() []attribute.KeyValue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attributes := make([]attribute.KeyValue, len(s.attributes))
+	copy(attributes, s.attributes)
+	return attributes
+}
+
+// Status returns the status code and description of the span.
+func (s *processingSpan) Status() (StatusCode, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status, s.statusDescription
+}
+
+// StartTime returns the time the span started.
+func (s *processingSpan) StartTime() time.Time { return s.startTime }
+
+// EndTime returns the time the span ended.
+func (s *processingSpan) EndTime() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.endTime
+}
+
+// SpanContext returns the SpanContext of the span.
+func (s *processingSpan) SpanContext() SpanContext {
+	return s.spanContext
+}
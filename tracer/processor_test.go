@@ -0,0 +1,261 @@
+package tracer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
+
+// fakeProcessor enriches spans on start, redacts and filters attributes as
+// they are set and drops the spans named "health" on end.
+type fakeProcessor struct {
+	started, ended []ReadOnlySpan
+	flushErr       error
+	shutdownErr    error
+}
+
+func (p *fakeProcessor) OnStart(_ context.Context, span ReadWriteSpan) {
+	p.started = append(p.started, span)
+	span.SetAttributes(attribute.KeyValuePair("service.version", "v1"))
+}
+
+func (p *fakeProcessor) OnSetAttributes(_ ReadOnlySpan, attributes []attribute.KeyValue) []attribute.KeyValue {
+	processed := make([]attribute.KeyValue, 0, len(attributes))
+	for _, attr := range attributes {
+		switch attr.Key {
+		case "internal":
+			continue
+		case "password":
+			attr = attribute.KeyValuePair("password", "****")
+		}
+		processed = append(processed, attr)
+	}
+	return processed
+}
+
+func (p *fakeProcessor) OnEnd(span ReadWriteSpan) {
+	p.ended = append(p.ended, span)
+	if span.Name() == "health" {
+		span.Drop()
+	}
+}
+
+func (p *fakeProcessor) ForceFlush(_ context.Context) error { return p.flushErr }
+
+func (p *fakeProcessor) Shutdown(_ context.Context) error { return p.shutdownErr }
+
+// droppingProcessor drops every span on start.
+type droppingProcessor struct{ fakeProcessor }
+
+func (p *droppingProcessor) OnStart(_ context.Context, span ReadWriteSpan) {
+	span.Drop()
+}
+
+func TestProcessingTracer_StartSpan(t *testing.T) {
+	fake := &fakeTracer{id: "fake"}
+	processor := &fakeProcessor{}
+	pt := NewProcessingTracer(fake, processor, nil)
+
+	ctx, span := pt.StartSpan(context.Background(), "span",
+		WithSpanType(SpanTypeExit), WithSpanLayer(SpanLayerHttp))
+	span.SetAttributes(
+		attribute.KeyValuePair("password", "secret"),
+		attribute.KeyValuePair("internal", true),
+		attribute.KeyValuePair("user", "alice"),
+	)
+
+	t.Run("OnStart", func(t *testing.T) {
+		if len(processor.started) != 1 {
+			t.Fatalf("expected 1 started span but got %d", len(processor.started))
+		}
+		started := processor.started[0]
+		if started.Name() != "span" {
+			t.Errorf("expected name to be span but got %s", started.Name())
+		} else if started.SpanType() != SpanTypeExit {
+			t.Errorf("expected exit span type but got %d", started.SpanType())
+		} else if started.SpanLayer() != SpanLayerHttp {
+			t.Errorf("expected http span layer but got %d", started.SpanLayer())
+		} else if started.StartTime().IsZero() {
+			t.Error("expected a start time")
+		} else if started.SpanContext().Context() != ctx {
+			t.Error("expected the span context to hold the returned context")
+		}
+	})
+
+	t.Run("Forwarded Attributes", func(t *testing.T) {
+		attributes := make(map[attribute.Key]any)
+		for _, attr := range fake.spans[0].attributes {
+			attributes[attr.Key] = attr.Value.AsInterface()
+		}
+		if len(attributes) != 3 {
+			t.Errorf("expected 3 attributes but got %v", attributes)
+		}
+		if attributes["service.version"] != "v1" {
+			t.Error("expected the span to be enriched")
+		} else if attributes["password"] != "****" {
+			t.Error("expected the password to be redacted")
+		} else if _, ok := attributes["internal"]; ok {
+			t.Error("expected the internal attribute to be filtered")
+		} else if attributes["user"] != "alice" {
+			t.Error("expected the user attribute to be kept")
+		}
+		if len(span.(ReadOnlySpan).Attributes()) != 3 {
+			t.Error("expected the processing span to keep a copy of the attributes")
+		}
+		if pt.SpanFromContext(ctx) != span {
+			t.Error("expected SpanFromContext to return the processing span")
+		}
+	})
+
+	t.Run("OnEnd", func(t *testing.T) {
+		span.SetStatus(StatusError, "some_error")
+		span.End()
+		span.End()
+
+		if len(processor.ended) != 1 {
+			t.Fatalf("expected 1 ended span but got %d", len(processor.ended))
+		}
+		if code, description := processor.ended[0].Status(); code != StatusError ||
+			description != "some_error" {
+			t.Errorf("unexpected status %s %s", code, description)
+		}
+		if processor.ended[0].EndTime().IsZero() {
+			t.Error("expected an end time")
+		}
+		if !fake.spans[0].ended || fake.spans[0].discarded {
+			t.Fatal("expected the underlying span to be ended")
+		}
+	})
+}
+
+func TestProcessingTracer_Drop(t *testing.T) {
+	t.Run("OnEnd", func(t *testing.T) {
+		fake := &fakeTracer{id: "fake"}
+		first, second := &fakeProcessor{}, &fakeProcessor{}
+		pt := NewProcessingTracer(fake, first, second)
+
+		_, span := pt.StartSpan(context.Background(), "health")
+		span.End()
+
+		if !span.(ReadOnlySpan).Dropped() {
+			t.Error("expected the span to be dropped")
+		}
+		if len(first.ended) != 1 || len(second.ended) != 0 {
+			t.Error("expected the processors after the dropping one to be skipped")
+		}
+		if !fake.spans[0].discarded || fake.spans[0].ended {
+			t.Error("expected the underlying span to be discarded")
+		}
+	})
+
+	t.Run("Discard", func(t *testing.T) {
+		fake := &fakeTracer{id: "fake"}
+		processor := &fakeProcessor{}
+		pt := NewProcessingTracer(fake, processor)
+
+		_, span := pt.StartSpan(context.Background(), "span")
+		span.(DiscardableSpan).Discard()
+
+		if len(processor.ended) != 0 {
+			t.Error("expected the processors to be skipped")
+		}
+		if !fake.spans[0].discarded || fake.spans[0].ended {
+			t.Error("expected the underlying span to be discarded")
+		}
+	})
+
+	t.Run("OnStart", func(t *testing.T) {
+		fake := &fakeTracer{id: "fake"}
+		processor := &fakeProcessor{}
+		pt := NewProcessingTracer(fake, &droppingProcessor{}, processor)
+
+		_, span := pt.StartSpan(context.Background(), "span")
+		span.SetAttributes(attribute.KeyValuePair("user", "alice"))
+		span.SetStatus(StatusError, "some_error")
+		span.AddEvent("event")
+		span.RecordError(errors.New("some_error"))
+		if span.IsRecording() {
+			t.Error("expected a dropped span to not be recording")
+		}
+		span.End()
+
+		underlying := fake.spans[0]
+		if len(processor.started) != 0 || len(processor.ended) != 0 {
+			t.Error("expected the processors to be skipped")
+		}
+		if len(underlying.attributes) != 0 || len(underlying.events) != 0 ||
+			underlying.err != nil || underlying.status != StatusUnset {
+			t.Error("expected nothing to be forwarded to the underlying span")
+		}
+		if !underlying.discarded {
+			t.Error("expected the underlying span to be discarded")
+		}
+	})
+}
+
+func TestProcessingTracer_SpanFromContext(t *testing.T) {
+	fake := &fakeTracer{id: "fake"}
+	pt := NewProcessingTracer(fake)
+
+	if span := pt.SpanFromContext(context.Background()); span != nil {
+		t.Error("expected nil span from an empty context")
+	}
+
+	// A span started directly on the underlying tracer is returned as is.
+	ctx, _ := pt.StartSpan(context.Background(), "processed")
+	ctx, child := fake.StartSpan(ctx, "child")
+	if span := pt.SpanFromContext(ctx); span != child {
+		t.Error("expected the underlying span to be returned")
+	}
+}
+
+func TestProcessingTracer_Shutdown(t *testing.T) {
+	errFlush, errShutdown := errors.New("flush"), errors.New("shutdown")
+	errTracer := errors.New("tracer")
+	pt := NewProcessingTracer(&fakeTracer{shutdownErr: errTracer},
+		&fakeProcessor{flushErr: errFlush, shutdownErr: errShutdown},
+		&fakeProcessor{})
+
+	if err := pt.ForceFlush(context.Background()); !errors.Is(err, errFlush) {
+		t.Errorf("expected flush error but got %v", err)
+	}
+
+	err := pt.Shutdown(context.Background())
+	if !errors.Is(err, errShutdown) || !errors.Is(err, errTracer) {
+		t.Errorf("expected joined errors but got %v", err)
+	}
+}
+
+func TestProcessingTracer_Propagation(t *testing.T) {
+	t.Run("Propagator", func(t *testing.T) {
+		pt := NewProcessingTracer(&fakeTracer{id: "fake"})
+
+		carrier := MapCarrier{}
+		pt.Inject(context.Background(), carrier)
+		if carrier.Get("fake") != "injected" {
+			t.Errorf("expected carrier to be injected but got %v", carrier)
+		}
+
+		ctx := pt.Extract(context.Background(), carrier)
+		if ctx.Value(fakeSpanKey("fake-extracted")) != "injected" {
+			t.Error("expected the underlying tracer to extract")
+		}
+	})
+
+	t.Run("Non Propagator", func(t *testing.T) {
+		pt := NewProcessingTracer(struct{ Tracer }{new(NoopTracer)})
+
+		carrier := MapCarrier{}
+		pt.Inject(context.Background(), carrier)
+		if len(carrier) != 0 {
+			t.Errorf("expected empty carrier but got %v", carrier)
+		}
+
+		ctx := context.Background()
+		if pt.Extract(ctx, carrier) != ctx {
+			t.Error("expected the same context to be returned")
+		}
+	})
+}
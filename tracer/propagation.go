@@ -0,0 +1,99 @@
+package tracer
+
+import (
+	"context"
+	"net/http"
+)
+
+// Carrier is the medium used by a Propagator to carry the trace context
+// across process boundaries, for example HTTP headers or message metadata.
+type Carrier interface {
+	// Get returns the value associated with the given key.
+	Get(key string) string
+
+	// Set stores the key-value pair into the carrier.
+	Set(key string, value string)
+
+	// Keys lists the keys stored in the carrier.
+	Keys() []string
+}
+
+// HeaderCarrier adapts http.Header to satisfy the Carrier interface.
+type HeaderCarrier http.Header
+
+// Make sure that HeaderCarrier implements [Carrier] during compile time.
+var _ Carrier = HeaderCarrier{}
+
+// Get returns the value associated with the given key.
+func (hc HeaderCarrier) Get(key string) string {
+	return http.Header(hc).Get(key)
+}
+
+// Set stores the key-value pair into the header.
+func (hc HeaderCarrier) Set(key string, value string) {
+	http.Header(hc).Set(key, value)
+}
+
+// Keys lists the keys stored in the header.
+func (hc HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range hc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// MapCarrier adapts map[string]string to satisfy the Carrier interface.
+type MapCarrier map[string]string
+
+// Make sure that MapCarrier implements [Carrier] during compile time.
+var _ Carrier = MapCarrier{}
+
+// Get returns the value associated with the given key.
+func (mc MapCarrier) Get(key string) string {
+	return mc[key]
+}
+
+// Set stores the key-value pair into the map.
+func (mc MapCarrier) Set(key string, value string) {
+	mc[key] = value
+}
+
+// Keys lists the keys stored in the map.
+func (mc MapCarrier) Keys() []string {
+	keys := make([]string, 0, len(mc))
+	for k := range mc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Propagator injects and extracts the trace context into and from a Carrier
+// using the wire format of the underlying tracer implementation. Tracer
+// implementations that are able to propagate their context implement this
+// interface alongside [Tracer].
+type Propagator interface {
+	// Inject writes the trace context found in ctx into the carrier.
+	Inject(ctx context.Context, carrier Carrier)
+
+	// Extract reads the trace context from the carrier and returns a copy of
+	// ctx holding it, such that the next started span continues the trace.
+	Extract(ctx context.Context, carrier Carrier) context.Context
+}
+
+// Inject writes the trace context found in ctx into the carrier using the
+// global tracer. It does nothing if the global tracer is not a [Propagator].
+func Inject(ctx context.Context, carrier Carrier) {
+	if propagator, ok := GetGlobalTracer().(Propagator); ok {
+		propagator.Inject(ctx, carrier)
+	}
+}
+
+// Extract reads the trace context from the carrier using the global tracer.
+// It returns ctx as is if the global tracer is not a [Propagator].
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	if propagator, ok := GetGlobalTracer().(Propagator); ok {
+		return propagator.Extract(ctx, carrier)
+	}
+	return ctx
+}
@@ -0,0 +1,106 @@
+package tracer
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+)
+
+func TestHeaderCarrier(t *testing.T) {
+	header := http.Header{}
+	carrier := HeaderCarrier(header)
+
+	carrier.Set("traceparent", "some_value")
+	if got := carrier.Get("Traceparent"); got != "some_value" {
+		t.Errorf("expected some_value but got %s", got)
+	}
+	if got := header.Get("traceparent"); got != "some_value" {
+		t.Errorf("expected the underlying header to be set, but got %s", got)
+	}
+
+	keys := carrier.Keys()
+	if len(keys) != 1 || keys[0] != "Traceparent" {
+		t.Errorf("expected keys to be [Traceparent] but got %v", keys)
+	}
+}
+
+func TestMapCarrier(t *testing.T) {
+	carrier := MapCarrier{}
+	carrier.Set("sw8", "some_value")
+	carrier.Set("sw8-correlation", "some_other_value")
+
+	if got := carrier.Get("sw8"); got != "some_value" {
+		t.Errorf("expected some_value but got %s", got)
+	}
+	if got := carrier.Get("unknown"); got != "" {
+		t.Errorf("expected empty value but got %s", got)
+	}
+
+	keys := carrier.Keys()
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "sw8" || keys[1] != "sw8-correlation" {
+		t.Errorf("expected keys to be [sw8 sw8-correlation] but got %v", keys)
+	}
+}
+
+type propagatingTracer struct {
+	*NoopTracer
+}
+
+type propagatingKey struct{}
+
+func (*propagatingTracer) Inject(ctx context.Context, carrier Carrier) {
+	if val, ok := ctx.Value(propagatingKey{}).(string); ok {
+		carrier.Set("x-testing", val)
+	}
+}
+
+func (*propagatingTracer) Extract(ctx context.Context, carrier Carrier) context.Context {
+	return context.WithValue(ctx, propagatingKey{}, carrier.Get("x-testing"))
+}
+
+func TestInject(t *testing.T) {
+	t.Run("NoopTracer", func(t *testing.T) {
+		SetGlobalTracer(new(NoopTracer))
+
+		carrier := MapCarrier{}
+		Inject(context.Background(), carrier)
+		if len(carrier) != 0 {
+			t.Errorf("expected empty carrier but got %v", carrier)
+		}
+	})
+
+	t.Run("Propagator", func(t *testing.T) {
+		SetGlobalTracer(&propagatingTracer{})
+		defer SetGlobalTracer(new(NoopTracer))
+
+		carrier := MapCarrier{}
+		ctx := context.WithValue(context.Background(), propagatingKey{}, "hello")
+		Inject(ctx, carrier)
+		if carrier.Get("x-testing") != "hello" {
+			t.Errorf("expected carrier to be injected but got %v", carrier)
+		}
+	})
+}
+
+func TestExtract(t *testing.T) {
+	t.Run("NoopTracer", func(t *testing.T) {
+		SetGlobalTracer(new(NoopTracer))
+
+		ctx := context.Background()
+		if got := Extract(ctx, MapCarrier{"x-testing": "hello"}); got != ctx {
+			t.Error("expected the same context to be returned")
+		}
+	})
+
+	t.Run("Propagator", func(t *testing.T) {
+		SetGlobalTracer(&propagatingTracer{})
+		defer SetGlobalTracer(new(NoopTracer))
+
+		ctx := Extract(context.Background(), MapCarrier{"x-testing": "hello"})
+		if val, _ := ctx.Value(propagatingKey{}).(string); val != "hello" {
+			t.Errorf("expected extracted value to be hello but got %s", val)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package tracer
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// SamplingDecision determines whether a span is recorded and exported.
+type SamplingDecision int8
+
+const (
+	// Drop determines that the span is neither recorded nor exported.
+	Drop SamplingDecision = 0
+	// RecordAndSample determines that the span is recorded and exported.
+	RecordAndSample SamplingDecision = 1
+)
+
+// SamplingParameters holds the information available to a Sampler when
+// deciding whether a span should be sampled. Tracer implementations fill
+// in as much as their backend exposes at the time of the decision.
+type SamplingParameters struct {
+	// TraceID is the trace id of the span to be started. It may be empty
+	// when the backend only generates the id after sampling.
+	TraceID string
+
+	// Name is the name of the span to be started.
+	Name string
+
+	// SpanType is the type of the span to be started.
+	SpanType SpanType
+
+	// SpanLayer is the layer of the span to be started.
+	SpanLayer SpanLayer
+
+	// HasParent reports whether the span to be started has a parent,
+	// either local or extracted from a remote process.
+	HasParent bool
+
+	// ParentSampled reports whether the parent span was sampled.
+	ParentSampled bool
+}
+
+// Sampler decides whether a span should be recorded and exported.
+type Sampler interface {
+	// ShouldSample returns the SamplingDecision for a span to be started.
+	ShouldSample(params SamplingParameters) SamplingDecision
+
+	// Description returns the name of the sampler.
+	Description() string
+}
+
+type alwaysSampler struct{}
+
+// AlwaysSample returns a Sampler that samples every span.
+func AlwaysSample() Sampler { return alwaysSampler{} }
+
+func (alwaysSampler) ShouldSample(_ SamplingParameters) SamplingDecision {
+	return RecordAndSample
+}
+
+func (alwaysSampler) Description() string { return "AlwaysOnSampler" }
+
+type neverSampler struct{}
+
+// NeverSample returns a Sampler that samples no span.
+func NeverSample() Sampler { return neverSampler{} }
+
+func (neverSampler) ShouldSample(_ SamplingParameters) SamplingDecision {
+	return Drop
+}
+
+func (neverSampler) Description() string { return "AlwaysOffSampler" }
+
+type traceIDRatioSampler struct {
+	threshold   uint64
+	description string
+}
+
+// TraceIDRatioBased returns a Sampler that samples the given fraction of
+// traces. The decision is derived from the trace id, so every span of the
+// same trace gets the same decision. Fractions >= 1 always sample and
+// fractions <= 0 never sample. When the trace id is not available, the
+// decision is made at random with the same probability.
+func TraceIDRatioBased(fraction float64) Sampler {
+	if fraction >= 1 {
+		return AlwaysSample()
+	}
+	if fraction <= 0 {
+		fraction = 0
+	}
+
+	return &traceIDRatioSampler{
+		threshold:   uint64(fraction * (1 << 63)),
+		description: fmt.Sprintf("TraceIDRatioBased{%g}", fraction),
+	}
+}
+
+func (s *traceIDRatioSampler) ShouldSample(params SamplingParameters) SamplingDecision {
+	var x uint64
+	if params.TraceID == "" {
+		x = rand.Uint64() >> 1
+	} else {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(params.TraceID))
+		x = h.Sum64() >> 1
+	}
+
+	if x < s.threshold {
+		return RecordAndSample
+	}
+	return Drop
+}
+
+func (s *traceIDRatioSampler) Description() string { return s.description }
+
+type parentBasedSampler struct {
+	root Sampler
+}
+
+// ParentBased returns a Sampler that follows the decision of the parent
+// span when there is one, and delegates to root for spans without parent.
+func ParentBased(root Sampler) Sampler {
+	if root == nil {
+		root = AlwaysSample()
+	}
+	return &parentBasedSampler{root: root}
+}
+
+func (s *parentBasedSampler) ShouldSample(params SamplingParameters) SamplingDecision {
+	if !params.HasParent {
+		return s.root.ShouldSample(params)
+	}
+	if params.ParentSampled {
+		return RecordAndSample
+	}
+	return Drop
+}
+
+func (s *parentBasedSampler) Description() string {
+	return fmt.Sprintf("ParentBased{root:%s}", s.root.Description())
+}
+
+type rateLimitingSampler struct {
+	mu       sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+
+	// now is swappable for testing purposes.
+	now func() time.Time
+}
+
+// RateLimited returns a Sampler that samples at most spansPerSecond spans
+// every second using a token bucket. A non-positive rate samples nothing.
+func RateLimited(spansPerSecond float64) Sampler {
+	if spansPerSecond <= 0 {
+		return NeverSample()
+	}
+
+	capacity := math.Max(spansPerSecond, 1)
+	return &rateLimitingSampler{
+		rate:     spansPerSecond,
+		capacity: capacity,
+		tokens:   capacity,
+		last:     time.Now(),
+		now:      time.Now,
+	}
+}
+
+func (s *rateLimitingSampler) ShouldSample(_ SamplingParameters) SamplingDecision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.tokens = math.Min(s.capacity, s.tokens+now.Sub(s.last).Seconds()*s.rate)
+	s.last = now
+
+	if s.tokens < 1 {
+		return Drop
+	}
+	s.tokens--
+	return RecordAndSample
+}
+
+func (s *rateLimitingSampler) Description() string {
+	return fmt.Sprintf("RateLimited{%g}", s.rate)
+}
@@ -0,0 +1,152 @@
+package tracer
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAlwaysSample(t *testing.T) {
+	sampler := AlwaysSample()
+	if sampler.ShouldSample(SamplingParameters{}) != RecordAndSample {
+		t.Error("expected AlwaysSample to sample")
+	}
+	if sampler.Description() != "AlwaysOnSampler" {
+		t.Errorf("unexpected description %s", sampler.Description())
+	}
+}
+
+func TestNeverSample(t *testing.T) {
+	sampler := NeverSample()
+	if sampler.ShouldSample(SamplingParameters{}) != Drop {
+		t.Error("expected NeverSample to drop")
+	}
+	if sampler.Description() != "AlwaysOffSampler" {
+		t.Errorf("unexpected description %s", sampler.Description())
+	}
+}
+
+func TestTraceIDRatioBased(t *testing.T) {
+	t.Run("Full Fraction", func(t *testing.T) {
+		sampler := TraceIDRatioBased(1)
+		if sampler.ShouldSample(SamplingParameters{TraceID: "abc"}) != RecordAndSample {
+			t.Error("expected fraction 1 to always sample")
+		}
+	})
+
+	t.Run("Zero Fraction", func(t *testing.T) {
+		sampler := TraceIDRatioBased(0)
+		for i := 0; i < 100; i++ {
+			params := SamplingParameters{TraceID: strconv.Itoa(i)}
+			if sampler.ShouldSample(params) != Drop {
+				t.Fatal("expected fraction 0 to never sample")
+			}
+		}
+	})
+
+	t.Run("Consistent Decision", func(t *testing.T) {
+		sampler := TraceIDRatioBased(0.5)
+		params := SamplingParameters{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"}
+		decision := sampler.ShouldSample(params)
+		for i := 0; i < 10; i++ {
+			if sampler.ShouldSample(params) != decision {
+				t.Fatal("expected the same trace id to get the same decision")
+			}
+		}
+	})
+
+	t.Run("Approximate Ratio", func(t *testing.T) {
+		sampler := TraceIDRatioBased(0.25)
+		sampled := 0
+		for i := 0; i < 10000; i++ {
+			params := SamplingParameters{TraceID: strconv.Itoa(i)}
+			if sampler.ShouldSample(params) == RecordAndSample {
+				sampled++
+			}
+		}
+		if sampled < 2000 || sampled > 3000 {
+			t.Errorf("expected around 2500 sampled traces but got %d", sampled)
+		}
+	})
+
+	t.Run("Description", func(t *testing.T) {
+		sampler := TraceIDRatioBased(0.25)
+		if sampler.Description() != "TraceIDRatioBased{0.25}" {
+			t.Errorf("unexpected description %s", sampler.Description())
+		}
+	})
+}
+
+func TestParentBased(t *testing.T) {
+	sampler := ParentBased(NeverSample())
+
+	t.Run("Root", func(t *testing.T) {
+		if sampler.ShouldSample(SamplingParameters{}) != Drop {
+			t.Error("expected root span to follow the root sampler")
+		}
+	})
+
+	t.Run("Sampled Parent", func(t *testing.T) {
+		params := SamplingParameters{HasParent: true, ParentSampled: true}
+		if sampler.ShouldSample(params) != RecordAndSample {
+			t.Error("expected span with sampled parent to be sampled")
+		}
+	})
+
+	t.Run("Unsampled Parent", func(t *testing.T) {
+		params := SamplingParameters{HasParent: true}
+		if ParentBased(AlwaysSample()).ShouldSample(params) != Drop {
+			t.Error("expected span with unsampled parent to be dropped")
+		}
+	})
+
+	t.Run("Nil Root", func(t *testing.T) {
+		if ParentBased(nil).ShouldSample(SamplingParameters{}) != RecordAndSample {
+			t.Error("expected nil root to default to AlwaysSample")
+		}
+	})
+
+	t.Run("Description", func(t *testing.T) {
+		if sampler.Description() != "ParentBased{root:AlwaysOffSampler}" {
+			t.Errorf("unexpected description %s", sampler.Description())
+		}
+	})
+}
+
+func TestRateLimited(t *testing.T) {
+	t.Run("Non Positive Rate", func(t *testing.T) {
+		if RateLimited(0).ShouldSample(SamplingParameters{}) != Drop {
+			t.Error("expected non positive rate to never sample")
+		}
+	})
+
+	t.Run("Limits Per Second", func(t *testing.T) {
+		now := time.Now()
+		sampler := RateLimited(2).(*rateLimitingSampler)
+		sampler.last = now
+		sampler.now = func() time.Time { return now }
+
+		for i := 0; i < 2; i++ {
+			if sampler.ShouldSample(SamplingParameters{}) != RecordAndSample {
+				t.Fatalf("expected span %d to be sampled", i)
+			}
+		}
+		if sampler.ShouldSample(SamplingParameters{}) != Drop {
+			t.Error("expected span exceeding the rate to be dropped")
+		}
+
+		now = now.Add(500 * time.Millisecond)
+		if sampler.ShouldSample(SamplingParameters{}) != RecordAndSample {
+			t.Error("expected a token to be refilled after half a second")
+		}
+		if sampler.ShouldSample(SamplingParameters{}) != Drop {
+			t.Error("expected span exceeding the rate to be dropped")
+		}
+	})
+
+	t.Run("Description", func(t *testing.T) {
+		if RateLimited(10).Description() != "RateLimited{10}" {
+			t.Errorf("unexpected description %s", RateLimited(10).Description())
+		}
+	})
+}
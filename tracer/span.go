@@ -12,14 +12,25 @@ type Span interface {
 	// SetAttributes sets attributes to the current span.
 	SetAttributes(attributes ...attribute.KeyValue)
 
-	// RecordError sets a new error to the span.
+	// RecordError sets a new error to the span. It does not change the
+	// status of the span, use SetStatus to mark the span as failed.
 	RecordError(err error)
 
-	// AddEvent adds certain event into the span.
-	AddEvent(descriptions ...string)
+	// SetStatus sets the status of the span. The description is only kept
+	// when the code is StatusError. Once the status is StatusOk, it can no
+	// longer be changed.
+	SetStatus(code StatusCode, description string)
+
+	// AddEvent adds an event with the given name into the span.
+	AddEvent(name string, opts ...EventOption)
 
 	// Context returns the SpanContext of the current span.
 	Context() SpanContext
+
+	// IsRecording reports whether the span is recording information such as
+	// attributes and events. Unsampled and ended spans are not recording, so
+	// expensive attribute building can be skipped for them.
+	IsRecording() bool
 }
 
 type SpanContext interface {
@@ -60,8 +71,34 @@ const (
 	SpanLayerHttp SpanLayer = 2
 	// SpanLayerMQ is a MQ layer and determines running a MQ.
 	SpanLayerMQ SpanLayer = 3
+	// SpanLayerRPC is a RPC layer and determines running a remote procedure call, such as gRPC.
+	SpanLayerRPC SpanLayer = 4
 )
 
+// StatusCode determines the status of a span.
+type StatusCode int32
+
+const (
+	// StatusUnset is the default status of a span.
+	StatusUnset StatusCode = 0
+	// StatusOk determines that the span has been validated to be successful.
+	StatusOk StatusCode = 1
+	// StatusError determines that the span contains an error.
+	StatusError StatusCode = 2
+)
+
+// String returns the name of the StatusCode.
+func (c StatusCode) String() string {
+	switch c {
+	case StatusOk:
+		return "Ok"
+	case StatusError:
+		return "Error"
+	default:
+		return "Unset"
+	}
+}
+
 type StartSpanConfig struct {
 	// TraceID to manually change the implementation's trace id of the current span.
 	TraceID string
@@ -74,6 +111,20 @@ type StartSpanConfig struct {
 
 	// SpanLayer should default to SpanLayerUnknown.
 	SpanLayer SpanLayer
+
+	// Links relates the span to other spans, possibly of other traces.
+	Links []Link
+}
+
+// Link relates a span to another span, for example the span of each
+// message consumed within a batch.
+type Link struct {
+	// Context holds the linked span. It is either a context returned by
+	// Extract or a context of a started span.
+	Context context.Context
+
+	// Attributes describes the link.
+	Attributes []attribute.KeyValue
 }
 
 // StartSpanOption provides options to inject to the span when
@@ -108,6 +159,13 @@ func WithSpanType(t SpanType) StartSpanOption {
 	}
 }
 
+// WithLinks appends the links to the span.
+func WithLinks(links ...Link) StartSpanOption {
+	return func(cfg *StartSpanConfig) {
+		cfg.Links = append(cfg.Links, links...)
+	}
+}
+
 // DefaultStartSpanConfig returns the default StartSpanConfig.
 func DefaultStartSpanConfig() *StartSpanConfig {
 	return &StartSpanConfig{
@@ -1,6 +1,11 @@
 package tracer
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"github.com/rmscoal/tengcorux/tracer/attribute"
+)
 
 func TestDefaultStartSpanConfig(t *testing.T) {
 	defaultStartSpanConfig := DefaultStartSpanConfig()
@@ -76,6 +81,20 @@ func TestStartSpanOption(t *testing.T) {
 		})
 	})
 
+	t.Run("WithLinks", func(t *testing.T) {
+		spanConfig := DefaultStartSpanConfig()
+		WithLinks(Link{Context: context.TODO()})(spanConfig)
+		WithLinks(Link{
+			Context:    context.TODO(),
+			Attributes: []attribute.KeyValue{attribute.KeyValuePair("k", "v")},
+		})(spanConfig)
+		if len(spanConfig.Links) != 2 {
+			t.Errorf("links should have 2 links but got %d", len(spanConfig.Links))
+		} else if len(spanConfig.Links[1].Attributes) != 1 {
+			t.Error("link attributes should be kept")
+		}
+	})
+
 	t.Run("WithSpanType", func(t *testing.T) {
 		t.Run("WithSpanType_SpanTypeLocal", func(t *testing.T) {
 			spanConfig := DefaultStartSpanConfig()
@@ -103,3 +122,17 @@ func TestStartSpanOption(t *testing.T) {
 		})
 	})
 }
+
+func TestStatusCode_String(t *testing.T) {
+	tests := map[StatusCode]string{
+		StatusUnset:    "Unset",
+		StatusOk:       "Ok",
+		StatusError:    "Error",
+		StatusCode(10): "Unset",
+	}
+	for code, expected := range tests {
+		if code.String() != expected {
+			t.Errorf("expected %s but got %s", expected, code.String())
+		}
+	}
+}
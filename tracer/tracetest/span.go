@@ -2,6 +2,7 @@ package tracetest
 
 import (
 	"context"
+	"runtime/debug"
 	"strconv"
 	"time"
 
@@ -12,7 +13,8 @@ import (
 type Span struct {
 	StartTime    time.Time
 	EndTime      time.Time
-	Events       []string
+	Events       []Event
+	Links        []Link
 	Attributes   []attribute.KeyValue
 	Name         string
 	TraceID      uint64
@@ -22,8 +24,21 @@ type Span struct {
 	Type         tengcoruxTracer.SpanType
 	Error        error
 
+	// Status and StatusDescription are set through SetStatus.
+	Status            tengcoruxTracer.StatusCode
+	StatusDescription string
+
+	// DroppedAttributes and DroppedEvents count the attributes and events
+	// dropped by the span limits of the tracer.
+	DroppedAttributes int
+	DroppedEvents     int
+
 	tracer      *Tracer
 	spanContext *SpanContext
+	limiter     *tengcoruxTracer.SpanLimiter
+
+	// dropped marks that the span is not sampled, hence never recorded.
+	dropped bool
 }
 
 // End ends the span by marking the EndTime as now as well as
@@ -34,11 +49,24 @@ func (s *Span) End() {
 		return
 	}
 	s.EndTime = time.Now()
-	s.tracer.recorder.OnEnd(s)
+	if !s.dropped {
+		s.tracer.recorder.OnEnd(s)
+	}
 }
 
-// SetAttributes appends the given attributes into the Attributes slice.
+// Discard ends the span without handing it over to the recorder.
+func (s *Span) Discard() {
+	if !s.EndTime.IsZero() {
+		return
+	}
+	s.EndTime = time.Now()
+}
+
+// SetAttributes appends the given attributes into the Attributes slice,
+// within the span limits of the tracer.
 func (s *Span) SetAttributes(kv ...attribute.KeyValue) {
+	kv = s.limiter.LimitAttributes(kv...)
+	s.DroppedAttributes = s.limiter.DroppedAttributes()
 	s.Attributes = append(s.Attributes, kv...)
 }
 
@@ -47,9 +75,46 @@ func (s *Span) RecordError(err error) {
 	s.Error = err
 }
 
-// AddEvent appends the string of events into the Events slice.
-func (s *Span) AddEvent(events ...string) {
-	s.Events = append(s.Events, events...)
+// SetStatus sets the Status of the test span. The description is only kept
+// for StatusError and a StatusOk status can no longer be changed.
+func (s *Span) SetStatus(code tengcoruxTracer.StatusCode, description string) {
+	if code == tengcoruxTracer.StatusUnset || s.Status == tengcoruxTracer.StatusOk {
+		return
+	}
+
+	s.Status = code
+	s.StatusDescription = ""
+	if code == tengcoruxTracer.StatusError {
+		s.StatusDescription = description
+	}
+}
+
+// AddEvent appends the event into the Events slice, within the span
+// limits of the tracer.
+func (s *Span) AddEvent(name string, opts ...tengcoruxTracer.EventOption) {
+	cfg := tengcoruxTracer.NewEventConfig(opts...)
+
+	attributes, ok := s.limiter.LimitEvent(cfg.Attributes)
+	s.DroppedEvents = s.limiter.DroppedEvents()
+	if !ok {
+		return
+	}
+
+	event := Event{
+		Name:       name,
+		Attributes: attributes,
+		Timestamp:  cfg.Timestamp,
+	}
+	if cfg.StackTrace {
+		event.StackTrace = string(debug.Stack())
+	}
+
+	s.Events = append(s.Events, event)
+}
+
+// IsRecording returns true if the span is sampled and has not ended.
+func (s *Span) IsRecording() bool {
+	return !s.dropped && s.EndTime.IsZero()
 }
 
 // Context returns SpanContext.
@@ -57,18 +122,69 @@ func (s *Span) Context() tengcoruxTracer.SpanContext {
 	return s.spanContext
 }
 
+// Event is an event added to a test span.
+type Event struct {
+	Name       string
+	Attributes []attribute.KeyValue
+	Timestamp  time.Time
+	// StackTrace is only filled when the event was added with
+	// tengcoruxTracer.WithStackTrace.
+	StackTrace string
+}
+
+// Attribute returns the value set for the given key and whether the event
+// has it.
+func (e Event) Attribute(key attribute.Key) (attribute.Value, bool) {
+	return lookupAttribute(e.Attributes, key)
+}
+
+// Link is a link of a test span to another span.
+type Link struct {
+	TraceID    uint64
+	SpanID     uint64
+	Attributes []attribute.KeyValue
+}
+
 // ReadWriteSpan allows the span to be read and written.
 type ReadWriteSpan Span
 
 // ReadOnlySpan only allows the span to be read.
 type ReadOnlySpan Span
 
+// Attribute returns the last value set for the given key and whether the
+// span has it.
+func (s *ReadOnlySpan) Attribute(key attribute.Key) (attribute.Value, bool) {
+	return lookupAttribute(s.Attributes, key)
+}
+
+// lookupAttribute returns the last value of the given key in attrs.
+func lookupAttribute(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for i := len(attrs) - 1; i >= 0; i-- {
+		if attrs[i].Key == key {
+			return attrs[i].Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
 type prevSpanContextKey struct{}
 
 // prevSpanKey is the key that holds the *Span value inside a context
 // carried along during the transaction.
 var prevSpanKey prevSpanContextKey
 
+type remoteSpanContextKey struct{}
+
+// remoteSpanKey is the key that holds the remoteSpanContext value
+// extracted from a carrier.
+var remoteSpanKey remoteSpanContextKey
+
+// remoteSpanContext holds the trace id and span id extracted from a carrier.
+type remoteSpanContext struct {
+	traceID uint64
+	spanID  uint64
+}
+
 // SpanContext stores Go context.
 type SpanContext struct {
 	ctx context.Context
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/rmscoal/tengcorux/tracer"
 	"github.com/rmscoal/tengcorux/tracer/attribute"
 )
 
@@ -38,6 +39,19 @@ func TestSpan_End(t *testing.T) {
 
 }
 
+func TestSpan_Discard(t *testing.T) {
+	tr := NewTracer()
+	_, span := tr.StartSpan(context.Background(), "discarded")
+	span.(tracer.DiscardableSpan).Discard()
+
+	if span.IsRecording() {
+		t.Error("discarded span should not be recording")
+	}
+	if len(tr.Recorder().EndedSpans()) != 0 {
+		t.Error("discarded span should not be recorded")
+	}
+}
+
 func TestSpan_SetAttributes(t *testing.T) {
 	span := &Span{}
 	span.SetAttributes(
@@ -53,12 +67,12 @@ func TestSpan_SetAttributes(t *testing.T) {
 	for _, kv := range span.Attributes {
 		switch kv.Key {
 		case "Hello1":
-			if kv.Value != "World1" {
+			if kv.Value.AsString() != "World1" {
 				t.Errorf("expected attribute value to be \"World1\", but got %s",
 					kv.Value)
 			}
 		case "Hello2":
-			if kv.Value != "World2" {
+			if kv.Value.AsString() != "World2" {
 				t.Errorf("expected attribute value to be \"World2\", but got %s",
 					kv.Value)
 			}
@@ -68,21 +82,102 @@ func TestSpan_SetAttributes(t *testing.T) {
 	}
 }
 
+func TestReadOnlySpan_Attribute(t *testing.T) {
+	span := &Span{}
+	span.SetAttributes(
+		attribute.KeyValuePair("Hello", "World1"),
+		attribute.KeyValuePair("Hello", "World2"),
+	)
+	readOnly := ReadOnlySpan(*span)
+
+	if got, ok := readOnly.Attribute("Hello"); !ok || got.AsString() != "World2" {
+		t.Errorf("expected the last value \"World2\", but got %s", got)
+	}
+	if _, ok := readOnly.Attribute("Unknown"); ok {
+		t.Error("expected unknown attribute to be missing")
+	}
+}
+
+func TestEvent_Attribute(t *testing.T) {
+	event := Event{Attributes: []attribute.KeyValue{
+		attribute.KeyValuePair("Hello", "World"),
+	}}
+
+	if got, ok := event.Attribute("Hello"); !ok || got.AsString() != "World" {
+		t.Errorf("expected \"World\", but got %s", got)
+	}
+	if _, ok := event.Attribute("Unknown"); ok {
+		t.Error("expected unknown attribute to be missing")
+	}
+}
+
 func TestSpan_AddEvent(t *testing.T) {
 	span := &Span{}
 	span.AddEvent("some event is happening here")
-	span.AddEvent("another event is happening here")
+
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	span.AddEvent("another event is happening here",
+		tracer.WithEventAttributes(attribute.KeyValuePair("Hello", "World")),
+		tracer.WithTimestamp(timestamp),
+		tracer.WithStackTrace(),
+	)
 
 	if len(span.Events) != 2 {
-		t.Errorf("expected span to have 2 events, but got %d", len(span.Events))
+		t.Fatalf("expected span to have 2 events, but got %d", len(span.Events))
 	}
 
-	for _, ev := range span.Events {
-		switch ev {
-		case "some event is happening here", "another event is happening here":
-		default:
-			t.Errorf("unknown event: %s", ev)
-		}
+	first := span.Events[0]
+	if first.Name != "some event is happening here" {
+		t.Errorf("unknown event: %s", first.Name)
+	} else if first.Timestamp.IsZero() {
+		t.Error("expected event timestamp to default to now")
+	} else if first.StackTrace != "" {
+		t.Error("expected event to not capture stack trace")
+	}
+
+	second := span.Events[1]
+	if second.Name != "another event is happening here" {
+		t.Errorf("unknown event: %s", second.Name)
+	} else if !second.Timestamp.Equal(timestamp) {
+		t.Errorf("expected event timestamp to be %s, but got %s", timestamp,
+			second.Timestamp)
+	} else if second.StackTrace == "" {
+		t.Error("expected event to capture stack trace")
+	}
+	if len(second.Attributes) != 1 || second.Attributes[0].Value.AsString() != "World" {
+		t.Errorf("unexpected event attributes: %v", second.Attributes)
+	}
+}
+
+func TestSpan_Limits(t *testing.T) {
+	tr := NewTracer(WithSpanLimits(tracer.SpanLimits{
+		AttributeCountLimit:       2,
+		AttributeValueLengthLimit: 4,
+		EventCountLimit:           1,
+	}))
+	_, span := tr.StartSpan(context.Background(), "span")
+
+	span.SetAttributes(
+		attribute.HTTPRequestBody("some long body"),
+		attribute.String("a", "a"),
+		attribute.String("b", "b"),
+	)
+	span.AddEvent("first")
+	span.AddEvent("second")
+	span.End()
+
+	ended := tr.Recorder().EndedSpans()[0]
+	if len(ended.Attributes) != 2 {
+		t.Errorf("expected 2 attributes, got %v", ended.Attributes)
+	} else if got := ended.Attributes[0].Value.AsString(); got != "some"+tracer.TruncationMarker {
+		t.Errorf("expected the body to be truncated, got %s", got)
+	}
+	if ended.DroppedAttributes != 1 {
+		t.Errorf("expected 1 dropped attribute, got %d", ended.DroppedAttributes)
+	}
+	if len(ended.Events) != 1 || ended.DroppedEvents != 1 {
+		t.Errorf("expected 1 event and 1 dropped event, got %d and %d",
+			len(ended.Events), ended.DroppedEvents)
 	}
 }
 
@@ -97,6 +192,48 @@ func TestSpan_RecordError(t *testing.T) {
 	}
 }
 
+func TestSpan_SetStatus(t *testing.T) {
+	t.Run("Error", func(t *testing.T) {
+		span := &Span{}
+		span.SetStatus(tracer.StatusError, "some_error")
+		if span.Status != tracer.StatusError {
+			t.Errorf("expected status to be Error, got %s", span.Status)
+		} else if span.StatusDescription != "some_error" {
+			t.Errorf("expected description to be some_error, got %s",
+				span.StatusDescription)
+		}
+
+		span.SetStatus(tracer.StatusUnset, "")
+		if span.Status != tracer.StatusError {
+			t.Errorf("expected status to remain Error, got %s", span.Status)
+		}
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		span := &Span{}
+		span.SetStatus(tracer.StatusOk, "ignored")
+		if span.Status != tracer.StatusOk {
+			t.Errorf("expected status to be Ok, got %s", span.Status)
+		} else if span.StatusDescription != "" {
+			t.Errorf("expected empty description, got %s", span.StatusDescription)
+		}
+
+		span.SetStatus(tracer.StatusError, "some_error")
+		if span.Status != tracer.StatusOk {
+			t.Errorf("expected status to remain Ok, got %s", span.Status)
+		}
+	})
+
+	t.Run("RecordError", func(t *testing.T) {
+		span := &Span{}
+		span.RecordError(errors.New("some_error"))
+		if span.Status != tracer.StatusUnset {
+			t.Errorf("expected RecordError to leave status Unset, got %s",
+				span.Status)
+		}
+	})
+}
+
 func TestSpan_Context(t *testing.T) {
 	span := &Span{
 		spanContext: &SpanContext{
@@ -109,6 +246,26 @@ func TestSpan_Context(t *testing.T) {
 	}
 }
 
+func TestSpan_IsRecording(t *testing.T) {
+	t.Run("Started Span", func(t *testing.T) {
+		if !(&Span{}).IsRecording() {
+			t.Error("expected started span to be recording")
+		}
+	})
+
+	t.Run("Ended Span", func(t *testing.T) {
+		if (&Span{EndTime: time.Now()}).IsRecording() {
+			t.Error("expected ended span to not be recording")
+		}
+	})
+
+	t.Run("Dropped Span", func(t *testing.T) {
+		if (&Span{dropped: true}).IsRecording() {
+			t.Error("expected dropped span to not be recording")
+		}
+	})
+}
+
 func TestSpanContext_Context(t *testing.T) {
 	tracer := NewTracer()
 	_, span := tracer.StartSpan(context.Background(), "hello")
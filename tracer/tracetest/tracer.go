@@ -2,6 +2,7 @@ package tracetest
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	tengcoruxTracer "github.com/rmscoal/tengcorux/tracer"
@@ -9,20 +10,59 @@ import (
 
 type Tracer struct {
 	recorder *SpanRecorder
+	sampler  tengcoruxTracer.Sampler
+	limits   *tengcoruxTracer.SpanLimits
 }
 
-// Checks if our test tracer implements tengcorux tracer interface.
-var _ tengcoruxTracer.Tracer = (*Tracer)(nil)
+// Checks if our test tracer implements tengcorux tracer and propagator interface.
+var (
+	_ tengcoruxTracer.Tracer     = (*Tracer)(nil)
+	_ tengcoruxTracer.Propagator = (*Tracer)(nil)
+)
+
+const (
+	// TraceIDKey is the carrier key holding the propagated trace id.
+	TraceIDKey = "tracetest-trace-id"
+	// SpanIDKey is the carrier key holding the propagated span id.
+	SpanIDKey = "tracetest-span-id"
+)
+
+// Option configures the test tracer.
+type Option func(*Tracer)
+
+// WithSampler sets the sampler deciding which spans are recorded.
+// Defaults to [tengcoruxTracer.AlwaysSample].
+func WithSampler(sampler tengcoruxTracer.Sampler) Option {
+	return func(t *Tracer) {
+		if sampler != nil {
+			t.sampler = sampler
+		}
+	}
+}
+
+// WithSpanLimits sets the limits enforced on the spans of the tracer.
+// Defaults to the global [tengcoruxTracer.GetSpanLimits] at span start.
+func WithSpanLimits(limits tengcoruxTracer.SpanLimits) Option {
+	return func(t *Tracer) {
+		t.limits = &limits
+	}
+}
 
 // NewTracer returns a test trace instance with a new span recorder.
-func NewTracer() *Tracer {
-	return &Tracer{
+func NewTracer(opts ...Option) *Tracer {
+	t := &Tracer{
 		recorder: NewSpanRecorder(),
+		sampler:  tengcoruxTracer.AlwaysSample(),
+	}
+	for _, opt := range opts {
+		opt(t)
 	}
+	return t
 }
 
 // StartSpan starts a test span and insert the span value into the context.
-// It also inserts the span into the recorder slice of spans.
+// It also inserts the span into the recorder slice of spans, unless the
+// span is dropped by the tracer's sampler.
 func (t *Tracer) StartSpan(ctx context.Context, name string, opts ...tengcoruxTracer.StartSpanOption) (context.Context, tengcoruxTracer.Span) {
 	spanConfig := tengcoruxTracer.DefaultStartSpanConfig()
 	for _, opt := range opts {
@@ -37,24 +77,60 @@ func (t *Tracer) StartSpan(ctx context.Context, name string, opts ...tengcoruxTr
 		Layer:     spanConfig.SpanLayer,
 		Type:      spanConfig.SpanType,
 		tracer:    t,
+		limiter:   tengcoruxTracer.NewSpanLimiter(t.spanLimits()),
 	}
 
 	// Search for the previous span in the context and adjust values
 	// for current span if found.
+	params := tengcoruxTracer.SamplingParameters{
+		Name:      name,
+		SpanType:  spanConfig.SpanType,
+		SpanLayer: spanConfig.SpanLayer,
+	}
 	prevSpan, exists := ctx.Value(prevSpanKey).(*Span)
 	if exists && prevSpan != nil {
 		span.TraceID = prevSpan.TraceID
 		span.ParentSpanID = prevSpan.SpanID
+		params.HasParent = true
+		params.ParentSampled = !prevSpan.dropped
+	} else if remote, ok := ctx.Value(remoteSpanKey).(remoteSpanContext); ok {
+		span.TraceID = remote.traceID
+		span.ParentSpanID = remote.spanID
+		params.HasParent = true
+		params.ParentSampled = true
 	}
 
+	for _, link := range spanConfig.Links {
+		if traceID, spanID, ok := spanContextFromContext(link.Context); ok {
+			span.Links = append(span.Links, Link{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				Attributes: link.Attributes,
+			})
+		}
+	}
+
+	params.TraceID = strconv.FormatUint(span.TraceID, 10)
+	span.dropped = t.sampler.ShouldSample(params) == tengcoruxTracer.Drop
+
 	// Replaces the context's prevSpanKey with the current span.
 	ctx = context.WithValue(ctx, prevSpanKey, span)
 	span.spanContext = &SpanContext{ctx: ctx}
-	t.recorder.OnStart(span)
+	if !span.dropped {
+		t.recorder.OnStart(span)
+	}
 
 	return ctx, span
 }
 
+// spanLimits returns the limits of the tracer, else the global ones.
+func (t *Tracer) spanLimits() tengcoruxTracer.SpanLimits {
+	if t.limits != nil {
+		return *t.limits
+	}
+	return tengcoruxTracer.GetSpanLimits()
+}
+
 // Shutdown does nothing and returns nil.
 func (t *Tracer) Shutdown(_ context.Context) error { return nil }
 
@@ -73,6 +149,48 @@ func (t *Tracer) SpanFromContext(ctx context.Context) tengcoruxTracer.Span {
 	return span
 }
 
+// Inject writes the trace id and span id of the span found in the context
+// into the carrier as decimal strings.
+func (t *Tracer) Inject(ctx context.Context, carrier tengcoruxTracer.Carrier) {
+	span, ok := ctx.Value(prevSpanKey).(*Span)
+	if !ok || span == nil {
+		return
+	}
+
+	carrier.Set(TraceIDKey, strconv.FormatUint(span.TraceID, 10))
+	carrier.Set(SpanIDKey, strconv.FormatUint(span.SpanID, 10))
+}
+
+// Extract reads the trace id and span id from the carrier and stores them
+// in the returned context, such that the next started span continues them.
+func (t *Tracer) Extract(ctx context.Context, carrier tengcoruxTracer.Carrier) context.Context {
+	traceID, err := strconv.ParseUint(carrier.Get(TraceIDKey), 10, 64)
+	if err != nil || traceID == 0 {
+		return ctx
+	}
+	spanID, _ := strconv.ParseUint(carrier.Get(SpanIDKey), 10, 64)
+
+	return context.WithValue(ctx, remoteSpanKey, remoteSpanContext{
+		traceID: traceID,
+		spanID:  spanID,
+	})
+}
+
+// spanContextFromContext returns the trace id and span id of the span found
+// in the context, or else of the remote span extracted into the context.
+func spanContextFromContext(ctx context.Context) (uint64, uint64, bool) {
+	if ctx == nil {
+		return 0, 0, false
+	}
+	if span, ok := ctx.Value(prevSpanKey).(*Span); ok && span != nil {
+		return span.TraceID, span.SpanID, true
+	}
+	if remote, ok := ctx.Value(remoteSpanKey).(remoteSpanContext); ok {
+		return remote.traceID, remote.spanID, true
+	}
+	return 0, 0, false
+}
+
 // Recorder returns tracer's recorder helping on retrieving the generated spans.
 func (t *Tracer) Recorder() *SpanRecorder {
 	return t.recorder
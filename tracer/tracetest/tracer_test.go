@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/rmscoal/tengcorux/tracer"
+	"github.com/rmscoal/tengcorux/tracer/attribute"
 )
 
 func TestTracer_StartSpan(t *testing.T) {
@@ -96,6 +97,89 @@ func TestTracer_StartSpan(t *testing.T) {
 	})
 }
 
+func TestTracer_StartSpan_Links(t *testing.T) {
+	tr := NewTracer()
+
+	localCtx, local := tr.StartSpan(context.TODO(), "local")
+	remoteCtx := tr.Extract(context.TODO(), tracer.MapCarrier{
+		TraceIDKey: "1",
+		SpanIDKey:  "2",
+	})
+
+	_, span := tr.StartSpan(context.TODO(), "batch",
+		tracer.WithLinks(
+			tracer.Link{Context: localCtx},
+			tracer.Link{
+				Context: remoteCtx,
+				Attributes: []attribute.KeyValue{
+					attribute.KeyValuePair("messaging.message.id", "abc"),
+				},
+			},
+			tracer.Link{Context: context.TODO()},
+		),
+	)
+
+	links := span.(*Span).Links
+	if len(links) != 2 {
+		t.Fatalf("expected 2 links but got %d", len(links))
+	}
+	if links[0].TraceID != local.(*Span).TraceID ||
+		links[0].SpanID != local.(*Span).SpanID {
+		t.Errorf("expected first link to refer the local span but got %+v",
+			links[0])
+	}
+	if links[1].TraceID != 1 || links[1].SpanID != 2 {
+		t.Errorf("expected second link to refer the remote span but got %+v",
+			links[1])
+	} else if len(links[1].Attributes) != 1 {
+		t.Errorf("expected link attributes to be kept but got %v",
+			links[1].Attributes)
+	}
+	if span.(*Span).TraceID == 1 || span.(*Span).ParentSpanID != 0 {
+		t.Error("expected links to not change the parent of the span")
+	}
+}
+
+func TestTracer_StartSpan_Sampler(t *testing.T) {
+	t.Run("Dropped Span", func(t *testing.T) {
+		tr := NewTracer(WithSampler(tracer.NeverSample()))
+		_, span := tr.StartSpan(context.TODO(), "dropped")
+		span.End()
+
+		if span.IsRecording() {
+			t.Error("expected dropped span to not be recording")
+		}
+		if len(tr.Recorder().StartedSpans()) != 0 {
+			t.Error("expected started spans to be empty")
+		} else if len(tr.Recorder().EndedSpans()) != 0 {
+			t.Error("expected ended spans to be empty")
+		}
+	})
+
+	t.Run("Parent Based", func(t *testing.T) {
+		tr := NewTracer(WithSampler(tracer.ParentBased(tracer.NeverSample())))
+		ctx, parent := tr.StartSpan(context.TODO(), "parent")
+		_, child := tr.StartSpan(ctx, "child")
+
+		if parent.IsRecording() || child.IsRecording() {
+			t.Error("expected child of dropped parent to not be recording")
+		}
+
+		remoteCtx := tr.Extract(context.TODO(), tracer.MapCarrier{
+			TraceIDKey: "1",
+			SpanIDKey:  "2",
+		})
+		_, remote := tr.StartSpan(remoteCtx, "remote")
+		if !remote.IsRecording() {
+			t.Error("expected child of remote parent to be recording")
+		}
+		if len(tr.Recorder().StartedSpans()) != 1 {
+			t.Errorf("expected 1 started span but got %d",
+				len(tr.Recorder().StartedSpans()))
+		}
+	})
+}
+
 func TestTracer_Shutdown(t *testing.T) {
 	tr := NewTracer()
 	err := tr.Shutdown(context.Background())
@@ -147,6 +231,60 @@ func TestTracer_SpanFromContext(t *testing.T) {
 	})
 }
 
+func TestTracer_Inject(t *testing.T) {
+	tr := NewTracer()
+
+	t.Run("Empty Context", func(t *testing.T) {
+		carrier := tracer.MapCarrier{}
+		tr.Inject(context.TODO(), carrier)
+		if len(carrier) != 0 {
+			t.Fatalf("expected empty carrier but got %v", carrier)
+		}
+	})
+
+	t.Run("From Span Context", func(t *testing.T) {
+		ctx, span := tr.StartSpan(context.TODO(), "test")
+		carrier := tracer.MapCarrier{}
+		tr.Inject(ctx, carrier)
+
+		if carrier.Get(TraceIDKey) != span.Context().TraceID() {
+			t.Fatalf("expected trace id %s but got %s",
+				span.Context().TraceID(), carrier.Get(TraceIDKey))
+		} else if carrier.Get(SpanIDKey) != span.Context().SpanID() {
+			t.Fatalf("expected span id %s but got %s",
+				span.Context().SpanID(), carrier.Get(SpanIDKey))
+		}
+	})
+}
+
+func TestTracer_Extract(t *testing.T) {
+	tr := NewTracer()
+
+	t.Run("Empty Carrier", func(t *testing.T) {
+		ctx := tr.Extract(context.TODO(), tracer.MapCarrier{})
+		_, span := tr.StartSpan(ctx, "test")
+		if span.(*Span).ParentSpanID != 0 {
+			t.Fatal("expected zero ParentSpanID")
+		}
+	})
+
+	t.Run("Continue Remote Span", func(t *testing.T) {
+		ctx := tr.Extract(context.TODO(), tracer.MapCarrier{
+			TraceIDKey: "12345",
+			SpanIDKey:  "678",
+		})
+		_, span := tr.StartSpan(ctx, "test")
+
+		testSpan := span.(*Span)
+		if testSpan.TraceID != 12345 {
+			t.Fatalf("expected trace id 12345 but got %d", testSpan.TraceID)
+		} else if testSpan.ParentSpanID != 678 {
+			t.Fatalf("expected parent span id 678 but got %d",
+				testSpan.ParentSpanID)
+		}
+	})
+}
+
 func TestTracer_Recorder(t *testing.T) {
 	tr := NewTracer()
 	rec := tr.Recorder()